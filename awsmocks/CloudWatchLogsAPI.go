@@ -2,11 +2,10 @@
 
 package awsmocks
 
-import aws "github.com/aws/aws-sdk-go/aws"
 import cloudwatchlogs "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
-
-import mock "github.com/stretchr/testify/mock"
 import request "github.com/aws/aws-sdk-go/aws/request"
+import aws "github.com/aws/aws-sdk-go/aws"
+import mock "github.com/stretchr/testify/mock"
 
 // CloudWatchLogsAPI is an autogenerated mock type for the CloudWatchLogsAPI type
 type CloudWatchLogsAPI struct {
@@ -2,11 +2,10 @@
 
 package awsmocks
 
-import aws "github.com/aws/aws-sdk-go/aws"
 import codebuild "github.com/aws/aws-sdk-go/service/codebuild"
-
-import mock "github.com/stretchr/testify/mock"
 import request "github.com/aws/aws-sdk-go/aws/request"
+import aws "github.com/aws/aws-sdk-go/aws"
+import mock "github.com/stretchr/testify/mock"
 
 // CodeBuildAPI is an autogenerated mock type for the CodeBuildAPI type
 type CodeBuildAPI struct {
@@ -247,6 +246,162 @@ func (_m *CodeBuildAPI) BatchGetProjectsWithContext(_a0 aws.Context, _a1 *codebu
 	return r0, r1
 }
 
+// BatchGetReportGroups provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) BatchGetReportGroups(_a0 *codebuild.BatchGetReportGroupsInput) (*codebuild.BatchGetReportGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.BatchGetReportGroupsOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.BatchGetReportGroupsInput) *codebuild.BatchGetReportGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.BatchGetReportGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.BatchGetReportGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchGetReportGroupsRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) BatchGetReportGroupsRequest(_a0 *codebuild.BatchGetReportGroupsInput) (*request.Request, *codebuild.BatchGetReportGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.BatchGetReportGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.BatchGetReportGroupsOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.BatchGetReportGroupsInput) *codebuild.BatchGetReportGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.BatchGetReportGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// BatchGetReportGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) BatchGetReportGroupsWithContext(_a0 aws.Context, _a1 *codebuild.BatchGetReportGroupsInput, _a2 ...request.Option) (*codebuild.BatchGetReportGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.BatchGetReportGroupsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.BatchGetReportGroupsInput, ...request.Option) *codebuild.BatchGetReportGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.BatchGetReportGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.BatchGetReportGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchGetReports provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) BatchGetReports(_a0 *codebuild.BatchGetReportsInput) (*codebuild.BatchGetReportsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.BatchGetReportsOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.BatchGetReportsInput) *codebuild.BatchGetReportsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.BatchGetReportsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.BatchGetReportsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchGetReportsRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) BatchGetReportsRequest(_a0 *codebuild.BatchGetReportsInput) (*request.Request, *codebuild.BatchGetReportsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.BatchGetReportsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.BatchGetReportsOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.BatchGetReportsInput) *codebuild.BatchGetReportsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.BatchGetReportsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// BatchGetReportsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) BatchGetReportsWithContext(_a0 aws.Context, _a1 *codebuild.BatchGetReportsInput, _a2 ...request.Option) (*codebuild.BatchGetReportsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.BatchGetReportsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.BatchGetReportsInput, ...request.Option) *codebuild.BatchGetReportsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.BatchGetReportsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.BatchGetReportsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateProject provides a mock function with given fields: _a0
 func (_m *CodeBuildAPI) CreateProject(_a0 *codebuild.CreateProjectInput) (*codebuild.CreateProjectOutput, error) {
 	ret := _m.Called(_a0)
@@ -325,6 +480,84 @@ func (_m *CodeBuildAPI) CreateProjectWithContext(_a0 aws.Context, _a1 *codebuild
 	return r0, r1
 }
 
+// CreateReportGroup provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) CreateReportGroup(_a0 *codebuild.CreateReportGroupInput) (*codebuild.CreateReportGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.CreateReportGroupOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.CreateReportGroupInput) *codebuild.CreateReportGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.CreateReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.CreateReportGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReportGroupRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) CreateReportGroupRequest(_a0 *codebuild.CreateReportGroupInput) (*request.Request, *codebuild.CreateReportGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.CreateReportGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.CreateReportGroupOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.CreateReportGroupInput) *codebuild.CreateReportGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.CreateReportGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateReportGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) CreateReportGroupWithContext(_a0 aws.Context, _a1 *codebuild.CreateReportGroupInput, _a2 ...request.Option) (*codebuild.CreateReportGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.CreateReportGroupOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.CreateReportGroupInput, ...request.Option) *codebuild.CreateReportGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.CreateReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.CreateReportGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateWebhook provides a mock function with given fields: _a0
 func (_m *CodeBuildAPI) CreateWebhook(_a0 *codebuild.CreateWebhookInput) (*codebuild.CreateWebhookOutput, error) {
 	ret := _m.Called(_a0)
@@ -481,6 +714,162 @@ func (_m *CodeBuildAPI) DeleteProjectWithContext(_a0 aws.Context, _a1 *codebuild
 	return r0, r1
 }
 
+// DeleteReport provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DeleteReport(_a0 *codebuild.DeleteReportInput) (*codebuild.DeleteReportOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.DeleteReportOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteReportInput) *codebuild.DeleteReportOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteReportOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteReportInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReportGroup provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DeleteReportGroup(_a0 *codebuild.DeleteReportGroupInput) (*codebuild.DeleteReportGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.DeleteReportGroupOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteReportGroupInput) *codebuild.DeleteReportGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteReportGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReportGroupRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DeleteReportGroupRequest(_a0 *codebuild.DeleteReportGroupInput) (*request.Request, *codebuild.DeleteReportGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteReportGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.DeleteReportGroupOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteReportGroupInput) *codebuild.DeleteReportGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.DeleteReportGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteReportGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) DeleteReportGroupWithContext(_a0 aws.Context, _a1 *codebuild.DeleteReportGroupInput, _a2 ...request.Option) (*codebuild.DeleteReportGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.DeleteReportGroupOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.DeleteReportGroupInput, ...request.Option) *codebuild.DeleteReportGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.DeleteReportGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReportRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DeleteReportRequest(_a0 *codebuild.DeleteReportInput) (*request.Request, *codebuild.DeleteReportOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteReportInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.DeleteReportOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteReportInput) *codebuild.DeleteReportOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.DeleteReportOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteReportWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) DeleteReportWithContext(_a0 aws.Context, _a1 *codebuild.DeleteReportInput, _a2 ...request.Option) (*codebuild.DeleteReportOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.DeleteReportOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.DeleteReportInput, ...request.Option) *codebuild.DeleteReportOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteReportOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.DeleteReportInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteSourceCredentials provides a mock function with given fields: _a0
 func (_m *CodeBuildAPI) DeleteSourceCredentials(_a0 *codebuild.DeleteSourceCredentialsInput) (*codebuild.DeleteSourceCredentialsOutput, error) {
 	ret := _m.Called(_a0)
@@ -563,17 +952,95 @@ func (_m *CodeBuildAPI) DeleteSourceCredentialsWithContext(_a0 aws.Context, _a1
 func (_m *CodeBuildAPI) DeleteWebhook(_a0 *codebuild.DeleteWebhookInput) (*codebuild.DeleteWebhookOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *codebuild.DeleteWebhookOutput
-	if rf, ok := ret.Get(0).(func(*codebuild.DeleteWebhookInput) *codebuild.DeleteWebhookOutput); ok {
+	var r0 *codebuild.DeleteWebhookOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteWebhookInput) *codebuild.DeleteWebhookOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteWebhookOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteWebhookInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhookRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DeleteWebhookRequest(_a0 *codebuild.DeleteWebhookInput) (*request.Request, *codebuild.DeleteWebhookOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.DeleteWebhookInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.DeleteWebhookOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.DeleteWebhookInput) *codebuild.DeleteWebhookOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.DeleteWebhookOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhookWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) DeleteWebhookWithContext(_a0 aws.Context, _a1 *codebuild.DeleteWebhookInput, _a2 ...request.Option) (*codebuild.DeleteWebhookOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.DeleteWebhookOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.DeleteWebhookInput, ...request.Option) *codebuild.DeleteWebhookOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DeleteWebhookOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.DeleteWebhookInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTestCases provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DescribeTestCases(_a0 *codebuild.DescribeTestCasesInput) (*codebuild.DescribeTestCasesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.DescribeTestCasesOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.DescribeTestCasesInput) *codebuild.DescribeTestCasesOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*codebuild.DeleteWebhookOutput)
+			r0 = ret.Get(0).(*codebuild.DescribeTestCasesOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*codebuild.DeleteWebhookInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*codebuild.DescribeTestCasesInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -582,12 +1049,12 @@ func (_m *CodeBuildAPI) DeleteWebhook(_a0 *codebuild.DeleteWebhookInput) (*codeb
 	return r0, r1
 }
 
-// DeleteWebhookRequest provides a mock function with given fields: _a0
-func (_m *CodeBuildAPI) DeleteWebhookRequest(_a0 *codebuild.DeleteWebhookInput) (*request.Request, *codebuild.DeleteWebhookOutput) {
+// DescribeTestCasesRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) DescribeTestCasesRequest(_a0 *codebuild.DescribeTestCasesInput) (*request.Request, *codebuild.DescribeTestCasesOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*codebuild.DeleteWebhookInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*codebuild.DescribeTestCasesInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -595,20 +1062,20 @@ func (_m *CodeBuildAPI) DeleteWebhookRequest(_a0 *codebuild.DeleteWebhookInput)
 		}
 	}
 
-	var r1 *codebuild.DeleteWebhookOutput
-	if rf, ok := ret.Get(1).(func(*codebuild.DeleteWebhookInput) *codebuild.DeleteWebhookOutput); ok {
+	var r1 *codebuild.DescribeTestCasesOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.DescribeTestCasesInput) *codebuild.DescribeTestCasesOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*codebuild.DeleteWebhookOutput)
+			r1 = ret.Get(1).(*codebuild.DescribeTestCasesOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DeleteWebhookWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *CodeBuildAPI) DeleteWebhookWithContext(_a0 aws.Context, _a1 *codebuild.DeleteWebhookInput, _a2 ...request.Option) (*codebuild.DeleteWebhookOutput, error) {
+// DescribeTestCasesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) DescribeTestCasesWithContext(_a0 aws.Context, _a1 *codebuild.DescribeTestCasesInput, _a2 ...request.Option) (*codebuild.DescribeTestCasesOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -618,17 +1085,17 @@ func (_m *CodeBuildAPI) DeleteWebhookWithContext(_a0 aws.Context, _a1 *codebuild
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *codebuild.DeleteWebhookOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.DeleteWebhookInput, ...request.Option) *codebuild.DeleteWebhookOutput); ok {
+	var r0 *codebuild.DescribeTestCasesOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.DescribeTestCasesInput, ...request.Option) *codebuild.DescribeTestCasesOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*codebuild.DeleteWebhookOutput)
+			r0 = ret.Get(0).(*codebuild.DescribeTestCasesOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.DeleteWebhookInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.DescribeTestCasesInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1105,6 +1572,240 @@ func (_m *CodeBuildAPI) ListProjectsWithContext(_a0 aws.Context, _a1 *codebuild.
 	return r0, r1
 }
 
+// ListReportGroups provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReportGroups(_a0 *codebuild.ListReportGroupsInput) (*codebuild.ListReportGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ListReportGroupsOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportGroupsInput) *codebuild.ListReportGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReportGroupsRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReportGroupsRequest(_a0 *codebuild.ListReportGroupsInput) (*request.Request, *codebuild.ListReportGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.ListReportGroupsOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportGroupsInput) *codebuild.ListReportGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.ListReportGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListReportGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) ListReportGroupsWithContext(_a0 aws.Context, _a1 *codebuild.ListReportGroupsInput, _a2 ...request.Option) (*codebuild.ListReportGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.ListReportGroupsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.ListReportGroupsInput, ...request.Option) *codebuild.ListReportGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.ListReportGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReports provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReports(_a0 *codebuild.ListReportsInput) (*codebuild.ListReportsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ListReportsOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportsInput) *codebuild.ListReportsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReportsForReportGroup provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReportsForReportGroup(_a0 *codebuild.ListReportsForReportGroupInput) (*codebuild.ListReportsForReportGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ListReportsForReportGroupOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportsForReportGroupInput) *codebuild.ListReportsForReportGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportsForReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportsForReportGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReportsForReportGroupRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReportsForReportGroupRequest(_a0 *codebuild.ListReportsForReportGroupInput) (*request.Request, *codebuild.ListReportsForReportGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportsForReportGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.ListReportsForReportGroupOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportsForReportGroupInput) *codebuild.ListReportsForReportGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.ListReportsForReportGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListReportsForReportGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) ListReportsForReportGroupWithContext(_a0 aws.Context, _a1 *codebuild.ListReportsForReportGroupInput, _a2 ...request.Option) (*codebuild.ListReportsForReportGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.ListReportsForReportGroupOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.ListReportsForReportGroupInput, ...request.Option) *codebuild.ListReportsForReportGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportsForReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.ListReportsForReportGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReportsRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) ListReportsRequest(_a0 *codebuild.ListReportsInput) (*request.Request, *codebuild.ListReportsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.ListReportsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.ListReportsOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.ListReportsInput) *codebuild.ListReportsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.ListReportsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListReportsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) ListReportsWithContext(_a0 aws.Context, _a1 *codebuild.ListReportsInput, _a2 ...request.Option) (*codebuild.ListReportsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.ListReportsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.ListReportsInput, ...request.Option) *codebuild.ListReportsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListReportsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.ListReportsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSourceCredentials provides a mock function with given fields: _a0
 func (_m *CodeBuildAPI) ListSourceCredentials(_a0 *codebuild.ListSourceCredentialsInput) (*codebuild.ListSourceCredentialsOutput, error) {
 	ret := _m.Called(_a0)
@@ -1417,6 +2118,84 @@ func (_m *CodeBuildAPI) UpdateProjectWithContext(_a0 aws.Context, _a1 *codebuild
 	return r0, r1
 }
 
+// UpdateReportGroup provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) UpdateReportGroup(_a0 *codebuild.UpdateReportGroupInput) (*codebuild.UpdateReportGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.UpdateReportGroupOutput
+	if rf, ok := ret.Get(0).(func(*codebuild.UpdateReportGroupInput) *codebuild.UpdateReportGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.UpdateReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.UpdateReportGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReportGroupRequest provides a mock function with given fields: _a0
+func (_m *CodeBuildAPI) UpdateReportGroupRequest(_a0 *codebuild.UpdateReportGroupInput) (*request.Request, *codebuild.UpdateReportGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*codebuild.UpdateReportGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *codebuild.UpdateReportGroupOutput
+	if rf, ok := ret.Get(1).(func(*codebuild.UpdateReportGroupInput) *codebuild.UpdateReportGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*codebuild.UpdateReportGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateReportGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CodeBuildAPI) UpdateReportGroupWithContext(_a0 aws.Context, _a1 *codebuild.UpdateReportGroupInput, _a2 ...request.Option) (*codebuild.UpdateReportGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.UpdateReportGroupOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *codebuild.UpdateReportGroupInput, ...request.Option) *codebuild.UpdateReportGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.UpdateReportGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *codebuild.UpdateReportGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateWebhook provides a mock function with given fields: _a0
 func (_m *CodeBuildAPI) UpdateWebhook(_a0 *codebuild.UpdateWebhookInput) (*codebuild.UpdateWebhookOutput, error) {
 	ret := _m.Called(_a0)
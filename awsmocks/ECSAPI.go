@@ -2,17 +2,94 @@
 
 package awsmocks
 
-import aws "github.com/aws/aws-sdk-go/aws"
 import ecs "github.com/aws/aws-sdk-go/service/ecs"
-
-import mock "github.com/stretchr/testify/mock"
 import request "github.com/aws/aws-sdk-go/aws/request"
+import aws "github.com/aws/aws-sdk-go/aws"
+import mock "github.com/stretchr/testify/mock"
 
 // ECSAPI is an autogenerated mock type for the ECSAPI type
 type ECSAPI struct {
 	mock.Mock
 }
 
+// CreateCapacityProvider provides a mock function with given fields: _a0
+func (_m *ECSAPI) CreateCapacityProvider(_a0 *ecs.CreateCapacityProviderInput) (*ecs.CreateCapacityProviderOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.CreateCapacityProviderOutput
+	if rf, ok := ret.Get(0).(func(*ecs.CreateCapacityProviderInput) *ecs.CreateCapacityProviderOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.CreateCapacityProviderOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.CreateCapacityProviderInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCapacityProviderRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) CreateCapacityProviderRequest(_a0 *ecs.CreateCapacityProviderInput) (*request.Request, *ecs.CreateCapacityProviderOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.CreateCapacityProviderInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.CreateCapacityProviderOutput
+	if rf, ok := ret.Get(1).(func(*ecs.CreateCapacityProviderInput) *ecs.CreateCapacityProviderOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.CreateCapacityProviderOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateCapacityProviderWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) CreateCapacityProviderWithContext(_a0 aws.Context, _a1 *ecs.CreateCapacityProviderInput, _a2 ...request.Option) (*ecs.CreateCapacityProviderOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.CreateCapacityProviderOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.CreateCapacityProviderInput, ...request.Option) *ecs.CreateCapacityProviderOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.CreateCapacityProviderOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.CreateCapacityProviderInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCluster provides a mock function with given fields: _a0
 func (_m *ECSAPI) CreateCluster(_a0 *ecs.CreateClusterInput) (*ecs.CreateClusterOutput, error) {
 	ret := _m.Called(_a0)
@@ -169,6 +246,84 @@ func (_m *ECSAPI) CreateServiceWithContext(_a0 aws.Context, _a1 *ecs.CreateServi
 	return r0, r1
 }
 
+// CreateTaskSet provides a mock function with given fields: _a0
+func (_m *ECSAPI) CreateTaskSet(_a0 *ecs.CreateTaskSetInput) (*ecs.CreateTaskSetOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.CreateTaskSetOutput
+	if rf, ok := ret.Get(0).(func(*ecs.CreateTaskSetInput) *ecs.CreateTaskSetOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.CreateTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.CreateTaskSetInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTaskSetRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) CreateTaskSetRequest(_a0 *ecs.CreateTaskSetInput) (*request.Request, *ecs.CreateTaskSetOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.CreateTaskSetInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.CreateTaskSetOutput
+	if rf, ok := ret.Get(1).(func(*ecs.CreateTaskSetInput) *ecs.CreateTaskSetOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.CreateTaskSetOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateTaskSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) CreateTaskSetWithContext(_a0 aws.Context, _a1 *ecs.CreateTaskSetInput, _a2 ...request.Option) (*ecs.CreateTaskSetOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.CreateTaskSetOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.CreateTaskSetInput, ...request.Option) *ecs.CreateTaskSetOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.CreateTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.CreateTaskSetInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAccountSetting provides a mock function with given fields: _a0
 func (_m *ECSAPI) DeleteAccountSetting(_a0 *ecs.DeleteAccountSettingInput) (*ecs.DeleteAccountSettingOutput, error) {
 	ret := _m.Called(_a0)
@@ -481,6 +636,84 @@ func (_m *ECSAPI) DeleteServiceWithContext(_a0 aws.Context, _a1 *ecs.DeleteServi
 	return r0, r1
 }
 
+// DeleteTaskSet provides a mock function with given fields: _a0
+func (_m *ECSAPI) DeleteTaskSet(_a0 *ecs.DeleteTaskSetInput) (*ecs.DeleteTaskSetOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.DeleteTaskSetOutput
+	if rf, ok := ret.Get(0).(func(*ecs.DeleteTaskSetInput) *ecs.DeleteTaskSetOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DeleteTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.DeleteTaskSetInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTaskSetRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) DeleteTaskSetRequest(_a0 *ecs.DeleteTaskSetInput) (*request.Request, *ecs.DeleteTaskSetOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.DeleteTaskSetInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.DeleteTaskSetOutput
+	if rf, ok := ret.Get(1).(func(*ecs.DeleteTaskSetInput) *ecs.DeleteTaskSetOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.DeleteTaskSetOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteTaskSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) DeleteTaskSetWithContext(_a0 aws.Context, _a1 *ecs.DeleteTaskSetInput, _a2 ...request.Option) (*ecs.DeleteTaskSetOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.DeleteTaskSetOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.DeleteTaskSetInput, ...request.Option) *ecs.DeleteTaskSetOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DeleteTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.DeleteTaskSetInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeregisterContainerInstance provides a mock function with given fields: _a0
 func (_m *ECSAPI) DeregisterContainerInstance(_a0 *ecs.DeregisterContainerInstanceInput) (*ecs.DeregisterContainerInstanceOutput, error) {
 	ret := _m.Called(_a0)
@@ -637,6 +870,84 @@ func (_m *ECSAPI) DeregisterTaskDefinitionWithContext(_a0 aws.Context, _a1 *ecs.
 	return r0, r1
 }
 
+// DescribeCapacityProviders provides a mock function with given fields: _a0
+func (_m *ECSAPI) DescribeCapacityProviders(_a0 *ecs.DescribeCapacityProvidersInput) (*ecs.DescribeCapacityProvidersOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.DescribeCapacityProvidersOutput
+	if rf, ok := ret.Get(0).(func(*ecs.DescribeCapacityProvidersInput) *ecs.DescribeCapacityProvidersOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DescribeCapacityProvidersOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.DescribeCapacityProvidersInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeCapacityProvidersRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) DescribeCapacityProvidersRequest(_a0 *ecs.DescribeCapacityProvidersInput) (*request.Request, *ecs.DescribeCapacityProvidersOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.DescribeCapacityProvidersInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.DescribeCapacityProvidersOutput
+	if rf, ok := ret.Get(1).(func(*ecs.DescribeCapacityProvidersInput) *ecs.DescribeCapacityProvidersOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.DescribeCapacityProvidersOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeCapacityProvidersWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) DescribeCapacityProvidersWithContext(_a0 aws.Context, _a1 *ecs.DescribeCapacityProvidersInput, _a2 ...request.Option) (*ecs.DescribeCapacityProvidersOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.DescribeCapacityProvidersOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.DescribeCapacityProvidersInput, ...request.Option) *ecs.DescribeCapacityProvidersOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DescribeCapacityProvidersOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.DescribeCapacityProvidersInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeClusters provides a mock function with given fields: _a0
 func (_m *ECSAPI) DescribeClusters(_a0 *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
 	ret := _m.Called(_a0)
@@ -949,6 +1260,84 @@ func (_m *ECSAPI) DescribeTaskDefinitionWithContext(_a0 aws.Context, _a1 *ecs.De
 	return r0, r1
 }
 
+// DescribeTaskSets provides a mock function with given fields: _a0
+func (_m *ECSAPI) DescribeTaskSets(_a0 *ecs.DescribeTaskSetsInput) (*ecs.DescribeTaskSetsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.DescribeTaskSetsOutput
+	if rf, ok := ret.Get(0).(func(*ecs.DescribeTaskSetsInput) *ecs.DescribeTaskSetsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DescribeTaskSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.DescribeTaskSetsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTaskSetsRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) DescribeTaskSetsRequest(_a0 *ecs.DescribeTaskSetsInput) (*request.Request, *ecs.DescribeTaskSetsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.DescribeTaskSetsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.DescribeTaskSetsOutput
+	if rf, ok := ret.Get(1).(func(*ecs.DescribeTaskSetsInput) *ecs.DescribeTaskSetsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.DescribeTaskSetsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeTaskSetsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) DescribeTaskSetsWithContext(_a0 aws.Context, _a1 *ecs.DescribeTaskSetsInput, _a2 ...request.Option) (*ecs.DescribeTaskSetsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.DescribeTaskSetsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.DescribeTaskSetsInput, ...request.Option) *ecs.DescribeTaskSetsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DescribeTaskSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.DescribeTaskSetsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeTasks provides a mock function with given fields: _a0
 func (_m *ECSAPI) DescribeTasks(_a0 *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
 	ret := _m.Called(_a0)
@@ -1206,6 +1595,41 @@ func (_m *ECSAPI) ListAttributes(_a0 *ecs.ListAttributesInput) (*ecs.ListAttribu
 	return r0, r1
 }
 
+// ListAttributesPages provides a mock function with given fields: _a0, _a1
+func (_m *ECSAPI) ListAttributesPages(_a0 *ecs.ListAttributesInput, _a1 func(*ecs.ListAttributesOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*ecs.ListAttributesInput, func(*ecs.ListAttributesOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAttributesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ECSAPI) ListAttributesPagesWithContext(_a0 aws.Context, _a1 *ecs.ListAttributesInput, _a2 func(*ecs.ListAttributesOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.ListAttributesInput, func(*ecs.ListAttributesOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListAttributesRequest provides a mock function with given fields: _a0
 func (_m *ECSAPI) ListAttributesRequest(_a0 *ecs.ListAttributesInput) (*request.Request, *ecs.ListAttributesOutput) {
 	ret := _m.Called(_a0)
@@ -2040,6 +2464,84 @@ func (_m *ECSAPI) PutAccountSetting(_a0 *ecs.PutAccountSettingInput) (*ecs.PutAc
 	return r0, r1
 }
 
+// PutAccountSettingDefault provides a mock function with given fields: _a0
+func (_m *ECSAPI) PutAccountSettingDefault(_a0 *ecs.PutAccountSettingDefaultInput) (*ecs.PutAccountSettingDefaultOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.PutAccountSettingDefaultOutput
+	if rf, ok := ret.Get(0).(func(*ecs.PutAccountSettingDefaultInput) *ecs.PutAccountSettingDefaultOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.PutAccountSettingDefaultOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.PutAccountSettingDefaultInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutAccountSettingDefaultRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) PutAccountSettingDefaultRequest(_a0 *ecs.PutAccountSettingDefaultInput) (*request.Request, *ecs.PutAccountSettingDefaultOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.PutAccountSettingDefaultInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.PutAccountSettingDefaultOutput
+	if rf, ok := ret.Get(1).(func(*ecs.PutAccountSettingDefaultInput) *ecs.PutAccountSettingDefaultOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.PutAccountSettingDefaultOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// PutAccountSettingDefaultWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) PutAccountSettingDefaultWithContext(_a0 aws.Context, _a1 *ecs.PutAccountSettingDefaultInput, _a2 ...request.Option) (*ecs.PutAccountSettingDefaultOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.PutAccountSettingDefaultOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.PutAccountSettingDefaultInput, ...request.Option) *ecs.PutAccountSettingDefaultOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.PutAccountSettingDefaultOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.PutAccountSettingDefaultInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutAccountSettingRequest provides a mock function with given fields: _a0
 func (_m *ECSAPI) PutAccountSettingRequest(_a0 *ecs.PutAccountSettingInput) (*request.Request, *ecs.PutAccountSettingOutput) {
 	ret := _m.Called(_a0)
@@ -2099,17 +2601,95 @@ func (_m *ECSAPI) PutAccountSettingWithContext(_a0 aws.Context, _a1 *ecs.PutAcco
 func (_m *ECSAPI) PutAttributes(_a0 *ecs.PutAttributesInput) (*ecs.PutAttributesOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *ecs.PutAttributesOutput
-	if rf, ok := ret.Get(0).(func(*ecs.PutAttributesInput) *ecs.PutAttributesOutput); ok {
+	var r0 *ecs.PutAttributesOutput
+	if rf, ok := ret.Get(0).(func(*ecs.PutAttributesInput) *ecs.PutAttributesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.PutAttributesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.PutAttributesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutAttributesRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) PutAttributesRequest(_a0 *ecs.PutAttributesInput) (*request.Request, *ecs.PutAttributesOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.PutAttributesInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.PutAttributesOutput
+	if rf, ok := ret.Get(1).(func(*ecs.PutAttributesInput) *ecs.PutAttributesOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.PutAttributesOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// PutAttributesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) PutAttributesWithContext(_a0 aws.Context, _a1 *ecs.PutAttributesInput, _a2 ...request.Option) (*ecs.PutAttributesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.PutAttributesOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.PutAttributesInput, ...request.Option) *ecs.PutAttributesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.PutAttributesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.PutAttributesInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutClusterCapacityProviders provides a mock function with given fields: _a0
+func (_m *ECSAPI) PutClusterCapacityProviders(_a0 *ecs.PutClusterCapacityProvidersInput) (*ecs.PutClusterCapacityProvidersOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.PutClusterCapacityProvidersOutput
+	if rf, ok := ret.Get(0).(func(*ecs.PutClusterCapacityProvidersInput) *ecs.PutClusterCapacityProvidersOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ecs.PutAttributesOutput)
+			r0 = ret.Get(0).(*ecs.PutClusterCapacityProvidersOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*ecs.PutAttributesInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*ecs.PutClusterCapacityProvidersInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -2118,12 +2698,12 @@ func (_m *ECSAPI) PutAttributes(_a0 *ecs.PutAttributesInput) (*ecs.PutAttributes
 	return r0, r1
 }
 
-// PutAttributesRequest provides a mock function with given fields: _a0
-func (_m *ECSAPI) PutAttributesRequest(_a0 *ecs.PutAttributesInput) (*request.Request, *ecs.PutAttributesOutput) {
+// PutClusterCapacityProvidersRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) PutClusterCapacityProvidersRequest(_a0 *ecs.PutClusterCapacityProvidersInput) (*request.Request, *ecs.PutClusterCapacityProvidersOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ecs.PutAttributesInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*ecs.PutClusterCapacityProvidersInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -2131,20 +2711,20 @@ func (_m *ECSAPI) PutAttributesRequest(_a0 *ecs.PutAttributesInput) (*request.Re
 		}
 	}
 
-	var r1 *ecs.PutAttributesOutput
-	if rf, ok := ret.Get(1).(func(*ecs.PutAttributesInput) *ecs.PutAttributesOutput); ok {
+	var r1 *ecs.PutClusterCapacityProvidersOutput
+	if rf, ok := ret.Get(1).(func(*ecs.PutClusterCapacityProvidersInput) *ecs.PutClusterCapacityProvidersOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ecs.PutAttributesOutput)
+			r1 = ret.Get(1).(*ecs.PutClusterCapacityProvidersOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// PutAttributesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ECSAPI) PutAttributesWithContext(_a0 aws.Context, _a1 *ecs.PutAttributesInput, _a2 ...request.Option) (*ecs.PutAttributesOutput, error) {
+// PutClusterCapacityProvidersWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) PutClusterCapacityProvidersWithContext(_a0 aws.Context, _a1 *ecs.PutClusterCapacityProvidersInput, _a2 ...request.Option) (*ecs.PutClusterCapacityProvidersOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -2154,17 +2734,17 @@ func (_m *ECSAPI) PutAttributesWithContext(_a0 aws.Context, _a1 *ecs.PutAttribut
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *ecs.PutAttributesOutput
-	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.PutAttributesInput, ...request.Option) *ecs.PutAttributesOutput); ok {
+	var r0 *ecs.PutClusterCapacityProvidersOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.PutClusterCapacityProvidersInput, ...request.Option) *ecs.PutClusterCapacityProvidersOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ecs.PutAttributesOutput)
+			r0 = ret.Get(0).(*ecs.PutClusterCapacityProvidersOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.PutAttributesInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.PutClusterCapacityProvidersInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -2563,6 +3143,84 @@ func (_m *ECSAPI) StopTaskWithContext(_a0 aws.Context, _a1 *ecs.StopTaskInput, _
 	return r0, r1
 }
 
+// SubmitAttachmentStateChanges provides a mock function with given fields: _a0
+func (_m *ECSAPI) SubmitAttachmentStateChanges(_a0 *ecs.SubmitAttachmentStateChangesInput) (*ecs.SubmitAttachmentStateChangesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.SubmitAttachmentStateChangesOutput
+	if rf, ok := ret.Get(0).(func(*ecs.SubmitAttachmentStateChangesInput) *ecs.SubmitAttachmentStateChangesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.SubmitAttachmentStateChangesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.SubmitAttachmentStateChangesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitAttachmentStateChangesRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) SubmitAttachmentStateChangesRequest(_a0 *ecs.SubmitAttachmentStateChangesInput) (*request.Request, *ecs.SubmitAttachmentStateChangesOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.SubmitAttachmentStateChangesInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.SubmitAttachmentStateChangesOutput
+	if rf, ok := ret.Get(1).(func(*ecs.SubmitAttachmentStateChangesInput) *ecs.SubmitAttachmentStateChangesOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.SubmitAttachmentStateChangesOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// SubmitAttachmentStateChangesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) SubmitAttachmentStateChangesWithContext(_a0 aws.Context, _a1 *ecs.SubmitAttachmentStateChangesInput, _a2 ...request.Option) (*ecs.SubmitAttachmentStateChangesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.SubmitAttachmentStateChangesOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.SubmitAttachmentStateChangesInput, ...request.Option) *ecs.SubmitAttachmentStateChangesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.SubmitAttachmentStateChangesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.SubmitAttachmentStateChangesInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitContainerStateChange provides a mock function with given fields: _a0
 func (_m *ECSAPI) SubmitContainerStateChange(_a0 *ecs.SubmitContainerStateChangeInput) (*ecs.SubmitContainerStateChangeOutput, error) {
 	ret := _m.Called(_a0)
@@ -2875,6 +3533,84 @@ func (_m *ECSAPI) UntagResourceWithContext(_a0 aws.Context, _a1 *ecs.UntagResour
 	return r0, r1
 }
 
+// UpdateClusterSettings provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateClusterSettings(_a0 *ecs.UpdateClusterSettingsInput) (*ecs.UpdateClusterSettingsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.UpdateClusterSettingsOutput
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateClusterSettingsInput) *ecs.UpdateClusterSettingsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateClusterSettingsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateClusterSettingsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateClusterSettingsRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateClusterSettingsRequest(_a0 *ecs.UpdateClusterSettingsInput) (*request.Request, *ecs.UpdateClusterSettingsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateClusterSettingsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.UpdateClusterSettingsOutput
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateClusterSettingsInput) *ecs.UpdateClusterSettingsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.UpdateClusterSettingsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateClusterSettingsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) UpdateClusterSettingsWithContext(_a0 aws.Context, _a1 *ecs.UpdateClusterSettingsInput, _a2 ...request.Option) (*ecs.UpdateClusterSettingsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.UpdateClusterSettingsOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.UpdateClusterSettingsInput, ...request.Option) *ecs.UpdateClusterSettingsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateClusterSettingsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.UpdateClusterSettingsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateContainerAgent provides a mock function with given fields: _a0
 func (_m *ECSAPI) UpdateContainerAgent(_a0 *ecs.UpdateContainerAgentInput) (*ecs.UpdateContainerAgentOutput, error) {
 	ret := _m.Called(_a0)
@@ -3054,6 +3790,84 @@ func (_m *ECSAPI) UpdateService(_a0 *ecs.UpdateServiceInput) (*ecs.UpdateService
 	return r0, r1
 }
 
+// UpdateServicePrimaryTaskSet provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateServicePrimaryTaskSet(_a0 *ecs.UpdateServicePrimaryTaskSetInput) (*ecs.UpdateServicePrimaryTaskSetOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.UpdateServicePrimaryTaskSetOutput
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateServicePrimaryTaskSetInput) *ecs.UpdateServicePrimaryTaskSetOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateServicePrimaryTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateServicePrimaryTaskSetInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateServicePrimaryTaskSetRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateServicePrimaryTaskSetRequest(_a0 *ecs.UpdateServicePrimaryTaskSetInput) (*request.Request, *ecs.UpdateServicePrimaryTaskSetOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateServicePrimaryTaskSetInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.UpdateServicePrimaryTaskSetOutput
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateServicePrimaryTaskSetInput) *ecs.UpdateServicePrimaryTaskSetOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.UpdateServicePrimaryTaskSetOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateServicePrimaryTaskSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) UpdateServicePrimaryTaskSetWithContext(_a0 aws.Context, _a1 *ecs.UpdateServicePrimaryTaskSetInput, _a2 ...request.Option) (*ecs.UpdateServicePrimaryTaskSetOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.UpdateServicePrimaryTaskSetOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.UpdateServicePrimaryTaskSetInput, ...request.Option) *ecs.UpdateServicePrimaryTaskSetOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateServicePrimaryTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.UpdateServicePrimaryTaskSetInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateServiceRequest provides a mock function with given fields: _a0
 func (_m *ECSAPI) UpdateServiceRequest(_a0 *ecs.UpdateServiceInput) (*request.Request, *ecs.UpdateServiceOutput) {
 	ret := _m.Called(_a0)
@@ -3109,6 +3923,84 @@ func (_m *ECSAPI) UpdateServiceWithContext(_a0 aws.Context, _a1 *ecs.UpdateServi
 	return r0, r1
 }
 
+// UpdateTaskSet provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateTaskSet(_a0 *ecs.UpdateTaskSetInput) (*ecs.UpdateTaskSetOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.UpdateTaskSetOutput
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateTaskSetInput) *ecs.UpdateTaskSetOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateTaskSetInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTaskSetRequest provides a mock function with given fields: _a0
+func (_m *ECSAPI) UpdateTaskSetRequest(_a0 *ecs.UpdateTaskSetInput) (*request.Request, *ecs.UpdateTaskSetOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ecs.UpdateTaskSetInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *ecs.UpdateTaskSetOutput
+	if rf, ok := ret.Get(1).(func(*ecs.UpdateTaskSetInput) *ecs.UpdateTaskSetOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecs.UpdateTaskSetOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateTaskSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ECSAPI) UpdateTaskSetWithContext(_a0 aws.Context, _a1 *ecs.UpdateTaskSetInput, _a2 ...request.Option) (*ecs.UpdateTaskSetOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.UpdateTaskSetOutput
+	if rf, ok := ret.Get(0).(func(aws.Context, *ecs.UpdateTaskSetInput, ...request.Option) *ecs.UpdateTaskSetOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.UpdateTaskSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(aws.Context, *ecs.UpdateTaskSetInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitUntilServicesInactive provides a mock function with given fields: _a0
 func (_m *ECSAPI) WaitUntilServicesInactive(_a0 *ecs.DescribeServicesInput) error {
 	ret := _m.Called(_a0)
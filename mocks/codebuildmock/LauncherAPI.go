@@ -3,6 +3,7 @@
 package codebuildmock
 
 import codebuild "github.com/wolfeidau/aws-launch/pkg/launcher/codebuild"
+import cwlogs "github.com/wolfeidau/aws-launch/pkg/cwlogs"
 import mock "github.com/stretchr/testify/mock"
 
 // LauncherAPI is an autogenerated mock type for the LauncherAPI type
@@ -56,6 +57,52 @@ func (_m *LauncherAPI) DefineTask(_a0 *codebuild.DefineTaskParams) (*codebuild.D
 	return r0, r1
 }
 
+// DescribeDefinition provides a mock function with given fields: _a0
+func (_m *LauncherAPI) DescribeDefinition(_a0 *codebuild.DescribeDefinitionParams) (*codebuild.DescribeDefinitionResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.DescribeDefinitionResult
+	if rf, ok := ret.Get(0).(func(*codebuild.DescribeDefinitionParams) *codebuild.DescribeDefinitionResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.DescribeDefinitionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.DescribeDefinitionParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportTaskLogs provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ExportTaskLogs(_a0 *codebuild.ExportTaskLogsParams) (*codebuild.ExportTaskLogsResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ExportTaskLogsResult
+	if rf, ok := ret.Get(0).(func(*codebuild.ExportTaskLogsParams) *codebuild.ExportTaskLogsResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ExportTaskLogsResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ExportTaskLogsParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskLogs provides a mock function with given fields: _a0
 func (_m *LauncherAPI) GetTaskLogs(_a0 *codebuild.GetTaskLogsParams) (*codebuild.GetTaskLogsResult, error) {
 	ret := _m.Called(_a0)
@@ -102,6 +149,29 @@ func (_m *LauncherAPI) GetTaskStatus(_a0 *codebuild.GetTaskStatusParams) (*codeb
 	return r0, r1
 }
 
+// GetTasksStatus provides a mock function with given fields: _a0
+func (_m *LauncherAPI) GetTasksStatus(_a0 *codebuild.GetTasksStatusParams) (*codebuild.GetTasksStatusResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.GetTasksStatusResult
+	if rf, ok := ret.Get(0).(func(*codebuild.GetTasksStatusParams) *codebuild.GetTasksStatusResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.GetTasksStatusResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.GetTasksStatusParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LaunchTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) LaunchTask(_a0 *codebuild.LaunchTaskParams) (*codebuild.LaunchTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -125,6 +195,105 @@ func (_m *LauncherAPI) LaunchTask(_a0 *codebuild.LaunchTaskParams) (*codebuild.L
 	return r0, r1
 }
 
+// ListDefinitions provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ListDefinitions(_a0 *codebuild.ListDefinitionsParams) (*codebuild.ListDefinitionsResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ListDefinitionsResult
+	if rf, ok := ret.Get(0).(func(*codebuild.ListDefinitionsParams) *codebuild.ListDefinitionsResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListDefinitionsResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ListDefinitionsParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ListTasks(_a0 *codebuild.ListTasksParams) (*codebuild.ListTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.ListTasksResult
+	if rf, ok := ret.Get(0).(func(*codebuild.ListTasksParams) *codebuild.ListTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.ListTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.ListTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewLease provides a mock function with given fields: _a0
+func (_m *LauncherAPI) RenewLease(_a0 *codebuild.RenewLeaseParams) (*codebuild.RenewLeaseResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.RenewLeaseResult
+	if rf, ok := ret.Get(0).(func(*codebuild.RenewLeaseParams) *codebuild.RenewLeaseResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.RenewLeaseResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.RenewLeaseParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunTask provides a mock function with given fields: _a0, _a1
+func (_m *LauncherAPI) RunTask(_a0 *codebuild.RunTaskParams, _a1 ...cwlogs.LogSink) (*codebuild.RunTaskResult, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *codebuild.RunTaskResult
+	if rf, ok := ret.Get(0).(func(*codebuild.RunTaskParams, ...cwlogs.LogSink) *codebuild.RunTaskResult); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.RunTaskResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.RunTaskParams, ...cwlogs.LogSink) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StopTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) StopTask(_a0 *codebuild.StopTaskParams) (*codebuild.StopTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -148,6 +317,29 @@ func (_m *LauncherAPI) StopTask(_a0 *codebuild.StopTaskParams) (*codebuild.StopT
 	return r0, r1
 }
 
+// StopTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) StopTasks(_a0 *codebuild.StopTasksParams) (*codebuild.StopTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.StopTasksResult
+	if rf, ok := ret.Get(0).(func(*codebuild.StopTasksParams) *codebuild.StopTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.StopTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.StopTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitForTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) WaitForTask(_a0 *codebuild.WaitForTaskParams) (*codebuild.WaitForTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -170,3 +362,49 @@ func (_m *LauncherAPI) WaitForTask(_a0 *codebuild.WaitForTaskParams) (*codebuild
 
 	return r0, r1
 }
+
+// WaitForTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) WaitForTasks(_a0 *codebuild.WaitForTasksParams) (*codebuild.WaitForTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.WaitForTasksResult
+	if rf, ok := ret.Get(0).(func(*codebuild.WaitForTasksParams) *codebuild.WaitForTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.WaitForTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.WaitForTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchTask provides a mock function with given fields: _a0
+func (_m *LauncherAPI) WatchTask(_a0 *codebuild.WatchTaskParams) (*codebuild.WatchTaskResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *codebuild.WatchTaskResult
+	if rf, ok := ret.Get(0).(func(*codebuild.WatchTaskParams) *codebuild.WatchTaskResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*codebuild.WatchTaskResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*codebuild.WatchTaskParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,6 +3,7 @@
 package ecsmock
 
 import ecs "github.com/wolfeidau/aws-launch/pkg/launcher/ecs"
+import cwlogs "github.com/wolfeidau/aws-launch/pkg/cwlogs"
 import mock "github.com/stretchr/testify/mock"
 
 // LauncherAPI is an autogenerated mock type for the LauncherAPI type
@@ -56,6 +57,52 @@ func (_m *LauncherAPI) DefineTask(_a0 *ecs.DefineTaskParams) (*ecs.DefineTaskRes
 	return r0, r1
 }
 
+// DescribeDefinition provides a mock function with given fields: _a0
+func (_m *LauncherAPI) DescribeDefinition(_a0 *ecs.DescribeDefinitionParams) (*ecs.DescribeDefinitionResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.DescribeDefinitionResult
+	if rf, ok := ret.Get(0).(func(*ecs.DescribeDefinitionParams) *ecs.DescribeDefinitionResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.DescribeDefinitionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.DescribeDefinitionParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportTaskLogs provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ExportTaskLogs(_a0 *ecs.ExportTaskLogsParams) (*ecs.ExportTaskLogsResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.ExportTaskLogsResult
+	if rf, ok := ret.Get(0).(func(*ecs.ExportTaskLogsParams) *ecs.ExportTaskLogsResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.ExportTaskLogsResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.ExportTaskLogsParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskLogs provides a mock function with given fields: _a0
 func (_m *LauncherAPI) GetTaskLogs(_a0 *ecs.GetTaskLogsParams) (*ecs.GetTaskLogsResult, error) {
 	ret := _m.Called(_a0)
@@ -102,6 +149,29 @@ func (_m *LauncherAPI) GetTaskStatus(_a0 *ecs.GetTaskStatusParams) (*ecs.GetTask
 	return r0, r1
 }
 
+// GetTasksStatus provides a mock function with given fields: _a0
+func (_m *LauncherAPI) GetTasksStatus(_a0 *ecs.GetTasksStatusParams) (*ecs.GetTasksStatusResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.GetTasksStatusResult
+	if rf, ok := ret.Get(0).(func(*ecs.GetTasksStatusParams) *ecs.GetTasksStatusResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.GetTasksStatusResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.GetTasksStatusParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LaunchTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) LaunchTask(_a0 *ecs.LaunchTaskParams) (*ecs.LaunchTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -125,6 +195,105 @@ func (_m *LauncherAPI) LaunchTask(_a0 *ecs.LaunchTaskParams) (*ecs.LaunchTaskRes
 	return r0, r1
 }
 
+// ListDefinitions provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ListDefinitions(_a0 *ecs.ListDefinitionsParams) (*ecs.ListDefinitionsResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.ListDefinitionsResult
+	if rf, ok := ret.Get(0).(func(*ecs.ListDefinitionsParams) *ecs.ListDefinitionsResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.ListDefinitionsResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.ListDefinitionsParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) ListTasks(_a0 *ecs.ListTasksParams) (*ecs.ListTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.ListTasksResult
+	if rf, ok := ret.Get(0).(func(*ecs.ListTasksParams) *ecs.ListTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.ListTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.ListTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewLease provides a mock function with given fields: _a0
+func (_m *LauncherAPI) RenewLease(_a0 *ecs.RenewLeaseParams) (*ecs.RenewLeaseResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.RenewLeaseResult
+	if rf, ok := ret.Get(0).(func(*ecs.RenewLeaseParams) *ecs.RenewLeaseResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.RenewLeaseResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.RenewLeaseParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunTask provides a mock function with given fields: _a0, _a1
+func (_m *LauncherAPI) RunTask(_a0 *ecs.RunTaskParams, _a1 ...cwlogs.LogSink) (*ecs.RunTaskResult, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *ecs.RunTaskResult
+	if rf, ok := ret.Get(0).(func(*ecs.RunTaskParams, ...cwlogs.LogSink) *ecs.RunTaskResult); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.RunTaskResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.RunTaskParams, ...cwlogs.LogSink) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StopTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) StopTask(_a0 *ecs.StopTaskParams) (*ecs.StopTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -148,6 +317,29 @@ func (_m *LauncherAPI) StopTask(_a0 *ecs.StopTaskParams) (*ecs.StopTaskResult, e
 	return r0, r1
 }
 
+// StopTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) StopTasks(_a0 *ecs.StopTasksParams) (*ecs.StopTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.StopTasksResult
+	if rf, ok := ret.Get(0).(func(*ecs.StopTasksParams) *ecs.StopTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.StopTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.StopTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitForTask provides a mock function with given fields: _a0
 func (_m *LauncherAPI) WaitForTask(_a0 *ecs.WaitForTaskParams) (*ecs.WaitForTaskResult, error) {
 	ret := _m.Called(_a0)
@@ -170,3 +362,49 @@ func (_m *LauncherAPI) WaitForTask(_a0 *ecs.WaitForTaskParams) (*ecs.WaitForTask
 
 	return r0, r1
 }
+
+// WaitForTasks provides a mock function with given fields: _a0
+func (_m *LauncherAPI) WaitForTasks(_a0 *ecs.WaitForTasksParams) (*ecs.WaitForTasksResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.WaitForTasksResult
+	if rf, ok := ret.Get(0).(func(*ecs.WaitForTasksParams) *ecs.WaitForTasksResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.WaitForTasksResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.WaitForTasksParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchTask provides a mock function with given fields: _a0
+func (_m *LauncherAPI) WatchTask(_a0 *ecs.WatchTaskParams) (*ecs.WatchTaskResult, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ecs.WatchTaskResult
+	if rf, ok := ret.Get(0).(func(*ecs.WatchTaskParams) *ecs.WatchTaskResult); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ecs.WatchTaskResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecs.WatchTaskParams) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -1,9 +1,15 @@
 package cwlogs
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
@@ -15,6 +21,20 @@ import (
 type LogLine struct {
 	Timestamp time.Time `json:"timestamp,omitempty"`
 	Message   string    `json:"message,omitempty"`
+
+	IngestionTime time.Time `json:"ingestion_time,omitempty"`
+	EventID       string    `json:"event_id,omitempty"`
+	GroupName     string    `json:"group_name,omitempty"`
+	StreamName    string    `json:"stream_name,omitempty"`
+	TaskID        string    `json:"task_id,omitempty"`
+	// the attempt which wrote the line when the task is retried
+	Attempt int `json:"attempt,omitempty"`
+
+	// populated when the message is parsed as JSON
+	Level  string                 `json:"level,omitempty"`
+	Msg    string                 `json:"msg,omitempty"`
+	Time   *time.Time             `json:"time,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
 }
 
 // ReadLogsParams read cloudwatch logs parameters
@@ -22,6 +42,15 @@ type ReadLogsParams struct {
 	GroupName  string  `json:"group_name,omitempty" jsonschema:"required"`
 	StreamName string  `json:"stream_name,omitempty" jsonschema:"required"`
 	NextToken  *string `json:"next_token,omitempty"`
+
+	// optional, read from the oldest event when no next token is supplied rather than the latest
+	StartFromHead bool `json:"start_from_head,omitempty"`
+
+	// optional, copied to each log line to identify the task which wrote it
+	TaskID string `json:"task_id,omitempty"`
+
+	// optional, when supplied each message is parsed as JSON using this field mapping
+	FieldMapping *FieldMapping `json:"field_mapping,omitempty"`
 }
 
 // ReadLogsResult read cloudwatch logs result
@@ -35,21 +64,71 @@ type LogsReader interface {
 	ReadLogs(*ReadLogsParams) (*ReadLogsResult, error)
 }
 
-// CloudwatchLogsReader cloudwatch log reader which uploads chunk of log data to buildkite
+// ContextLogsReader logs reader which stops waiting for the rate limiter when the context is cancelled
+type ContextLogsReader interface {
+	ReadLogsWithContext(context.Context, *ReadLogsParams) (*ReadLogsResult, error)
+}
+
+// ReaderOptions cloudwatch log reader options
+type ReaderOptions struct {
+	// optional, calls are only rate limited when a limiter is supplied, use DefaultRateLimiter to share
+	// a limiter with the other readers in this process
+	RateLimiter *RateLimiter
+	// optional, the number of times a throttled call is retried by the limiter, defaults to DefaultMaxThrottleRetries
+	MaxThrottleRetries int
+}
+
+// CloudwatchLogsReader cloudwatch log reader, pages of log data can be written to buildkite using the BuildkiteSink
 type CloudwatchLogsReader struct {
-	cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI
+	cwlogsSvc          cloudwatchlogsiface.CloudWatchLogsAPI
+	limiter            *RateLimiter
+	maxThrottleRetries int
 }
 
-// NewCloudwatchLogsReader read all the things
+// NewCloudwatchLogsReader read all the things, calls aren't rate limited
 func NewCloudwatchLogsReader(cfgs ...*aws.Config) *CloudwatchLogsReader {
+	return NewCloudwatchLogsReaderWithOptions(&ReaderOptions{}, cfgs...)
+}
+
+// NewCloudwatchLogsReaderWithOptions read all the things, when a rate limiter is supplied it retries throttled
+// calls so the SDK is configured not to retry them as well
+func NewCloudwatchLogsReaderWithOptions(opts *ReaderOptions, cfgs ...*aws.Config) *CloudwatchLogsReader {
 	sess := session.Must(session.NewSession(cfgs...))
+
+	var svcCfgs []*aws.Config
+	if opts.RateLimiter != nil {
+		svcCfgs = append(svcCfgs, request.WithRetryer(aws.NewConfig(), &throttleRetryer{
+			Retryer: client.DefaultRetryer{NumMaxRetries: client.DefaultRetryerMaxNumRetries},
+		}))
+	}
+
 	return &CloudwatchLogsReader{
-		cwlogsSvc: cloudwatchlogs.New(sess),
+		cwlogsSvc:          cloudwatchlogs.New(sess, svcCfgs...),
+		limiter:            opts.RateLimiter,
+		maxThrottleRetries: opts.MaxThrottleRetries,
+	}
+}
+
+// throttleRetryer leaves throttled calls to the rate limiter, other errors are retried by the SDK as usual
+type throttleRetryer struct {
+	request.Retryer
+}
+
+func (tr *throttleRetryer) ShouldRetry(r *request.Request) bool {
+	if request.IsErrorThrottle(r.Error) {
+		return false
 	}
+
+	return tr.Retryer.ShouldRetry(r)
 }
 
 // ReadLogs this reads a page of logs from cloudwatch and returns a token which will access the next page
 func (cwlr *CloudwatchLogsReader) ReadLogs(rlr *ReadLogsParams) (*ReadLogsResult, error) {
+	return cwlr.ReadLogsWithContext(context.Background(), rlr)
+}
+
+// ReadLogsWithContext read a page of logs, waiting for the rate limiter stops when the context is cancelled
+func (cwlr *CloudwatchLogsReader) ReadLogsWithContext(ctx context.Context, rlr *ReadLogsParams) (*ReadLogsResult, error) {
 
 	getlogsInput := &cloudwatchlogs.GetLogEventsInput{
 		LogGroupName:  aws.String(rlr.GroupName),
@@ -57,13 +136,17 @@ func (cwlr *CloudwatchLogsReader) ReadLogs(rlr *ReadLogsParams) (*ReadLogsResult
 		NextToken:     rlr.NextToken,
 	}
 
+	if rlr.StartFromHead {
+		getlogsInput.StartFromHead = aws.Bool(true)
+	}
+
 	logrus.WithFields(logrus.Fields{
 		"LogGroupName":  rlr.GroupName,
 		"LogStreamName": rlr.StreamName,
 		"NextToken":     rlr.NextToken,
 	}).Debug("GetLogEvents")
 
-	getlogsResult, err := cwlr.cwlogsSvc.GetLogEvents(getlogsInput)
+	getlogsResult, err := cwlr.getLogEvents(ctx, getlogsInput)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read logs from codebuild cloudwatch log group")
 	}
@@ -71,8 +154,31 @@ func (cwlr *CloudwatchLogsReader) ReadLogs(rlr *ReadLogsParams) (*ReadLogsResult
 	// buf := new(bytes.Buffer)
 	logLines := make([]*LogLine, len(getlogsResult.Events))
 
+	// identical events written in the same millisecond are told apart by the order they are returned in
+	occurrences := map[string]int{}
+
 	for n, event := range getlogsResult.Events {
-		logLines[n] = &LogLine{Message: aws.StringValue(event.Message), Timestamp: aws.MillisecondsTimeValue(event.Timestamp)}
+		key := EventKey(rlr.GroupName, rlr.StreamName, event, 0)
+		occurrence := occurrences[key]
+		occurrences[key]++
+
+		if occurrence > 0 {
+			key = EventKey(rlr.GroupName, rlr.StreamName, event, occurrence)
+		}
+
+		logLines[n] = &LogLine{
+			Message:       aws.StringValue(event.Message),
+			Timestamp:     aws.MillisecondsTimeValue(event.Timestamp),
+			IngestionTime: aws.MillisecondsTimeValue(event.IngestionTime),
+			EventID:       key,
+			GroupName:     rlr.GroupName,
+			StreamName:    rlr.StreamName,
+			TaskID:        rlr.TaskID,
+		}
+
+		if rlr.FieldMapping != nil {
+			ParseJSONFields(logLines[n], rlr.FieldMapping)
+		}
 	}
 
 	nextTokenResult := getlogsResult.NextForwardToken
@@ -83,3 +189,68 @@ func (cwlr *CloudwatchLogsReader) ReadLogs(rlr *ReadLogsParams) (*ReadLogsResult
 
 	return &ReadLogsResult{NextToken: nextTokenResult, LogLines: logLines}, nil
 }
+
+func (cwlr *CloudwatchLogsReader) getLogEvents(ctx context.Context, getlogsInput *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+
+	// no limiter means no limit
+	if cwlr.limiter == nil {
+		return cwlr.cwlogsSvc.GetLogEventsWithContext(ctx, getlogsInput)
+	}
+
+	maxRetries := cwlr.maxThrottleRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxThrottleRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := cwlr.limiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		getlogsResult, err := cwlr.cwlogsSvc.GetLogEventsWithContext(ctx, getlogsInput)
+		if err == nil {
+			cwlr.limiter.Succeeded()
+			return getlogsResult, nil
+		}
+
+		if !request.IsErrorThrottle(err) || attempt >= maxRetries {
+			return nil, err
+		}
+
+		backoff := cwlr.limiter.Throttled()
+
+		logrus.WithFields(logrus.Fields{
+			"LogGroupName": aws.StringValue(getlogsInput.LogGroupName),
+			"Backoff":      backoff,
+		}).Debug("GetLogEvents throttled")
+	}
+}
+
+// EventKey build a deterministic key for a log event, GetLogEvents doesn't return event identifiers
+// so this is derived from the group, stream, timestamps and message which are stable across retries,
+// the occurrence counts earlier events in the same page with identical values so repeated lines keep
+// distinct keys, identical events split across a page boundary can't be told apart
+func EventKey(groupName, streamName string, event *cloudwatchlogs.OutputLogEvent, occurrence int) string {
+	h := sha1.New()
+
+	values := []string{
+		groupName,
+		streamName,
+		strconv.FormatInt(aws.Int64Value(event.Timestamp), 10),
+		strconv.FormatInt(aws.Int64Value(event.IngestionTime), 10),
+		aws.StringValue(event.Message),
+	}
+
+	// the first occurrence keeps the key it had before occurrences were counted
+	if occurrence > 0 {
+		values = append(values, strconv.Itoa(occurrence))
+	}
+
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -1,9 +1,15 @@
 package cwlogs
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -16,22 +22,151 @@ func TestReadLogs(t *testing.T) {
 		NextForwardToken: aws.String("f/34139340658027874184690460781927772298499668124394061824"),
 		Events: []*cloudwatchlogs.OutputLogEvent{
 			&cloudwatchlogs.OutputLogEvent{
-				Message: aws.String("test"),
+				Message:       aws.String("test"),
+				Timestamp:     aws.Int64(1549015200000),
+				IngestionTime: aws.Int64(1549015201000),
 			},
 			&cloudwatchlogs.OutputLogEvent{
-				Message: aws.String("test"),
+				Message:       aws.String("test"),
+				Timestamp:     aws.Int64(1549015200001),
+				IngestionTime: aws.Int64(1549015201000),
 			},
 		},
 	}
 
 	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
 
-	cwlogsSvc.On("GetLogEvents", mock.Anything).Return(cwlGetOutput, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(cwlGetOutput, nil)
 
 	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
 
-	res, err := logReader.ReadLogs(&ReadLogsParams{})
+	res, err := logReader.ReadLogs(&ReadLogsParams{GroupName: "/aws/fargate/test", StreamName: "ecs/test/abc123", TaskID: "abc123"})
 	require.Nil(t, err)
 	require.Len(t, res.LogLines, 2)
+	require.Equal(t, aws.MillisecondsTimeValue(aws.Int64(1549015201000)), res.LogLines[0].IngestionTime)
+	require.Equal(t, "/aws/fargate/test", res.LogLines[0].GroupName)
+	require.Equal(t, "ecs/test/abc123", res.LogLines[0].StreamName)
+	require.Equal(t, "abc123", res.LogLines[0].TaskID)
+	require.NotEmpty(t, res.LogLines[0].EventID)
+	require.NotEqual(t, res.LogLines[0].EventID, res.LogLines[1].EventID)
 	require.Equal(t, "f/34139340658027874184690460781927772298499668124394061824", aws.StringValue(res.NextToken))
 }
+
+func TestReadLogs_Throttled(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)).Once()
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("test")},
+		},
+	}, nil)
+
+	limiter := NewRateLimiter(100, 10)
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc, limiter: limiter}
+
+	res, err := logReader.ReadLogs(&ReadLogsParams{})
+	require.Nil(t, err)
+	require.Len(t, res.LogLines, 1)
+
+	metrics := limiter.Metrics()
+	require.Equal(t, int64(2), metrics.Requests)
+	require.Equal(t, int64(1), metrics.Throttled)
+	require.True(t, metrics.TotalWait >= minThrottleBackoff/2)
+}
+
+func TestEventKey(t *testing.T) {
+
+	event := &cloudwatchlogs.OutputLogEvent{
+		Message:       aws.String("test"),
+		Timestamp:     aws.Int64(1549015200000),
+		IngestionTime: aws.Int64(1549015201000),
+	}
+
+	key := EventKey("group", "stream", event, 0)
+	require.Equal(t, key, EventKey("group", "stream", event, 0))
+	require.NotEqual(t, key, EventKey("group", "other-stream", event, 0))
+	require.NotEqual(t, key, EventKey("group", "stream", event, 1))
+}
+
+func TestReadLogs_RepeatedLines(t *testing.T) {
+
+	event := &cloudwatchlogs.OutputLogEvent{
+		Message:       aws.String("retrying"),
+		Timestamp:     aws.Int64(1549015200000),
+		IngestionTime: aws.Int64(1549015201000),
+	}
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{event, event, event},
+	}, nil)
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	res, err := logReader.ReadLogs(&ReadLogsParams{GroupName: "group", StreamName: "stream"})
+	require.Nil(t, err)
+	require.Len(t, res.LogLines, 3)
+	require.Equal(t, EventKey("group", "stream", event, 0), res.LogLines[0].EventID)
+	require.NotEqual(t, res.LogLines[0].EventID, res.LogLines[1].EventID)
+	require.NotEqual(t, res.LogLines[1].EventID, res.LogLines[2].EventID)
+	require.NotEqual(t, res.LogLines[0].EventID, res.LogLines[2].EventID)
+}
+
+func TestReadLogsWithContext_Cancelled(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	// the limiter has no tokens left and refills slowly
+	limiter := NewRateLimiter(0.001, 1)
+	require.Nil(t, limiter.Wait(context.Background()))
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc, limiter: limiter}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := logReader.ReadLogsWithContext(ctx, &ReadLogsParams{})
+	require.Error(t, err)
+	cwlogsSvc.AssertNotCalled(t, "GetLogEventsWithContext", mock.Anything, mock.Anything)
+}
+
+func TestReadLogsWithContext_Request(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the context is passed to the request so cancelling stops it while in flight
+	cwlogsSvc.On("GetLogEventsWithContext", ctx, mock.AnythingOfType("*cloudwatchlogs.GetLogEventsInput")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/1"),
+	}, nil).Once()
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	res, err := logReader.ReadLogsWithContext(ctx, &ReadLogsParams{GroupName: "group", StreamName: "stream"})
+	require.Nil(t, err)
+	require.Equal(t, "f/1", aws.StringValue(res.NextToken))
+	cwlogsSvc.AssertExpectations(t)
+}
+
+func Test_throttleRetryer(t *testing.T) {
+
+	tr := &throttleRetryer{Retryer: client.DefaultRetryer{NumMaxRetries: 3}}
+
+	throttled := &request.Request{
+		Error:        awserr.New("ThrottlingException", "Rate exceeded", nil),
+		HTTPResponse: &http.Response{StatusCode: 400},
+	}
+	require.False(t, tr.ShouldRetry(throttled))
+
+	unavailable := &request.Request{
+		Error:        awserr.New("ServiceUnavailable", "unavailable", nil),
+		HTTPResponse: &http.Response{StatusCode: 503},
+	}
+	require.True(t, tr.ShouldRetry(unavailable))
+}
@@ -0,0 +1,246 @@
+package cwlogs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ExportFormatText plain text with a timestamp prefix
+	ExportFormatText = "text"
+	// ExportFormatJSONLines one JSON document per log line
+	ExportFormatJSONLines = "jsonl"
+	// ExportFormatGzip gzip compressed plain text
+	ExportFormatGzip = "gzip"
+
+	// ManifestSuffix appended to the export path when no manifest path is supplied
+	ManifestSuffix = ".manifest.json"
+)
+
+var (
+	// ErrInvalidExportFormat the export format isn't one of text, jsonl or gzip
+	ErrInvalidExportFormat = errors.New("export format must be one of text, jsonl or gzip")
+)
+
+// ExportParams export a complete log stream to a local file
+type ExportParams struct {
+	GroupName  string `json:"group_name,omitempty" jsonschema:"required"`
+	StreamName string `json:"stream_name,omitempty" jsonschema:"required"`
+	Path       string `json:"path,omitempty" jsonschema:"required"`
+
+	// optional, defaults to text
+	Format string `json:"format,omitempty"`
+	// optional, defaults to DefaultTimeFormat, only used by the text and gzip formats
+	TimeFormat string `json:"time_format,omitempty"`
+	// optional, defaults to the path with ManifestSuffix appended
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	// optional, recorded in the manifest and copied to each log line
+	TaskID     string `json:"task_id,omitempty"`
+	Definition string `json:"definition,omitempty"`
+
+	FieldMapping *FieldMapping `json:"field_mapping,omitempty"`
+}
+
+// ExportResult export result
+type ExportResult struct {
+	Path         string `json:"path,omitempty"`
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	Manifest *ExportManifest `json:"manifest,omitempty"`
+}
+
+// ExportManifest written alongside the exported logs to describe what they contain
+type ExportManifest struct {
+	TaskID     string     `json:"task_id,omitempty"`
+	Definition string     `json:"definition,omitempty"`
+	GroupName  string     `json:"group_name,omitempty"`
+	StreamName string     `json:"stream_name,omitempty"`
+	Format     string     `json:"format,omitempty"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+	LineCount  int64      `json:"line_count"`
+	ExportedAt time.Time  `json:"exported_at"`
+}
+
+// Export read the log stream from the head to the end writing each page to the file as it arrives, only
+// one page is held in memory at a time so very large streams can be exported. The logs are written to a
+// temporary file which is renamed to the path once complete, so a failed export leaves no partial file
+func Export(lr LogsReader, ep *ExportParams) (*ExportResult, error) {
+
+	format := ep.Format
+	if format == "" {
+		format = ExportFormatText
+	}
+
+	timeFormat := ep.TimeFormat
+	if timeFormat == "" {
+		timeFormat = DefaultTimeFormat
+	}
+
+	manifestPath := ep.ManifestPath
+	if manifestPath == "" {
+		manifestPath = ep.Path + ManifestSuffix
+	}
+
+	switch format {
+	case ExportFormatText, ExportFormatJSONLines, ExportFormatGzip:
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+
+	// the temporary file is created alongside the export so the rename doesn't cross file systems
+	tmp, err := ioutil.TempFile(filepath.Dir(ep.Path), filepath.Base(ep.Path)+".*.tmp")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create export file")
+	}
+	tmp.Close()
+
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	sink, err := newExportSink(format, tmpPath, timeFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ExportManifest{
+		TaskID:     ep.TaskID,
+		Definition: ep.Definition,
+		GroupName:  ep.GroupName,
+		StreamName: ep.StreamName,
+		Format:     format,
+	}
+
+	err = exportPages(lr, ep, sink, manifest)
+	if err != nil {
+		sink.Close()
+		return nil, err
+	}
+
+	err = sink.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to close export file")
+	}
+
+	err = os.Chmod(tmpPath, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set export file permissions")
+	}
+
+	err = os.Rename(tmpPath, ep.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to rename export file")
+	}
+
+	manifest.ExportedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode export manifest")
+	}
+
+	err = ioutil.WriteFile(manifestPath, data, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write export manifest")
+	}
+
+	return &ExportResult{Path: ep.Path, ManifestPath: manifestPath, Manifest: manifest}, nil
+}
+
+// exportPages the end of the stream is reached when the reader returns the token it was given
+func exportPages(lr LogsReader, ep *ExportParams, sink LogSink, manifest *ExportManifest) error {
+
+	var nextToken *string
+
+	for {
+		res, err := lr.ReadLogs(&ReadLogsParams{
+			GroupName:     ep.GroupName,
+			StreamName:    ep.StreamName,
+			NextToken:     nextToken,
+			StartFromHead: true,
+			TaskID:        ep.TaskID,
+			FieldMapping:  ep.FieldMapping,
+		})
+		if err != nil {
+			// nothing has been written to the stream yet
+			if isResourceNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		err = sink.WriteLogLines(res.LogLines)
+		if err != nil {
+			return errors.Wrap(err, "failed to write logs to export file")
+		}
+
+		for _, line := range res.LogLines {
+			manifest.LineCount++
+
+			if line.Timestamp.IsZero() {
+				continue
+			}
+
+			ts := line.Timestamp.UTC()
+
+			if manifest.StartTime == nil || ts.Before(*manifest.StartTime) {
+				manifest.StartTime = &ts
+			}
+
+			if manifest.EndTime == nil || ts.After(*manifest.EndTime) {
+				manifest.EndTime = &ts
+			}
+		}
+
+		if res.NextToken == nil || aws.StringValue(res.NextToken) == aws.StringValue(nextToken) {
+			return nil
+		}
+
+		nextToken = res.NextToken
+	}
+}
+
+// fileSink closes the file once the sink writing to it is closed
+type fileSink struct {
+	LogSink
+	f *os.File
+}
+
+func (fs *fileSink) Close() error {
+	err := fs.LogSink.Close()
+	if err != nil {
+		fs.f.Close()
+		return err
+	}
+
+	return fs.f.Close()
+}
+
+func newExportSink(format, path, timeFormat string) (LogSink, error) {
+
+	switch format {
+	case ExportFormatGzip:
+		return NewGzipFileSink(path, timeFormat)
+	case ExportFormatText, ExportFormatJSONLines:
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create export file")
+	}
+
+	if format == ExportFormatJSONLines {
+		return &fileSink{LogSink: NewJSONLinesSink(f), f: f}, nil
+	}
+
+	return &fileSink{LogSink: NewTextSink(f, timeFormat), f: f}, nil
+}
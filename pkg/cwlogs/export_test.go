@@ -0,0 +1,126 @@
+package cwlogs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+)
+
+func TestExport(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.MatchedBy(func(in *cloudwatchlogs.GetLogEventsInput) bool {
+		return in.NextToken == nil && aws.BoolValue(in.StartFromHead)
+	})).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("first"), Timestamp: aws.Int64(1546300800000)},
+			{Message: aws.String("second"), Timestamp: aws.Int64(1546300801000)},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	// empty pages can be returned before the end of the stream
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/1")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/2"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/2")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("third"), Timestamp: aws.Int64(1546300802000)},
+		},
+		NextForwardToken: aws.String("f/3"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/3")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/3"),
+	}, nil)
+
+	dir, err := ioutil.TempDir("", "export")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "task.jsonl")
+
+	res, err := Export(&CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}, &ExportParams{
+		GroupName:  "/aws/fargate/test",
+		StreamName: "ecs/test/abc123",
+		Path:       path,
+		Format:     ExportFormatJSONLines,
+		TaskID:     "abc123",
+		Definition: "test:1",
+	})
+	require.Nil(t, err)
+	require.Equal(t, path+ManifestSuffix, res.ManifestPath)
+
+	f, err := os.Open(path)
+	require.Nil(t, err)
+	defer f.Close()
+
+	messages := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := new(LogLine)
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), line))
+		require.Equal(t, "abc123", line.TaskID)
+		messages = append(messages, line.Message)
+	}
+	require.Equal(t, []string{"first", "second", "third"}, messages)
+
+	data, err := ioutil.ReadFile(res.ManifestPath)
+	require.Nil(t, err)
+
+	manifest := new(ExportManifest)
+	require.Nil(t, json.Unmarshal(data, manifest))
+	require.Equal(t, int64(3), manifest.LineCount)
+	require.Equal(t, "abc123", manifest.TaskID)
+	require.Equal(t, "test:1", manifest.Definition)
+	require.Equal(t, ExportFormatJSONLines, manifest.Format)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), *manifest.StartTime)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 0, 2, 0, time.UTC), *manifest.EndTime)
+}
+
+func TestExport_Failed(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.MatchedBy(func(in *cloudwatchlogs.GetLogEventsInput) bool {
+		return in.NextToken == nil
+	})).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("first"), Timestamp: aws.Int64(1546300800000)},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/1")).Return(nil, errors.New("throttled"))
+
+	dir, err := ioutil.TempDir("", "export")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = Export(&CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}, &ExportParams{
+		GroupName:  "/aws/fargate/test",
+		StreamName: "fargate/test/abc",
+		Path:       filepath.Join(dir, "task.log"),
+	})
+	require.Error(t, err)
+
+	// neither the export nor the temporary file are left behind
+	files, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, files, 0)
+}
+
+func TestExport_InvalidFormat(t *testing.T) {
+
+	_, err := Export(&CloudwatchLogsReader{}, &ExportParams{Path: "task.log", Format: "xml"})
+	require.Equal(t, ErrInvalidExportFormat, err)
+}
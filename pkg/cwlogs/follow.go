@@ -0,0 +1,202 @@
+package cwlogs
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultPollInterval the default interval between reads when following logs
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultDrainQuietPeriod how long the stream must be quiet after following is cancelled before it stops,
+	// cloudwatch can take a few seconds to ingest the last lines written by a task
+	DefaultDrainQuietPeriod = 5 * time.Second
+
+	// MaxDrainPeriod the longest time spent draining a stream after following is cancelled
+	MaxDrainPeriod = time.Minute
+)
+
+var (
+	// ErrLogStreamNotFound the log stream for the task hasn't been created yet
+	ErrLogStreamNotFound = errors.New("log stream not found")
+)
+
+// FollowParams follow cloudwatch logs parameters
+type FollowParams struct {
+	GroupName  string  `json:"group_name,omitempty" jsonschema:"required"`
+	StreamName string  `json:"stream_name,omitempty" jsonschema:"required"`
+	NextToken  *string `json:"next_token,omitempty"`
+
+	TaskID       string        `json:"task_id,omitempty"`
+	FieldMapping *FieldMapping `json:"field_mapping,omitempty"`
+
+	// optional, the attempt recorded on each line when the task is retried
+	Attempt int `json:"attempt,omitempty"`
+
+	// optional, defaults to DefaultPollInterval
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+	// optional, defaults to DefaultDrainQuietPeriod
+	DrainQuietPeriod time.Duration `json:"drain_quiet_period,omitempty"`
+}
+
+// FollowResult follow cloudwatch logs result
+type FollowResult struct {
+	LineCount int64   `json:"line_count,omitempty"`
+	NextToken *string `json:"next_token,omitempty"`
+}
+
+// Follow poll the log stream writing each page of log lines to the sinks, this continues until the
+// context is cancelled at which point the stream is drained until no lines arrive for the quiet period
+func Follow(ctx context.Context, lr LogsReader, fp *FollowParams, sinks ...LogSink) (*FollowResult, error) {
+
+	interval := fp.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+
+	quietPeriod := fp.DrainQuietPeriod
+	if quietPeriod == 0 {
+		quietPeriod = DefaultDrainQuietPeriod
+	}
+
+	res := &FollowResult{NextToken: fp.NextToken}
+
+	var (
+		lastActivity time.Time
+		drainCtx     context.Context
+	)
+
+	for {
+		done := ctx.Err() != nil
+
+		// reads made while draining are bounded by their own deadline as the callers context is cancelled
+		readCtx := ctx
+		if done {
+			if drainCtx == nil {
+				var cancel context.CancelFunc
+				drainCtx, cancel = context.WithTimeout(context.Background(), MaxDrainPeriod)
+				defer cancel()
+			}
+
+			readCtx = drainCtx
+		}
+
+		// read pages until we reach the end of the stream
+		read := 0
+		for {
+			lines, err := followPage(readCtx, lr, fp, res, sinks)
+			if err != nil {
+				// cancelled while waiting to read, the stream is drained on the next pass
+				if readCtx.Err() != nil {
+					break
+				}
+
+				return res, err
+			}
+
+			if lines == 0 {
+				break
+			}
+
+			read += lines
+		}
+
+		if !done {
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+
+			continue
+		}
+
+		if drainCtx.Err() != nil {
+			logrus.WithField("StreamName", fp.StreamName).Warn("stopped draining log stream before it was quiet")
+			return res, nil
+		}
+
+		if lastActivity.IsZero() || read > 0 {
+			lastActivity = time.Now()
+		}
+
+		if time.Since(lastActivity) >= quietPeriod {
+			return res, nil
+		}
+
+		// poll more often while draining so following stops soon after the quiet period
+		drainInterval := interval
+		if drainInterval > quietPeriod {
+			drainInterval = quietPeriod
+		}
+
+		time.Sleep(drainInterval)
+	}
+}
+
+func followPage(ctx context.Context, lr LogsReader, fp *FollowParams, res *FollowResult, sinks []LogSink) (int, error) {
+	// without start from head the first read returns the newest events and skips the rest of the stream
+	rlp := &ReadLogsParams{
+		GroupName:     fp.GroupName,
+		StreamName:    fp.StreamName,
+		NextToken:     res.NextToken,
+		StartFromHead: true,
+		TaskID:        fp.TaskID,
+		FieldMapping:  fp.FieldMapping,
+	}
+
+	var (
+		readRes *ReadLogsResult
+		err     error
+	)
+
+	if clr, ok := lr.(ContextLogsReader); ok {
+		readRes, err = clr.ReadLogsWithContext(ctx, rlp)
+	} else {
+		readRes, err = lr.ReadLogs(rlp)
+	}
+	if err != nil {
+		// the stream isn't created until the task starts writing logs
+		if isResourceNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if fp.Attempt > 0 {
+		for _, line := range readRes.LogLines {
+			line.Attempt = fp.Attempt
+		}
+	}
+
+	for _, sink := range sinks {
+		err := sink.WriteLogLines(readRes.LogLines)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to write logs to sink")
+		}
+	}
+
+	if readRes.NextToken != nil {
+		res.NextToken = readRes.NextToken
+	}
+	res.LineCount += int64(len(readRes.LogLines))
+
+	return len(readRes.LogLines), nil
+}
+
+func isResourceNotFound(err error) bool {
+	if errors.Cause(err) == ErrLogStreamNotFound {
+		return true
+	}
+
+	if aerr, ok := errors.Cause(err).(awserr.Error); ok {
+		return aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package cwlogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+)
+
+func matchToken(token string) interface{} {
+	return mock.MatchedBy(func(in *cloudwatchlogs.GetLogEventsInput) bool {
+		return aws.StringValue(in.NextToken) == token
+	})
+}
+
+func TestFollow(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	// the stream doesn't exist until the task has started
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("")).Return(nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "stream not found", nil)).Once()
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("first")},
+			{Message: aws.String("second")},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/1")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("third")},
+		},
+		NextForwardToken: aws.String("f/2"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/2")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/2"),
+	}, nil)
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	buf := new(bytes.Buffer)
+
+	res, err := Follow(ctx, logReader, &FollowParams{PollInterval: 10 * time.Millisecond, DrainQuietPeriod: 10 * time.Millisecond}, NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, int64(3), res.LineCount)
+	require.Equal(t, "f/2", aws.StringValue(res.NextToken))
+	require.Equal(t, "first\nsecond\nthird\n", buf.String())
+}
+
+func TestFollow_DrainLateLines(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	// the last line is ingested after following has been cancelled
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String(""),
+	}, nil).Once()
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("late")},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil).Once()
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/1")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buf := new(bytes.Buffer)
+
+	res, err := Follow(ctx, logReader, &FollowParams{PollInterval: 10 * time.Millisecond, DrainQuietPeriod: 30 * time.Millisecond}, NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), res.LineCount)
+	require.Equal(t, "late\n", buf.String())
+}
+
+func TestFollow_Error(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	_, err := Follow(context.Background(), logReader, &FollowParams{})
+	require.Error(t, err)
+}
+
+func TestFollow_StartFromHead(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	// without start from head the first read only returns the newest events
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.MatchedBy(func(in *cloudwatchlogs.GetLogEventsInput) bool {
+		return in.NextToken == nil && aws.BoolValue(in.StartFromHead)
+	})).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("first")},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil).Once()
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchToken("f/1")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+
+	logReader := &CloudwatchLogsReader{cwlogsSvc: cwlogsSvc}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	buf := new(bytes.Buffer)
+
+	res, err := Follow(ctx, logReader, &FollowParams{PollInterval: 10 * time.Millisecond, DrainQuietPeriod: 10 * time.Millisecond}, NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), res.LineCount)
+	require.Equal(t, "first\n", buf.String())
+	cwlogsSvc.AssertExpectations(t)
+}
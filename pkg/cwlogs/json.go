@@ -0,0 +1,112 @@
+package cwlogs
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldMapping the keys a structured logger uses for the level, message and time of each JSON log line
+type FieldMapping struct {
+	LevelKey   string `json:"level_key,omitempty"`
+	MessageKey string `json:"message_key,omitempty"`
+	TimeKey    string `json:"time_key,omitempty"`
+}
+
+var (
+	// LogrusFieldMapping field mapping for the logrus JSONFormatter
+	LogrusFieldMapping = &FieldMapping{LevelKey: "level", MessageKey: "msg", TimeKey: "time"}
+
+	// ZapFieldMapping field mapping for the zap production JSON encoder
+	ZapFieldMapping = &FieldMapping{LevelKey: "level", MessageKey: "msg", TimeKey: "ts"}
+
+	// BunyanFieldMapping field mapping for bunyan, numeric levels are converted to their names
+	BunyanFieldMapping = &FieldMapping{LevelKey: "level", MessageKey: "msg", TimeKey: "time"}
+)
+
+// bunyan style numeric levels
+var numericLevels = map[int]string{
+	10: "trace",
+	20: "debug",
+	30: "info",
+	40: "warn",
+	50: "error",
+	60: "fatal",
+}
+
+// ParseJSONFields parse the message of the log line as a JSON object, on success the
+// fields are stored in Fields with the level, message and time keys extracted using the mapping
+func ParseJSONFields(line *LogLine, fm *FieldMapping) bool {
+	if fm == nil {
+		fm = LogrusFieldMapping
+	}
+
+	msg := strings.TrimSpace(line.Message)
+	if !strings.HasPrefix(msg, "{") {
+		return false
+	}
+
+	fields := map[string]interface{}{}
+
+	err := json.Unmarshal([]byte(msg), &fields)
+	if err != nil {
+		return false
+	}
+
+	if v, ok := fields[fm.LevelKey]; ok {
+		line.Level = convertLevel(v)
+		delete(fields, fm.LevelKey)
+	}
+
+	if v, ok := fields[fm.MessageKey].(string); ok {
+		line.Msg = v
+		delete(fields, fm.MessageKey)
+	}
+
+	if v, ok := fields[fm.TimeKey]; ok {
+		if ts, ok := convertTime(v); ok {
+			line.Time = &ts
+			delete(fields, fm.TimeKey)
+		}
+	}
+
+	line.Fields = fields
+
+	return true
+}
+
+func convertLevel(v interface{}) string {
+	switch lvl := v.(type) {
+	case string:
+		return strings.ToLower(lvl)
+	case float64:
+		if name, ok := numericLevels[int(lvl)]; ok {
+			return name
+		}
+		return strconv.Itoa(int(lvl))
+	}
+
+	return ""
+}
+
+func convertTime(v interface{}) (time.Time, bool) {
+	switch ts := v.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	case float64:
+		// zap encodes epoch seconds as a float, anything this large is epoch milliseconds
+		if ts > 1e12 {
+			ts = ts / 1000
+		}
+		sec, frac := math.Modf(ts)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,78 @@
+package cwlogs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		fm      *FieldMapping
+		want    bool
+		level   string
+		message string
+		time    time.Time
+		fields  map[string]interface{}
+	}{
+		{
+			name:    "logrus",
+			msg:     `{"level":"info","msg":"started","time":"2019-02-01T10:00:00Z","port":8080}`,
+			fm:      LogrusFieldMapping,
+			want:    true,
+			level:   "info",
+			message: "started",
+			time:    time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC),
+			fields:  map[string]interface{}{"port": float64(8080)},
+		},
+		{
+			name:    "zap",
+			msg:     `{"level":"WARN","ts":1549015200.5,"msg":"slow","caller":"main.go:12"}`,
+			fm:      ZapFieldMapping,
+			want:    true,
+			level:   "warn",
+			message: "slow",
+			time:    time.Date(2019, 2, 1, 10, 0, 0, 5e8, time.UTC),
+			fields:  map[string]interface{}{"caller": "main.go:12"},
+		},
+		{
+			name:    "bunyan",
+			msg:     `{"name":"app","level":50,"msg":"failed","time":"2019-02-01T10:00:00.000Z","v":0}`,
+			fm:      BunyanFieldMapping,
+			want:    true,
+			level:   "error",
+			message: "failed",
+			time:    time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC),
+			fields:  map[string]interface{}{"name": "app", "v": float64(0)},
+		},
+		{
+			name: "plain text",
+			msg:  "hello world",
+			want: false,
+		},
+		{
+			name: "invalid json",
+			msg:  "{hello world",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := &LogLine{Message: tt.msg}
+			got := ParseJSONFields(line, tt.fm)
+			require.Equal(t, tt.want, got)
+			if !tt.want {
+				require.Nil(t, line.Fields)
+				return
+			}
+			require.Equal(t, tt.level, line.Level)
+			require.Equal(t, tt.message, line.Msg)
+			require.NotNil(t, line.Time)
+			require.True(t, tt.time.Equal(*line.Time))
+			require.Equal(t, tt.fields, line.Fields)
+		})
+	}
+}
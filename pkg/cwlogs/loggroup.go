@@ -0,0 +1,242 @@
+package cwlogs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// LogGroupConfig log group settings applied when a task is defined
+type LogGroupConfig struct {
+	// optional, a format containing exactly one %s which is replaced with the definition name
+	NameFormat      string            `json:"name_format,omitempty"`
+	RetentionInDays *int64            `json:"retention_in_days,omitempty"`
+	KMSKeyARN       *string           `json:"kms_key_arn,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+// Validate check the name format contains exactly one %s and no other verbs
+func (lgc *LogGroupConfig) Validate() error {
+	if lgc == nil || lgc.NameFormat == "" {
+		return nil
+	}
+
+	if strings.Count(lgc.NameFormat, "%") != 1 || strings.Count(lgc.NameFormat, "%s") != 1 {
+		return errors.Errorf("log group name format must contain exactly one %%s: %s", lgc.NameFormat)
+	}
+
+	return nil
+}
+
+// LogGroupName build the log group name for the definition, the default format is used if none is configured
+func (lgc *LogGroupConfig) LogGroupName(defaultFormat, definitionName string) string {
+	if lgc == nil || lgc.NameFormat == "" {
+		return fmt.Sprintf(defaultFormat, definitionName)
+	}
+
+	return fmt.Sprintf(lgc.NameFormat, definitionName)
+}
+
+// DescribeLogGroupConfig build the config which would define the named log group, this is the reverse of
+// LogGroupName and EnsureLogGroupParams, the name format is the one recorded when the definition was created
+// and is empty if the default was used, nil is returned when the log group only uses the defaults
+func DescribeLogGroupConfig(cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI, name, nameFormat string, defaultTags map[string]string) (*LogGroupConfig, error) {
+
+	lgc := &LogGroupConfig{NameFormat: nameFormat}
+
+	logGroup, err := FindLogGroup(cwlogsSvc, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the log group is created on demand so it may not exist yet
+	if logGroup != nil {
+		lgc.RetentionInDays = logGroup.RetentionInDays
+		lgc.KMSKeyARN = logGroup.KmsKeyId
+
+		tagsRes, err := cwlogsSvc.ListTagsLogGroup(&cloudwatchlogs.ListTagsLogGroupInput{
+			LogGroupName: aws.String(name),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "list log group tags failed.")
+		}
+
+		for k, v := range aws.StringValueMap(tagsRes.Tags) {
+			if dv, ok := defaultTags[k]; ok && dv == v {
+				continue
+			}
+
+			if lgc.Tags == nil {
+				lgc.Tags = map[string]string{}
+			}
+
+			lgc.Tags[k] = v
+		}
+	}
+
+	if lgc.NameFormat == "" && lgc.RetentionInDays == nil && lgc.KMSKeyARN == nil && len(lgc.Tags) == 0 {
+		return nil, nil
+	}
+
+	return lgc, nil
+}
+
+// EnsureLogGroupParams ensure log group parameters
+type EnsureLogGroupParams struct {
+	Name            string            `json:"name,omitempty" jsonschema:"required"`
+	RetentionInDays *int64            `json:"retention_in_days,omitempty"`
+	KMSKeyARN       *string           `json:"kms_key_arn,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+// EnsureLogGroup create the log group, if it already exists the retention, encryption key and tags are reconciled
+func EnsureLogGroup(cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI, elp *EnsureLogGroupParams) error {
+
+	_, err := cwlogsSvc.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(elp.Name),
+		KmsKeyId:     elp.KMSKeyARN,
+		Tags:         aws.StringMap(elp.Tags),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != cloudwatchlogs.ErrCodeResourceAlreadyExistsException {
+			return errors.Wrap(err, "create log group failed.")
+		}
+
+		logrus.WithField("name", elp.Name).Info("cloudwatch log group exists")
+
+		return reconcileLogGroup(cwlogsSvc, elp)
+	}
+
+	if elp.RetentionInDays != nil {
+		_, err = cwlogsSvc.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(elp.Name),
+			RetentionInDays: elp.RetentionInDays,
+		})
+		if err != nil {
+			return errors.Wrap(err, "put log group retention policy failed.")
+		}
+	}
+
+	return nil
+}
+
+func reconcileLogGroup(cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI, elp *EnsureLogGroupParams) error {
+
+	logGroup, err := FindLogGroup(cwlogsSvc, elp.Name)
+	if err != nil {
+		return err
+	}
+
+	if logGroup == nil {
+		return errors.Errorf("log group not found: %s", elp.Name)
+	}
+
+	if elp.RetentionInDays != nil && aws.Int64Value(elp.RetentionInDays) != aws.Int64Value(logGroup.RetentionInDays) {
+		logrus.WithFields(logrus.Fields{
+			"name":            elp.Name,
+			"retentionInDays": aws.Int64Value(elp.RetentionInDays),
+		}).Info("update log group retention")
+
+		_, err = cwlogsSvc.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(elp.Name),
+			RetentionInDays: elp.RetentionInDays,
+		})
+		if err != nil {
+			return errors.Wrap(err, "put log group retention policy failed.")
+		}
+	}
+
+	if elp.KMSKeyARN != nil && aws.StringValue(elp.KMSKeyARN) != aws.StringValue(logGroup.KmsKeyId) {
+		logrus.WithField("name", elp.Name).Info("update log group kms key")
+
+		_, err = cwlogsSvc.AssociateKmsKey(&cloudwatchlogs.AssociateKmsKeyInput{
+			LogGroupName: aws.String(elp.Name),
+			KmsKeyId:     elp.KMSKeyARN,
+		})
+		if err != nil {
+			return errors.Wrap(err, "associate log group kms key failed.")
+		}
+	}
+
+	if len(elp.Tags) > 0 {
+		_, err = cwlogsSvc.TagLogGroup(&cloudwatchlogs.TagLogGroupInput{
+			LogGroupName: aws.String(elp.Name),
+			Tags:         aws.StringMap(elp.Tags),
+		})
+		if err != nil {
+			return errors.Wrap(err, "tag log group failed.")
+		}
+	}
+
+	return nil
+}
+
+// DeleteLogGroup delete the log group, returns false if it didn't exist
+func DeleteLogGroup(cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI, name string) (bool, error) {
+
+	_, err := cwlogsSvc.DeleteLogGroup(&cloudwatchlogs.DeleteLogGroupInput{
+		LogGroupName: aws.String(name),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "delete log group failed.")
+	}
+
+	logrus.WithField("name", name).Info("deleted cloudwatch log group")
+
+	return true, nil
+}
+
+// FindLogGroup find the named log group, nil is returned if it doesn't exist
+func FindLogGroup(cwlogsSvc cloudwatchlogsiface.CloudWatchLogsAPI, name string) (*cloudwatchlogs.LogGroup, error) {
+
+	descRes, err := cwlogsSvc.DescribeLogGroups(&cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe log group failed.")
+	}
+
+	// the describe call matches on prefix so the results are searched for the exact name
+	for _, lg := range descRes.LogGroups {
+		if aws.StringValue(lg.LogGroupName) == name {
+			return lg, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// EnsureLogGroupParams build the parameters used to ensure the named log group, the configured tags are merged over the defaults
+func (lgc *LogGroupConfig) EnsureLogGroupParams(name string, defaultTags map[string]string) *EnsureLogGroupParams {
+
+	tags := map[string]string{}
+
+	for k, v := range defaultTags {
+		tags[k] = v
+	}
+
+	elp := &EnsureLogGroupParams{Name: name, Tags: tags}
+
+	if lgc == nil {
+		return elp
+	}
+
+	for k, v := range lgc.Tags {
+		tags[k] = v
+	}
+
+	elp.RetentionInDays = lgc.RetentionInDays
+	elp.KMSKeyARN = lgc.KMSKeyARN
+
+	return elp
+}
@@ -0,0 +1,137 @@
+package cwlogs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+)
+
+func TestLogGroupConfig_LogGroupName(t *testing.T) {
+	var lgc *LogGroupConfig
+	require.Equal(t, "/aws/fargate/test", lgc.LogGroupName("/aws/fargate/%s", "test"))
+
+	lgc = &LogGroupConfig{NameFormat: "/ci/%s/logs"}
+	require.Equal(t, "/ci/test/logs", lgc.LogGroupName("/aws/fargate/%s", "test"))
+
+}
+
+func TestLogGroupConfig_Validate(t *testing.T) {
+	var lgc *LogGroupConfig
+	require.Nil(t, lgc.Validate())
+
+	require.Nil(t, (&LogGroupConfig{}).Validate())
+	require.Nil(t, (&LogGroupConfig{NameFormat: "/ci/%s/logs"}).Validate())
+
+	require.Error(t, (&LogGroupConfig{NameFormat: "/ci/shared"}).Validate())
+	require.Error(t, (&LogGroupConfig{NameFormat: "/ci/%s/%s"}).Validate())
+	require.Error(t, (&LogGroupConfig{NameFormat: "/ci/%d"}).Validate())
+	require.Error(t, (&LogGroupConfig{NameFormat: "/ci/%s/%d"}).Validate())
+}
+
+func TestDescribeLogGroupConfig(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/ci/test/logs"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("/ci/test/logs"), RetentionInDays: aws.Int64(14)},
+		},
+	}, nil)
+	cwlogsSvc.On("ListTagsLogGroup", &cloudwatchlogs.ListTagsLogGroupInput{
+		LogGroupName: aws.String("/ci/test/logs"),
+	}).Return(&cloudwatchlogs.ListTagsLogGroupOutput{
+		Tags: aws.StringMap(map[string]string{"createdBy": "fargate-run-job", "team": "build"}),
+	}, nil)
+
+	got, err := DescribeLogGroupConfig(cwlogsSvc, "/ci/test/logs", "/ci/%s/logs", map[string]string{"createdBy": "fargate-run-job"})
+	require.Nil(t, err)
+	require.Equal(t, &LogGroupConfig{
+		NameFormat:      "/ci/%s/logs",
+		RetentionInDays: aws.Int64(14),
+		Tags:            map[string]string{"team": "build"},
+	}, got)
+}
+
+func TestDescribeLogGroupConfig_Defaults(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("DescribeLogGroups", mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, nil)
+
+	got, err := DescribeLogGroupConfig(cwlogsSvc, "/aws/fargate/test", "", nil)
+	require.Nil(t, err)
+	require.Nil(t, got)
+}
+
+func TestLogGroupConfig_EnsureLogGroupParams(t *testing.T) {
+	lgc := &LogGroupConfig{
+		RetentionInDays: aws.Int64(14),
+		Tags:            map[string]string{"createdBy": "ci", "team": "build"},
+	}
+
+	got := lgc.EnsureLogGroupParams("/aws/fargate/test", map[string]string{"createdBy": "fargate-run-job"})
+	require.Equal(t, &EnsureLogGroupParams{
+		Name:            "/aws/fargate/test",
+		RetentionInDays: aws.Int64(14),
+		Tags:            map[string]string{"createdBy": "ci", "team": "build"},
+	}, got)
+}
+
+func TestEnsureLogGroup_Create(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("CreateLogGroup", &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String("/aws/fargate/test"),
+		KmsKeyId:     aws.String("arn:aws:kms:ap-southeast-2:123456789012:key/abc123"),
+		Tags:         map[string]*string{"createdBy": aws.String("fargate-run-job")},
+	}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvc.On("PutRetentionPolicy", &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/aws/fargate/test"),
+		RetentionInDays: aws.Int64(30),
+	}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+	err := EnsureLogGroup(cwlogsSvc, &EnsureLogGroupParams{
+		Name:            "/aws/fargate/test",
+		RetentionInDays: aws.Int64(30),
+		KMSKeyARN:       aws.String("arn:aws:kms:ap-southeast-2:123456789012:key/abc123"),
+		Tags:            map[string]string{"createdBy": "fargate-run-job"},
+	})
+	require.Nil(t, err)
+	cwlogsSvc.AssertExpectations(t)
+}
+
+func TestEnsureLogGroup_Reconcile(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(nil, awserr.New(cloudwatchlogs.ErrCodeResourceAlreadyExistsException, "exists", nil))
+	cwlogsSvc.On("DescribeLogGroups", mock.AnythingOfType("*cloudwatchlogs.DescribeLogGroupsInput")).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("/aws/fargate/test-other")},
+			{LogGroupName: aws.String("/aws/fargate/test"), RetentionInDays: aws.Int64(7)},
+		},
+	}, nil)
+	cwlogsSvc.On("PutRetentionPolicy", &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/aws/fargate/test"),
+		RetentionInDays: aws.Int64(30),
+	}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+	cwlogsSvc.On("AssociateKmsKey", mock.AnythingOfType("*cloudwatchlogs.AssociateKmsKeyInput")).Return(&cloudwatchlogs.AssociateKmsKeyOutput{}, nil)
+	cwlogsSvc.On("TagLogGroup", mock.AnythingOfType("*cloudwatchlogs.TagLogGroupInput")).Return(&cloudwatchlogs.TagLogGroupOutput{}, nil)
+
+	err := EnsureLogGroup(cwlogsSvc, &EnsureLogGroupParams{
+		Name:            "/aws/fargate/test",
+		RetentionInDays: aws.Int64(30),
+		KMSKeyARN:       aws.String("arn:aws:kms:ap-southeast-2:123456789012:key/abc123"),
+		Tags:            map[string]string{"createdBy": "fargate-run-job"},
+	})
+	require.Nil(t, err)
+	cwlogsSvc.AssertExpectations(t)
+}
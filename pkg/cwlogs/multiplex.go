@@ -0,0 +1,282 @@
+package cwlogs
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errMultiplexStopped = errors.New("multiplexer stopped")
+)
+
+const (
+	// DefaultReorderWindow how long lines are held so lines from other streams can be merged in timestamp order
+	DefaultReorderWindow = 2 * time.Second
+
+	// minReleaseInterval the shortest interval between releasing lines from the reorder buffer
+	minReleaseInterval = 10 * time.Millisecond
+)
+
+// MultiplexStream a log stream followed as part of a multiplexed view, ECS and CodeBuild streams can be mixed
+type MultiplexStream struct {
+	Label      string `json:"label,omitempty" jsonschema:"required"`
+	GroupName  string `json:"group_name,omitempty" jsonschema:"required"`
+	StreamName string `json:"stream_name,omitempty" jsonschema:"required"`
+
+	TaskID       string        `json:"task_id,omitempty"`
+	FieldMapping *FieldMapping `json:"field_mapping,omitempty"`
+
+	// optional, defaults to the reader supplied to the multiplexer
+	Reader LogsReader `json:"-"`
+}
+
+// MultiplexParams multiplex log streams parameters
+type MultiplexParams struct {
+	Streams []*MultiplexStream `json:"streams,omitempty" jsonschema:"required"`
+
+	// optional, defaults to DefaultPollInterval
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+	// optional, defaults to DefaultDrainQuietPeriod
+	DrainQuietPeriod time.Duration `json:"drain_quiet_period,omitempty"`
+
+	// optional, defaults to DefaultReorderWindow
+	ReorderWindow time.Duration `json:"reorder_window,omitempty"`
+
+	// optional, called when following a stream fails, this doesn't stop the other streams
+	OnError func(label string, err error) `json:"-"`
+}
+
+// MultiplexResult multiplex log streams result
+type MultiplexResult struct {
+	LineCount int64            `json:"line_count,omitempty"`
+	Errors    map[string]error `json:"-"`
+}
+
+// Multiplexer follows many log streams concurrently and merges the lines in timestamp order
+type Multiplexer struct {
+	lr LogsReader
+}
+
+// NewMultiplexer create a multiplexer, the reader is used for any stream which doesn't supply its own
+func NewMultiplexer(lr LogsReader) *Multiplexer {
+	return &Multiplexer{lr: lr}
+}
+
+// Multiplex follow all the streams until the context is cancelled, lines are prefixed with
+// the stream label and written to the sinks in timestamp order within the reorder window
+func (mx *Multiplexer) Multiplex(ctx context.Context, mp *MultiplexParams, sinks ...LogSink) (*MultiplexResult, error) {
+
+	if mp.ReorderWindow < 0 {
+		return nil, errors.Errorf("reorder window must not be negative: %s", mp.ReorderWindow)
+	}
+
+	window := mp.ReorderWindow
+	if window == 0 {
+		window = DefaultReorderWindow
+	}
+
+	releaseInterval := window / 4
+	if releaseInterval < minReleaseInterval {
+		releaseInterval = minReleaseInterval
+	}
+
+	res := &MultiplexResult{Errors: map[string]error{}}
+
+	// followers are cancelled and unblocked if writing to the sinks fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	linesCh := make(chan []*LogLine)
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, stream := range mp.Streams {
+		wg.Add(1)
+
+		go func(stream *MultiplexStream) {
+			defer wg.Done()
+
+			lr := stream.Reader
+			if lr == nil {
+				lr = mx.lr
+			}
+
+			_, err := Follow(ctx, lr, &FollowParams{
+				GroupName:        stream.GroupName,
+				StreamName:       stream.StreamName,
+				TaskID:           stream.TaskID,
+				FieldMapping:     stream.FieldMapping,
+				PollInterval:     mp.PollInterval,
+				DrainQuietPeriod: mp.DrainQuietPeriod,
+			}, &labelSink{label: stream.Label, linesCh: linesCh, stopCh: stopCh})
+			if err != nil && errors.Cause(err) != errMultiplexStopped {
+				logrus.WithError(err).WithField("label", stream.Label).Warn("failed to follow log stream")
+
+				mu.Lock()
+				res.Errors[stream.Label] = err
+				mu.Unlock()
+
+				if mp.OnError != nil {
+					mp.OnError(stream.Label, err)
+				}
+			}
+		}(stream)
+	}
+
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+
+	rb := &reorderBuffer{window: window}
+	ticker := time.NewTicker(releaseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case lines := <-linesCh:
+			rb.add(time.Now(), lines)
+			continue
+		case <-ticker.C:
+			err := writeSinks(sinks, rb.release(time.Now()), res)
+			if err != nil {
+				close(stopCh)
+				cancel()
+				<-doneCh
+
+				return res, err
+			}
+			continue
+		case <-doneCh:
+		}
+
+		// all the streams are drained so flush everything that is left
+		return res, writeSinks(sinks, rb.release(time.Time{}), res)
+	}
+}
+
+func writeSinks(sinks []LogSink, lines []*LogLine, res *MultiplexResult) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	for _, sink := range sinks {
+		err := sink.WriteLogLines(lines)
+		if err != nil {
+			return errors.Wrap(err, "failed to write logs to sink")
+		}
+	}
+
+	res.LineCount += int64(len(lines))
+
+	return nil
+}
+
+// labelSink prefixes each line with the stream label and passes them to the multiplexer
+type labelSink struct {
+	label   string
+	linesCh chan<- []*LogLine
+	stopCh  <-chan struct{}
+}
+
+func (ls *labelSink) WriteLogLines(lines []*LogLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	labelled := make([]*LogLine, len(lines))
+
+	for n, line := range lines {
+		l := *line
+		l.Message = fmt.Sprintf("[%s] %s", ls.label, line.Message)
+		labelled[n] = &l
+	}
+
+	select {
+	case ls.linesCh <- labelled:
+		return nil
+	case <-ls.stopCh:
+		return errMultiplexStopped
+	}
+}
+
+func (ls *labelSink) Close() error {
+	return nil
+}
+
+type bufferedLine struct {
+	line     *LogLine
+	arrived  time.Time
+	released bool
+}
+
+// reorderBuffer holds lines for the reorder window, lines are released in timestamp order
+// once the oldest line in the buffer has been held for the window
+type reorderBuffer struct {
+	window  time.Duration
+	byTime  lineHeap
+	arrival []*bufferedLine
+}
+
+func (rb *reorderBuffer) add(now time.Time, lines []*LogLine) {
+	for _, line := range lines {
+		bl := &bufferedLine{line: line, arrived: now}
+		heap.Push(&rb.byTime, bl)
+		rb.arrival = append(rb.arrival, bl)
+	}
+}
+
+// release lines held longer than the window, a zero time releases everything
+func (rb *reorderBuffer) release(now time.Time) []*LogLine {
+	var lines []*LogLine
+
+	for rb.byTime.Len() > 0 {
+		// drop lines from the front of the arrival queue which were already released
+		for rb.arrival[0].released {
+			rb.arrival = rb.arrival[1:]
+		}
+
+		if !now.IsZero() && now.Sub(rb.arrival[0].arrived) < rb.window {
+			break
+		}
+
+		bl := heap.Pop(&rb.byTime).(*bufferedLine)
+		bl.released = true
+		lines = append(lines, bl.line)
+	}
+
+	if rb.byTime.Len() == 0 {
+		rb.arrival = nil
+	}
+
+	return lines
+}
+
+type lineHeap []*bufferedLine
+
+func (h lineHeap) Len() int           { return len(h) }
+func (h lineHeap) Less(i, j int) bool { return h[i].line.Timestamp.Before(h[j].line.Timestamp) }
+func (h lineHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *lineHeap) Push(x interface{}) {
+	*h = append(*h, x.(*bufferedLine))
+}
+
+func (h *lineHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,167 @@
+package cwlogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+)
+
+func matchStream(stream, token string) interface{} {
+	return mock.MatchedBy(func(in *cloudwatchlogs.GetLogEventsInput) bool {
+		return aws.StringValue(in.LogStreamName) == stream && aws.StringValue(in.NextToken) == token
+	})
+}
+
+func TestMultiplexer_Multiplex(t *testing.T) {
+
+	cwlogsSvc := &awsmocks.CloudWatchLogsAPI{}
+
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchStream("ecs/task/abc", "")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("one"), Timestamp: aws.Int64(1000)},
+			{Message: aws.String("three"), Timestamp: aws.Int64(3000)},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchStream("codebuild/def", "")).Return(&cloudwatchlogs.GetLogEventsOutput{
+		Events: []*cloudwatchlogs.OutputLogEvent{
+			{Message: aws.String("two"), Timestamp: aws.Int64(2000)},
+		},
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, matchStream("ecs/task/ghi", "")).Return(nil, errors.New("access denied"))
+	cwlogsSvc.On("GetLogEventsWithContext", mock.Anything, mock.Anything).Return(&cloudwatchlogs.GetLogEventsOutput{
+		NextForwardToken: aws.String("f/1"),
+	}, nil)
+
+	mx := NewMultiplexer(&CloudwatchLogsReader{cwlogsSvc: cwlogsSvc})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var failed []string
+
+	buf := new(bytes.Buffer)
+
+	res, err := mx.Multiplex(ctx, &MultiplexParams{
+		Streams: []*MultiplexStream{
+			{Label: "ecs-1", GroupName: "/aws/fargate/task", StreamName: "ecs/task/abc"},
+			{Label: "build-1", GroupName: "/aws/codebuild/project", StreamName: "codebuild/def"},
+			{Label: "ecs-2", GroupName: "/aws/fargate/task", StreamName: "ecs/task/ghi"},
+		},
+		PollInterval:     10 * time.Millisecond,
+		DrainQuietPeriod: 10 * time.Millisecond,
+		ReorderWindow:    20 * time.Millisecond,
+		OnError: func(label string, err error) {
+			failed = append(failed, label)
+		},
+	}, NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, int64(3), res.LineCount)
+	require.Contains(t, res.Errors, "ecs-2")
+	require.Equal(t, []string{"ecs-2"}, failed)
+	require.Equal(t, "[ecs-1] one\n[build-1] two\n[ecs-1] three\n", buf.String())
+}
+
+func Test_reorderBuffer(t *testing.T) {
+
+	now := time.Now()
+
+	rb := &reorderBuffer{window: time.Second}
+
+	rb.add(now, []*LogLine{{Message: "b", Timestamp: now.Add(2 * time.Millisecond)}})
+	rb.add(now.Add(500*time.Millisecond), []*LogLine{{Message: "a", Timestamp: now.Add(1 * time.Millisecond)}, {Message: "c", Timestamp: now.Add(3 * time.Millisecond)}})
+
+	require.Len(t, rb.release(now.Add(500*time.Millisecond)), 0)
+
+	// b is overdue, a has an earlier timestamp so is released first
+	lines := rb.release(now.Add(time.Second))
+	require.Len(t, lines, 2)
+	require.Equal(t, "a", lines[0].Message)
+	require.Equal(t, "b", lines[1].Message)
+
+	lines = rb.release(time.Time{})
+	require.Len(t, lines, 1)
+	require.Equal(t, "c", lines[0].Message)
+}
+
+type failingSink struct{}
+
+func (fs *failingSink) WriteLogLines(lines []*LogLine) error {
+	return errors.New("disk full")
+}
+
+func (fs *failingSink) Close() error {
+	return nil
+}
+
+// countingReader always returns a line and counts the reads
+type countingReader struct {
+	reads int64
+}
+
+func (cr *countingReader) ReadLogs(rlp *ReadLogsParams) (*ReadLogsResult, error) {
+	atomic.AddInt64(&cr.reads, 1)
+	return &ReadLogsResult{LogLines: []*LogLine{{Message: "line"}}}, nil
+}
+
+// quietReader never returns any lines
+type quietReader struct{}
+
+func (qr *quietReader) ReadLogs(rlp *ReadLogsParams) (*ReadLogsResult, error) {
+	return &ReadLogsResult{}, nil
+}
+
+func TestMultiplexer_Multiplex_SinkError(t *testing.T) {
+
+	lr := &countingReader{}
+
+	mx := NewMultiplexer(lr)
+
+	_, err := mx.Multiplex(context.Background(), &MultiplexParams{
+		Streams: []*MultiplexStream{
+			{Label: "ecs-1", GroupName: "/aws/fargate/task", StreamName: "ecs/task/abc"},
+			{Label: "ecs-2", GroupName: "/aws/fargate/task", StreamName: "ecs/task/def"},
+		},
+		PollInterval:     time.Millisecond,
+		DrainQuietPeriod: time.Millisecond,
+		ReorderWindow:    4 * time.Millisecond,
+	}, &failingSink{})
+	require.EqualError(t, err, "failed to write logs to sink: disk full")
+
+	// the followers have stopped so there are no more reads
+	reads := atomic.LoadInt64(&lr.reads)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, reads, atomic.LoadInt64(&lr.reads))
+}
+
+func TestMultiplexer_Multiplex_ReorderWindow(t *testing.T) {
+
+	mx := NewMultiplexer(&countingReader{})
+
+	_, err := mx.Multiplex(context.Background(), &MultiplexParams{ReorderWindow: -time.Second})
+	require.EqualError(t, err, "reorder window must not be negative: -1s")
+
+	// a window too small to divide into release ticks is clamped rather than panicking
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = mx.Multiplex(ctx, &MultiplexParams{
+		Streams: []*MultiplexStream{
+			{Label: "ecs-1", GroupName: "/aws/fargate/task", StreamName: "ecs/task/abc", Reader: &quietReader{}},
+		},
+		PollInterval:     time.Millisecond,
+		DrainQuietPeriod: time.Millisecond,
+		ReorderWindow:    time.Nanosecond,
+	})
+	require.Nil(t, err)
+}
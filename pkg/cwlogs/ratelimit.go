@@ -0,0 +1,165 @@
+package cwlogs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRequestsPerSecond the default rate of GetLogEvents calls, this is limited per account and region
+	DefaultRequestsPerSecond = 10
+
+	// DefaultBurst the default number of calls which can be made at once before the rate applies
+	DefaultBurst = 10
+
+	// DefaultMaxThrottleRetries the number of times a throttled call is retried
+	DefaultMaxThrottleRetries = 5
+
+	minThrottleBackoff = 200 * time.Millisecond
+	maxThrottleBackoff = 10 * time.Second
+)
+
+// DefaultRateLimiter rate limiter for readers in this process, readers only share it when it is supplied in their options
+var DefaultRateLimiter = NewRateLimiter(DefaultRequestsPerSecond, DefaultBurst)
+
+// RateLimiterMetrics counters for calls made through the rate limiter
+type RateLimiterMetrics struct {
+	Requests  int64         `json:"requests,omitempty"`
+	Throttled int64         `json:"throttled,omitempty"`
+	TotalWait time.Duration `json:"total_wait,omitempty"`
+	MaxWait   time.Duration `json:"max_wait,omitempty"`
+}
+
+// RateLimiter token bucket rate limiter which can be shared between log readers, when a call
+// is throttled all callers back off until the backoff period has passed
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	backoff      time.Duration
+	backoffUntil time.Time
+
+	metrics RateLimiterMetrics
+}
+
+// NewRateLimiter create a rate limiter allowing the number of requests per second with a burst
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SetRate update the rate and burst, this applies to all the readers sharing the limiter
+func (rl *RateLimiter) SetRate(requestsPerSecond float64, burst int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill(time.Now())
+
+	rl.rate = requestsPerSecond
+	rl.burst = float64(burst)
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+}
+
+// Wait block until a call can be made, or the context is cancelled
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	delay := rl.reserve(time.Now())
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Throttled record a throttled call, this increases the backoff for all callers and returns it
+func (rl *RateLimiter) Throttled() time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.metrics.Throttled++
+
+	switch {
+	case rl.backoff == 0:
+		rl.backoff = minThrottleBackoff
+	case rl.backoff < maxThrottleBackoff:
+		rl.backoff *= 2
+		if rl.backoff > maxThrottleBackoff {
+			rl.backoff = maxThrottleBackoff
+		}
+	}
+
+	rl.backoffUntil = time.Now().Add(rl.backoff)
+
+	return rl.backoff
+}
+
+// Succeeded record a successful call, this resets the backoff
+func (rl *RateLimiter) Succeeded() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.backoff = 0
+}
+
+// Metrics return a snapshot of the limiter metrics
+func (rl *RateLimiter) Metrics() RateLimiterMetrics {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.metrics
+}
+
+// reserve take a token and return how long the caller needs to wait before using it
+func (rl *RateLimiter) reserve(now time.Time) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill(now)
+
+	rl.tokens--
+
+	var delay time.Duration
+
+	if rl.tokens < 0 && rl.rate > 0 {
+		delay = time.Duration(-rl.tokens / rl.rate * float64(time.Second))
+	}
+
+	if backoff := rl.backoffUntil.Sub(now); backoff > delay {
+		delay = backoff
+	}
+
+	rl.metrics.Requests++
+	rl.metrics.TotalWait += delay
+	if delay > rl.metrics.MaxWait {
+		rl.metrics.MaxWait = delay
+	}
+
+	return delay
+}
+
+func (rl *RateLimiter) refill(now time.Time) {
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.last = now
+}
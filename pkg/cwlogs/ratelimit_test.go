@@ -0,0 +1,55 @@
+package cwlogs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+
+	now := time.Now()
+
+	rl := NewRateLimiter(10, 2)
+	rl.last = now
+
+	require.Equal(t, time.Duration(0), rl.reserve(now))
+	require.Equal(t, time.Duration(0), rl.reserve(now))
+	require.Equal(t, 100*time.Millisecond, rl.reserve(now))
+	require.Equal(t, 200*time.Millisecond, rl.reserve(now))
+
+	// tokens are refilled over time
+	require.Equal(t, time.Duration(0), rl.reserve(now.Add(time.Second)))
+
+	metrics := rl.Metrics()
+	require.Equal(t, int64(5), metrics.Requests)
+	require.Equal(t, 300*time.Millisecond, metrics.TotalWait)
+	require.Equal(t, 200*time.Millisecond, metrics.MaxWait)
+}
+
+func TestRateLimiter_Throttled(t *testing.T) {
+
+	rl := NewRateLimiter(10, 2)
+
+	require.Equal(t, minThrottleBackoff, rl.Throttled())
+	require.Equal(t, 2*minThrottleBackoff, rl.Throttled())
+	require.True(t, rl.reserve(time.Now()) > minThrottleBackoff)
+
+	rl.Succeeded()
+	require.Equal(t, minThrottleBackoff, rl.Throttled())
+	require.Equal(t, int64(3), rl.Metrics().Throttled)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+
+	rl := NewRateLimiter(1, 1)
+
+	require.Nil(t, rl.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Equal(t, context.Canceled, rl.Wait(ctx))
+}
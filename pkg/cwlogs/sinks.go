@@ -0,0 +1,192 @@
+package cwlogs
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeFormat the default timestamp format used when writing text log lines
+	DefaultTimeFormat = time.RFC3339
+)
+
+// LogSink writes pages of log lines to a destination
+type LogSink interface {
+	WriteLogLines([]*LogLine) error
+	Close() error
+}
+
+// TextSink writes log lines as plain text, each line is prefixed with a formatted timestamp
+type TextSink struct {
+	w          io.Writer
+	timeFormat string
+}
+
+// NewTextSink create a text sink, an empty time format will omit the timestamp
+func NewTextSink(w io.Writer, timeFormat string) *TextSink {
+	return &TextSink{w: w, timeFormat: timeFormat}
+}
+
+// WriteLogLines write the log lines to the writer
+func (ts *TextSink) WriteLogLines(lines []*LogLine) error {
+	for _, line := range lines {
+		err := writeTextLine(ts.w, ts.timeFormat, line)
+		if err != nil {
+			return errors.Wrap(err, "failed to write log line")
+		}
+	}
+
+	return nil
+}
+
+// Close the text sink doesn't own the writer so this does nothing
+func (ts *TextSink) Close() error {
+	return nil
+}
+
+// GzipFileSink writes log lines as plain text to a gzip compressed file
+type GzipFileSink struct {
+	f          *os.File
+	gw         *gzip.Writer
+	timeFormat string
+}
+
+// NewGzipFileSink create or truncate the file at the path and return a gzip sink which writes to it
+func NewGzipFileSink(path, timeFormat string) (*GzipFileSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create log file")
+	}
+
+	return &GzipFileSink{f: f, gw: gzip.NewWriter(f), timeFormat: timeFormat}, nil
+}
+
+// WriteLogLines write the log lines to the compressed file
+func (gs *GzipFileSink) WriteLogLines(lines []*LogLine) error {
+	for _, line := range lines {
+		err := writeTextLine(gs.gw, gs.timeFormat, line)
+		if err != nil {
+			return errors.Wrap(err, "failed to write log line")
+		}
+	}
+
+	return nil
+}
+
+// Close flush the compressed data and close the file
+func (gs *GzipFileSink) Close() error {
+	err := gs.gw.Close()
+	if err != nil {
+		gs.f.Close()
+		return errors.Wrap(err, "failed to flush gzip log file")
+	}
+
+	return gs.f.Close()
+}
+
+// JSONLinesSink writes each log line as a JSON document followed by a newline
+type JSONLinesSink struct {
+	enc *json.Encoder
+}
+
+// NewJSONLinesSink create a JSON lines sink
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// WriteLogLines encode the log lines to the writer
+func (js *JSONLinesSink) WriteLogLines(lines []*LogLine) error {
+	for _, line := range lines {
+		err := js.enc.Encode(line)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode log line")
+		}
+	}
+
+	return nil
+}
+
+// Close the JSON lines sink doesn't own the writer so this does nothing
+func (js *JSONLinesSink) Close() error {
+	return nil
+}
+
+// BuildkiteSink writes log lines to a buildkite job log, a "---" section header is emitted
+// before the first line and whenever the task writing the lines changes
+type BuildkiteSink struct {
+	w          io.Writer
+	header     string
+	timeFormat string
+	lastTask   string
+	started    bool
+}
+
+// NewBuildkiteSink create a buildkite sink, the header is used as the section title
+func NewBuildkiteSink(w io.Writer, header, timeFormat string) *BuildkiteSink {
+	return &BuildkiteSink{w: w, header: header, timeFormat: timeFormat}
+}
+
+// WriteLogLines write the log lines with section headers to the writer
+func (bs *BuildkiteSink) WriteLogLines(lines []*LogLine) error {
+	for _, line := range lines {
+		if !bs.started || line.TaskID != bs.lastTask {
+			_, err := fmt.Fprintf(bs.w, "--- %s\n", bs.sectionTitle(line))
+			if err != nil {
+				return errors.Wrap(err, "failed to write section header")
+			}
+			bs.started = true
+			bs.lastTask = line.TaskID
+		}
+
+		err := writeTextLine(bs.w, bs.timeFormat, line)
+		if err != nil {
+			return errors.Wrap(err, "failed to write log line")
+		}
+	}
+
+	return nil
+}
+
+// Close the buildkite sink doesn't own the writer so this does nothing
+func (bs *BuildkiteSink) Close() error {
+	return nil
+}
+
+func (bs *BuildkiteSink) sectionTitle(line *LogLine) string {
+	title := bs.taskTitle(line)
+
+	if line.Attempt > 0 {
+		return fmt.Sprintf("%s (attempt %d)", title, line.Attempt)
+	}
+
+	return title
+}
+
+func (bs *BuildkiteSink) taskTitle(line *LogLine) string {
+	switch {
+	case bs.header != "" && line.TaskID != "":
+		return fmt.Sprintf("%s %s", bs.header, line.TaskID)
+	case bs.header != "":
+		return bs.header
+	case line.TaskID != "":
+		return line.TaskID
+	default:
+		return "logs"
+	}
+}
+
+func writeTextLine(w io.Writer, timeFormat string, line *LogLine) error {
+	if timeFormat == "" {
+		_, err := fmt.Fprintln(w, line.Message)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%s %s\n", line.Timestamp.Format(timeFormat), line.Message)
+	return err
+}
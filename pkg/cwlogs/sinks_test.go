@@ -0,0 +1,92 @@
+package cwlogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testLines = []*LogLine{
+	{Timestamp: time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC), Message: "first", TaskID: "abc123"},
+	{Timestamp: time.Date(2019, 2, 1, 10, 0, 1, 0, time.UTC), Message: "second", TaskID: "abc123"},
+	{Timestamp: time.Date(2019, 2, 1, 10, 0, 2, 0, time.UTC), Message: "third", TaskID: "def456"},
+}
+
+func TestTextSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	sink := NewTextSink(buf, DefaultTimeFormat)
+	require.Nil(t, sink.WriteLogLines(testLines))
+	require.Nil(t, sink.Close())
+
+	require.Equal(t, "2019-02-01T10:00:00Z first\n2019-02-01T10:00:01Z second\n2019-02-01T10:00:02Z third\n", buf.String())
+}
+
+func TestGzipFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cwlogs")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logs.txt.gz")
+
+	sink, err := NewGzipFileSink(path, "")
+	require.Nil(t, err)
+	require.Nil(t, sink.WriteLogLines(testLines))
+	require.Nil(t, sink.Close())
+
+	f, err := os.Open(path)
+	require.Nil(t, err)
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	require.Nil(t, err)
+
+	data, err := ioutil.ReadAll(gr)
+	require.Nil(t, err)
+	require.Equal(t, "first\nsecond\nthird\n", string(data))
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	sink := NewJSONLinesSink(buf)
+	require.Nil(t, sink.WriteLogLines(testLines))
+
+	dec := json.NewDecoder(buf)
+
+	for _, want := range testLines {
+		got := &LogLine{}
+		require.Nil(t, dec.Decode(got))
+		require.Equal(t, want.Message, got.Message)
+		require.Equal(t, want.TaskID, got.TaskID)
+	}
+}
+
+func TestBuildkiteSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	sink := NewBuildkiteSink(buf, ":docker: task", "")
+	require.Nil(t, sink.WriteLogLines(testLines[:1]))
+	require.Nil(t, sink.WriteLogLines(testLines[1:]))
+
+	require.Equal(t, "--- :docker: task abc123\nfirst\nsecond\n--- :docker: task def456\nthird\n", buf.String())
+}
+
+func TestBuildkiteSink_Attempts(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	sink := NewBuildkiteSink(buf, ":docker: task", "")
+	require.Nil(t, sink.WriteLogLines([]*LogLine{
+		{Message: "failed", TaskID: "abc123", Attempt: 1},
+		{Message: "passed", TaskID: "def456", Attempt: 2},
+	}))
+
+	require.Equal(t, "--- :docker: task abc123 (attempt 1)\nfailed\n--- :docker: task def456 (attempt 2)\npassed\n", buf.String())
+}
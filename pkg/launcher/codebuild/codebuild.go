@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
 )
 
 const (
@@ -12,17 +13,38 @@ const (
 
 	// CodebuildLogGroupFormat the name format for cloudwatch log group names
 	CodebuildLogGroupFormat = "/aws/codebuild/%s"
+
+	// DefaultS3LogsPageSize the number of lines returned in each page of logs read from S3
+	DefaultS3LogsPageSize = 1000
 )
 
 // LauncherAPI build the definition, then launch a container based task
 type LauncherAPI interface {
 	DefineTask(*DefineTaskParams) (*DefineTaskResult, error)
+	ListDefinitions(*ListDefinitionsParams) (*ListDefinitionsResult, error)
+	DescribeDefinition(*DescribeDefinitionParams) (*DescribeDefinitionResult, error)
 	LaunchTask(*LaunchTaskParams) (*LaunchTaskResult, error)
 	GetTaskStatus(*GetTaskStatusParams) (*GetTaskStatusResult, error)
+	GetTasksStatus(*GetTasksStatusParams) (*GetTasksStatusResult, error)
 	WaitForTask(*WaitForTaskParams) (*WaitForTaskResult, error)
+	WaitForTasks(*WaitForTasksParams) (*WaitForTasksResult, error)
+	WatchTask(*WatchTaskParams) (*WatchTaskResult, error)
 	StopTask(*StopTaskParams) (*StopTaskResult, error)
+	StopTasks(*StopTasksParams) (*StopTasksResult, error)
 	CleanupTask(*CleanupTaskParams) (*CleanupTaskResult, error)
+	ListTasks(*ListTasksParams) (*ListTasksResult, error)
 	GetTaskLogs(*GetTaskLogsParams) (*GetTaskLogsResult, error)
+	ExportTaskLogs(*ExportTaskLogsParams) (*ExportTaskLogsResult, error)
+	RenewLease(*RenewLeaseParams) (*RenewLeaseResult, error)
+	RunTask(*RunTaskParams, ...cwlogs.LogSink) (*RunTaskResult, error)
+}
+
+// LauncherOptions options used when creating a launcher
+type LauncherOptions struct {
+	// optional, updates published to the feed, such as task state change events, are passed to the launcher's poller
+	StatusFeed *launcher.StatusFeed
+	// optional, tune the poller shared by the tasks waiting on the launcher
+	Poller *launcher.PollerConfig
 }
 
 // DefineTaskParams parameters used to build a container execution environment for Codebuild
@@ -38,6 +60,19 @@ type DefineTaskParams struct {
 	Image       string            `json:"image,omitempty" jsonschema:"required"`
 	Environment map[string]string `json:"environment,omitempty"`
 	Tags        map[string]string `json:"tags,omitempty"`
+
+	// optional, log group naming, retention, encryption and tags
+	LogGroup *cwlogs.LogGroupConfig `json:"log_group,omitempty"`
+
+	// optional, upload build logs to S3, cloudwatch logs can be disabled when these are enabled
+	S3Logs                *S3LogsConfig `json:"s3_logs,omitempty"`
+	DisableCloudwatchLogs bool          `json:"disable_cloudwatch_logs,omitempty"`
+}
+
+// S3LogsConfig upload build logs to a bucket, the location is the bucket name with an optional prefix
+type S3LogsConfig struct {
+	Location           string `json:"location,omitempty" jsonschema:"required"`
+	EncryptionDisabled *bool  `json:"encryption_disabled,omitempty"`
 }
 
 // DefineTaskResult the results from create definition for Codebuild
@@ -45,6 +80,38 @@ type DefineTaskResult struct {
 	ID                     string `json:"id,omitempty"`
 	CloudwatchLogGroupName string `json:"cloudwatch_log_group_name,omitempty"`
 	CloudwatchStreamPrefix string `json:"cloudwatch_stream_prefix,omitempty"`
+	S3LogsLocation         string `json:"s3_logs_location,omitempty"`
+}
+
+// ListDefinitionsParams list the codebuild projects in the account
+type ListDefinitionsParams struct {
+	NextToken *string `json:"next_token,omitempty"`
+}
+
+// ListDefinitionsResult a page of projects sorted by name
+type ListDefinitionsResult struct {
+	Definitions []*ListedDefinition `json:"definitions,omitempty"`
+	NextToken   *string             `json:"next_token,omitempty"`
+}
+
+// ListedDefinition a codebuild project, the ID matches the one returned by define task
+type ListedDefinition struct {
+	ID           string     `json:"id,omitempty"`
+	ProjectName  string     `json:"project_name,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"last_modified,omitempty"`
+}
+
+// DescribeDefinitionParams describe a codebuild project
+type DescribeDefinitionParams struct {
+	ProjectName string `json:"project_name,omitempty" jsonschema:"required"`
+}
+
+// DescribeDefinitionResult the deployed project along with the parameters which would define it
+type DescribeDefinitionResult struct {
+	DefineTaskResult
+
+	Definition *DefineTaskParams `json:"definition,omitempty"`
 }
 
 // LaunchTaskParams used to launch Codebuild container based tasks
@@ -55,11 +122,18 @@ type LaunchTaskParams struct {
 	Image          *string `json:"image,omitempty"`
 	ServiceRole    *string `json:"service_role,omitempty"`
 
+	// optional, the number of builds to start, defaults to 1
+	Count int64 `json:"count,omitempty"`
+
 	Environment map[string]string `json:"environment,omitempty"`
 	Tags        map[string]string `json:"tags,omitempty"`
+
+	// optional, lease the launched tasks to an owner so they are stopped by the reaper if the owner goes away
+	Lease *launcher.LeaseConfig `json:"lease,omitempty"`
 }
 
-// LaunchTaskResult summarsied result of the launched task in Codebuild
+// LaunchTaskResult summarsied result of the launched task in Codebuild, when more than one build
+// is started the first build is summarised and all the builds are listed
 type LaunchTaskResult struct {
 	BuildArn    string `json:"build_arn,omitempty"`
 	BuildStatus string `json:"build_status,omitempty"`
@@ -68,11 +142,29 @@ type LaunchTaskResult struct {
 	TaskStatus string     `json:"task_status,omitempty"`
 	StartTime  *time.Time `json:"start_time,omitempty"`
 	EndTime    *time.Time `json:"end_time,omitempty"`
+
+	// encoded launcher.TaskHandle of the first build, this can be passed to the other operations in place of the ID
+	Handle string `json:"handle,omitempty"`
+
+	Tasks    []*LaunchedTask         `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+}
+
+// LaunchedTask a build started by launch task
+type LaunchedTask struct {
+	BuildArn    string `json:"build_arn,omitempty"`
+	BuildStatus string `json:"build_status,omitempty"`
+	ID          string `json:"id,omitempty"`
+	TaskStatus  string `json:"task_status,omitempty"`
+	Handle      string `json:"handle,omitempty"`
 }
 
 // GetTaskStatusParams get status task parameters for Codebuild
 type GetTaskStatusParams struct {
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
 }
 
 // GetTaskStatusResult get status task result for Codebuild
@@ -84,44 +176,188 @@ type GetTaskStatusResult struct {
 	TaskStatus string     `json:"task_status,omitempty"`
 	StartTime  *time.Time `json:"start_time,omitempty"`
 	EndTime    *time.Time `json:"end_time,omitempty"`
+	ExitCode   *int64     `json:"exit_code,omitempty"`
+}
+
+// GetTasksStatusParams get the status of many builds
+type GetTasksStatusParams struct {
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+}
+
+// GetTasksStatusResult the status of each build in the order requested, along with the builds which weren't found
+type GetTasksStatusResult struct {
+	Tasks    []*GetTaskStatusResult  `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
 }
 
 // WaitForTaskParams wait for task parameters for Codebuild
 type WaitForTaskParams struct {
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, defaults to launcher.DefaultMaxWait, launcher.ErrWaitTimeout is returned if the task is still running
+	MaxWait time.Duration `json:"max_wait,omitempty"`
 }
 
 // WaitForTaskResult wait for task parameters for Codebuild
 type WaitForTaskResult struct {
+	ID         string `json:"id,omitempty"`
+	TaskStatus string `json:"task_status,omitempty"`
+}
+
+// WaitForTasksParams wait for many builds
+type WaitForTasksParams struct {
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+
+	// optional, defaults to launcher.DefaultMaxWait, tasks still running after this are returned as failures
+	MaxWait time.Duration `json:"max_wait,omitempty"`
+}
+
+// WaitForTasksResult the builds which completed in the order requested, along with those which couldn't be checked
+type WaitForTasksResult struct {
+	Tasks    []*WaitForTaskResult    `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+}
+
+// WatchTaskParams watch task parameters for Codebuild
+type WatchTaskParams struct {
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+}
+
+// WatchTaskResult the events channel is closed once the task reaches a terminal state
+type WatchTaskResult struct {
+	Events <-chan *launcher.StatusChange `json:"-"`
+
+	// stop watching before the task completes, this closes the events channel
+	Stop func() `json:"-"`
 }
 
 // StopTaskParams stop task params for Codebuild
 type StopTaskParams struct {
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, CodeBuild doesn't record a reason so this is only logged and never sent to AWS
+	Reason string `json:"reason,omitempty"`
+	// optional, wait for the build to stop, if it doesn't stop within the timeout the stop is sent again
+	Wait bool `json:"wait,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	WaitTimeout time.Duration `json:"wait_timeout,omitempty"`
 }
 
 // StopTaskResult stop task result for Codebuild
 type StopTaskResult struct {
+	ID          string `json:"id,omitempty"`
 	BuildStatus string `json:"build_status,omitempty"`
 
-	TaskStatus string `json:"task_status,omitempty"`
+	TaskStatus string     `json:"task_status,omitempty"`
+	ExitCode   *int64     `json:"exit_code,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+
+	// set when the build didn't stop within the wait timeout
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// StopTasksParams stop many builds
+type StopTasksParams struct {
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+
+	// optional, CodeBuild doesn't record a reason so this is only logged and never sent to AWS
+	Reason string `json:"reason,omitempty"`
+	// optional, wait for each build to stop, if it doesn't stop within the timeout the stop is sent again
+	Wait bool `json:"wait,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	WaitTimeout time.Duration `json:"wait_timeout,omitempty"`
+}
+
+// StopTasksResult the builds which were stopped in the order requested, along with those which couldn't be stopped
+type StopTasksResult struct {
+	Tasks    []*StopTaskResult       `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
 }
 
 // CleanupTaskParams cleanup task params for Codebuild
 type CleanupTaskParams struct {
 	ProjectName string `json:"project_name,omitempty" jsonschema:"required"`
+
+	// optional, stop in progress builds and wait for them to finish before the project is deleted
+	StopBuilds bool `json:"stop_builds,omitempty"`
+
+	// optional, delete the project log group
+	DeleteLogGroup bool `json:"delete_log_group,omitempty"`
+
+	// optional, rather than deleting the log group straight away set its retention so the logs expire after this many days
+	LogRetentionInDays *int64 `json:"log_retention_in_days,omitempty"`
 }
 
-// CleanupTaskResult cleanup task result for Codebuild
+// CleanupTaskResult report of everything removed by cleanup
 type CleanupTaskResult struct {
+	DeletedProject string   `json:"deleted_project,omitempty"`
+	StoppedBuilds  []string `json:"stopped_builds,omitempty"`
+
+	DeletedLogGroup string `json:"deleted_log_group,omitempty"`
+
+	// the log group which was left to expire using the retention grace period
+	ExpiringLogGroup string `json:"expiring_log_group,omitempty"`
+}
+
+// ListTasksParams list the builds of a project, most recent first, the tags filter is matched against the project
+type ListTasksParams struct {
+	ProjectName string `json:"project_name,omitempty" jsonschema:"required"`
+
+	launcher.TaskFilter
+
+	NextToken *string `json:"next_token,omitempty"`
+}
+
+// ListTasksResult the builds in a page which match the filter, there may be more pages when next token is set
+type ListTasksResult struct {
+	Tasks     []*ListedTask `json:"tasks,omitempty"`
+	NextToken *string       `json:"next_token,omitempty"`
+}
+
+// ListedTask summary of a build returned by list tasks
+type ListedTask struct {
+	BuildArn    string `json:"build_arn,omitempty"`
+	BuildStatus string `json:"build_status,omitempty"`
+	Definition  string `json:"definition,omitempty"`
+	Phase       string `json:"phase,omitempty"`
+
+	ID         string     `json:"id,omitempty"`
+	TaskStatus string     `json:"task_status,omitempty"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
 }
 
 // GetTaskLogsParams get logs task params for Codebuild
 type GetTaskLogsParams struct {
-	ProjectName string  `json:"project_name,omitempty" jsonschema:"required"`
-	TaskID      string  `json:"task_id,omitempty" jsonschema:"required"`
-	NextToken   *string `json:"next_token,omitempty"`
+	ID        string  `json:"id,omitempty"`
+	NextToken *string `json:"next_token,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, read from the start of the stream when no next token is supplied
+	StartFromHead bool `json:"start_from_head,omitempty"`
+
+	// optional, parse each message as JSON with this mapping
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
 }
 
 // GetTaskLogsResult get logs task result for Codebuild
@@ -129,3 +365,89 @@ type GetTaskLogsResult struct {
 	LogLines  []*cwlogs.LogLine `json:"log_lines,omitempty"`
 	NextToken *string           `json:"next_token,omitempty"`
 }
+
+// ExportTaskLogsParams export the complete logs of a task to a local file for Codebuild
+type ExportTaskLogsParams struct {
+	ID   string `json:"id,omitempty"`
+	Path string `json:"path,omitempty" jsonschema:"required"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, one of text, jsonl or gzip, defaults to text
+	Format       string `json:"format,omitempty"`
+	TimeFormat   string `json:"time_format,omitempty"`
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
+}
+
+// ExportTaskLogsResult export task logs result for Codebuild
+type ExportTaskLogsResult struct {
+	Path         string `json:"path,omitempty"`
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	Manifest *cwlogs.ExportManifest `json:"manifest,omitempty"`
+}
+
+// RenewLeaseParams extend the lease on the builds of the project, builds can't be tagged so the expiry is
+// recorded in a project tag for the owner
+type RenewLeaseParams struct {
+	ProjectName string                `json:"project_name,omitempty" jsonschema:"required"`
+	Lease       *launcher.LeaseConfig `json:"lease,omitempty" jsonschema:"required"`
+
+	// optional, defaults to the lease duration from now
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// RenewLeaseResult the new expiry
+type RenewLeaseResult struct {
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// RunTaskParams launch a build, follow the logs and wait for it to complete
+type RunTaskParams struct {
+	LaunchTaskParams
+
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
+	PollInterval time.Duration        `json:"poll_interval,omitempty"`
+
+	// optional, how long the logs must be quiet once the task completes, defaults to cwlogs.DefaultDrainQuietPeriod
+	DrainQuietPeriod time.Duration `json:"drain_quiet_period,omitempty"`
+
+	// optional, stop the builds when the process receives SIGINT or SIGTERM, the run then returns its result along with
+	// launcher.ErrInterrupted, a second signal terminates the process without waiting for the build to stop
+	StopOnInterrupt bool `json:"stop_on_interrupt,omitempty"`
+	// optional, defaults to launcher.DefaultStopReason, CodeBuild doesn't record a reason so this is only logged
+	StopReason string `json:"stop_reason,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	StopTimeout time.Duration `json:"stop_timeout,omitempty"`
+
+	// optional, start the build again when it fails for one of the retryable reasons
+	RetryPolicy *launcher.RetryPolicy `json:"retry_policy,omitempty"`
+}
+
+// RunTaskResult the final status of the build after it has completed
+type RunTaskResult struct {
+	BuildArn    string `json:"build_arn,omitempty"`
+	BuildStatus string `json:"build_status,omitempty"`
+
+	ID           string     `json:"id,omitempty"`
+	TaskStatus   string     `json:"task_status,omitempty"`
+	StartTime    *time.Time `json:"start_time,omitempty"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	LogLineCount int64      `json:"log_line_count,omitempty"`
+
+	// the handle of the build and the log stream its logs were read from
+	Handle        string `json:"handle,omitempty"`
+	LogGroupName  string `json:"log_group_name,omitempty"`
+	LogStreamName string `json:"log_stream_name,omitempty"`
+
+	ExitCode *int64 `json:"exit_code,omitempty"`
+
+	// set when the build was stopped after the run was interrupted
+	Interrupted bool `json:"interrupted,omitempty"`
+
+	// every attempt at running the build, the other fields describe the last attempt which started a build
+	Attempts []*launcher.RunAttempt `json:"attempts,omitempty"`
+}
@@ -1,18 +1,28 @@
 package codebuild
 
 import (
-	"context"
+	"bufio"
+	"compress/gzip"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/codebuild"
 	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
@@ -23,36 +33,61 @@ import (
 type Launcher struct {
 	codeBuildSvc codebuildiface.CodeBuildAPI
 	cwlogsSvc    cloudwatchlogsiface.CloudWatchLogsAPI
+	s3Svc        s3iface.S3API
 	cwlogsReader cwlogs.LogsReader
+
+	// log locations discovered for each build
+	logLocations sync.Map
+
+	// shared by all the builds waiting on this launcher
+	pollerOnce   sync.Once
+	poller       *launcher.Poller
+	pollerConfig *launcher.PollerConfig
+	statusFeed   *launcher.StatusFeed
+
+	// handles launched in another region are refused, empty skips the check
+	region string
+
+	// optional, defaults to launcher.NotifyInterrupt, replaced in tests so a run can be interrupted without a signal
+	notifyInterrupt launcher.NotifyFunc
 }
 
 // NewLauncher create a new launcher
 func NewLauncher(cfgs ...*aws.Config) LauncherAPI {
+	return NewLauncherWithOptions(&LauncherOptions{}, cfgs...)
+}
+
+// NewLauncherWithOptions create a new launcher using the options
+func NewLauncherWithOptions(opts *LauncherOptions, cfgs ...*aws.Config) LauncherAPI {
 	sess := session.Must(session.NewSession(cfgs...))
 	return &Launcher{
 		codeBuildSvc: codebuild.New(sess),
 		cwlogsSvc:    cloudwatchlogs.New(sess),
-		cwlogsReader: cwlogs.NewCloudwatchLogsReader(cfgs...),
+		s3Svc:        s3.New(sess),
+		cwlogsReader: cwlogs.NewCloudwatchLogsReaderWithOptions(&cwlogs.ReaderOptions{RateLimiter: cwlogs.DefaultRateLimiter}, cfgs...),
+		pollerConfig: opts.Poller,
+		statusFeed:   opts.StatusFeed,
+		region:       aws.StringValue(sess.Config.Region),
 	}
 }
 
 // DefineTask create or update a codebuild job for this definition and return the ARN of this job
 func (cbl *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error) {
 
-	logGroupName := fmt.Sprintf(CodebuildLogGroupFormat, dp.ProjectName)
+	err := dp.LogGroup.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	logGroupName := ""
 
-	_, err := cbl.cwlogsSvc.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
-		LogGroupName: aws.String(logGroupName),
-		Tags: map[string]*string{
-			"createdBy": aws.String("fargate-run-job"),
-		},
-	})
-	if err, ok := err.(awserr.Error); ok {
-		if err.Code() != "ResourceAlreadyExistsException" {
-			return nil, errors.Wrap(err, "create log group failed.")
-		}
+	if !dp.DisableCloudwatchLogs {
+		logGroupName = dp.LogGroup.LogGroupName(CodebuildLogGroupFormat, dp.ProjectName)
 
-		logrus.WithField("name", logGroupName).Info("cloudwatch log group exists")
+		err = cwlogs.EnsureLogGroup(cbl.cwlogsSvc, dp.LogGroup.EnsureLogGroupParams(logGroupName, launcher.OwnershipTags(nil)))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// just update the project to see if it already exists
@@ -64,11 +99,7 @@ func (cbl *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error)
 	if updated {
 		logrus.WithField("projectArn", projectArn).Info("updated codebuild project")
 
-		return &DefineTaskResult{
-			ID:                     projectArn,
-			CloudwatchLogGroupName: logGroupName,
-			CloudwatchStreamPrefix: "codebuild",
-		}, nil
+		return newDefineTaskResult(dp, projectArn, logGroupName), nil
 	}
 
 	createRes, err := cbl.codeBuildSvc.CreateProject(&codebuild.CreateProjectInput{
@@ -88,14 +119,8 @@ func (cbl *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error)
 			Buildspec: aws.String(dp.Buildspec),
 		},
 		ServiceRole: aws.String(dp.ServiceRole),
-		LogsConfig: &codebuild.LogsConfig{
-			CloudWatchLogs: &codebuild.CloudWatchLogsConfig{
-				GroupName:  aws.String(logGroupName),
-				StreamName: aws.String(CodebuildStreamPrefix),
-				Status:     aws.String(codebuild.LogsConfigStatusTypeEnabled),
-			},
-		},
-		Tags: convertMapToCodebuildTags(dp.Tags),
+		LogsConfig:  buildLogsConfig(dp, logGroupName),
+		Tags:        convertMapToCodebuildTags(projectTags(dp)),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to register project.")
@@ -105,64 +130,213 @@ func (cbl *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error)
 
 	logrus.WithField("projectArn", projectArn).Info("created codebuild project")
 
-	return &DefineTaskResult{
-		ID:                     projectArn,
-		CloudwatchLogGroupName: logGroupName,
-		CloudwatchStreamPrefix: "codebuild",
+	return newDefineTaskResult(dp, projectArn, logGroupName), nil
+}
+
+// ListDefinitions list a page of the codebuild projects
+func (cbl *Launcher) ListDefinitions(ldp *ListDefinitionsParams) (*ListDefinitionsResult, error) {
+
+	listRes, err := cbl.codeBuildSvc.ListProjects(&codebuild.ListProjectsInput{
+		SortBy:    aws.String(codebuild.ProjectSortByTypeName),
+		SortOrder: aws.String(codebuild.SortOrderTypeAscending),
+		NextToken: ldp.NextToken,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list projects.")
+	}
+
+	res := &ListDefinitionsResult{NextToken: listRes.NextToken}
+
+	if len(listRes.Projects) == 0 {
+		return res, nil
+	}
+
+	projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+		Names: listRes.Projects,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get projects.")
+	}
+
+	for _, project := range projRes.Projects {
+		res.Definitions = append(res.Definitions, &ListedDefinition{
+			ID:           aws.StringValue(project.Arn),
+			ProjectName:  aws.StringValue(project.Name),
+			Created:      project.Created,
+			LastModified: project.LastModified,
+		})
+	}
+
+	return res, nil
+}
+
+// DescribeDefinition describe the project and convert it back into the parameters which would define it
+func (cbl *Launcher) DescribeDefinition(ddp *DescribeDefinitionParams) (*DescribeDefinitionResult, error) {
+
+	projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+		Names: []*string{aws.String(ddp.ProjectName)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project.")
+	}
+
+	if len(projRes.Projects) == 0 {
+		return nil, errors.Errorf("project not found: %s", ddp.ProjectName)
+	}
+
+	project := projRes.Projects[0]
+
+	dp, logGroupName := newDefineTaskParams(project)
+
+	if logGroupName != "" {
+		nameFormat := launcher.LogGroupFormat(convertCodebuildTagsToMap(project.Tags))
+
+		dp.LogGroup, err = cwlogs.DescribeLogGroupConfig(cbl.cwlogsSvc, logGroupName, nameFormat, launcher.OwnershipTags(nil))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &DescribeDefinitionResult{
+		DefineTaskResult: *newDefineTaskResult(dp, aws.StringValue(project.Arn), logGroupName),
+		Definition:       dp,
 	}, nil
 }
 
 // LaunchTask run a container task and monitor it till completion
 func (cbl *Launcher) LaunchTask(rt *LaunchTaskParams) (*LaunchTaskResult, error) {
 
-	res, err := cbl.codeBuildSvc.StartBuild(&codebuild.StartBuildInput{
-		ProjectName:                  aws.String(rt.ProjectName),
-		EnvironmentVariablesOverride: convertMapToEnvironmentVariable(rt.Environment),
-		ImageOverride:                rt.Image,
-		ComputeTypeOverride:          rt.ComputeType,
-		PrivilegedModeOverride:       rt.PrivilegedMode,
-		ServiceRoleOverride:          rt.ServiceRole,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to start build.")
+	count := rt.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	taskRes := &LaunchTaskResult{}
+
+	for n := int64(0); n < count; n++ {
+		res, err := cbl.codeBuildSvc.StartBuild(&codebuild.StartBuildInput{
+			ProjectName:                  aws.String(rt.ProjectName),
+			EnvironmentVariablesOverride: convertMapToEnvironmentVariable(buildEnvironment(rt)),
+			ImageOverride:                rt.Image,
+			ComputeTypeOverride:          rt.ComputeType,
+			PrivilegedModeOverride:       rt.PrivilegedMode,
+			ServiceRoleOverride:          rt.ServiceRole,
+		})
+		if err != nil {
+			if count == 1 {
+				return nil, errors.Wrap(err, "failed to start build.")
+			}
+
+			taskRes.Failures = append(taskRes.Failures, &launcher.TaskFailure{Reason: err.Error()})
+			continue
+		}
+
+		taskRes.Tasks = append(taskRes.Tasks, &LaunchedTask{
+			ID:          aws.StringValue(res.Build.Id),
+			TaskStatus:  convertTaskStatus(aws.StringValue(res.Build.BuildStatus)),
+			BuildArn:    aws.StringValue(res.Build.Arn),
+			BuildStatus: aws.StringValue(res.Build.BuildStatus),
+			Handle:      buildHandle(rt, res.Build).Encode(),
+		})
 	}
 
-	taskRes := &LaunchTaskResult{
-		ID:          aws.StringValue(res.Build.Id),
-		TaskStatus:  convertTaskStatus(aws.StringValue(res.Build.BuildStatus)),
-		BuildArn:    aws.StringValue(res.Build.Arn),
-		BuildStatus: aws.StringValue(res.Build.BuildStatus),
+	if len(taskRes.Tasks) == 0 {
+		return nil, errors.Errorf("failed to start build: %s", taskRes.Failures[0].Reason)
 	}
 
+	build := taskRes.Tasks[0]
+
+	taskRes.ID = build.ID
+	taskRes.TaskStatus = build.TaskStatus
+	taskRes.BuildArn = build.BuildArn
+	taskRes.BuildStatus = build.BuildStatus
+	taskRes.Handle = build.Handle
+
 	return taskRes, nil
 }
 
-// WaitForTask wait for task to complete
+// WaitForTask wait for task to complete, the status of all waiting builds is polled in batches
 func (cbl *Launcher) WaitForTask(wft *WaitForTaskParams) (*WaitForTaskResult, error) {
 
-	params := &codebuild.BatchGetBuildsInput{
-		Ids: []*string{aws.String(wft.ID)},
+	id, err := cbl.resolveHandle(wft.Handle, wft.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWait := wft.MaxWait
+	if maxWait == 0 {
+		maxWait = launcher.DefaultMaxWait
+	}
+
+	su, err := cbl.statusPoller().WaitTimeout("", id, maxWait)
+	if err == launcher.ErrWaitTimeout {
+		return nil, err
 	}
 
-	err := cbl.waitUntilTasksStoppedWithContext(context.Background(), params)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to start build.")
+		return nil, errors.Wrap(err, "failed to check stopped build.")
+	}
+
+	return &WaitForTaskResult{ID: id, TaskStatus: su.TaskStatus}, nil
+}
+
+// WaitForTasks wait for all the builds to complete, builds which can't be found are returned as failures
+func (cbl *Launcher) WaitForTasks(wft *WaitForTasksParams) (*WaitForTasksResult, error) {
+
+	ids, err := cbl.resolveHandles(wft.Handles, wft.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWait := wft.MaxWait
+	if maxWait == 0 {
+		maxWait = launcher.DefaultMaxWait
+	}
+
+	updates, failures := cbl.statusPoller().WaitAll("", ids, maxWait)
+
+	res := &WaitForTasksResult{Failures: failures}
+
+	for _, su := range updates {
+		res.Tasks = append(res.Tasks, &WaitForTaskResult{ID: su.ID, TaskStatus: su.TaskStatus})
+	}
+
+	return res, nil
+}
+
+// WatchTask send each change in the status of the build to the events channel until it completes
+func (cbl *Launcher) WatchTask(wtp *WatchTaskParams) (*WatchTaskResult, error) {
+
+	id, err := cbl.resolveHandle(wtp.Handle, wtp.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	return &WaitForTaskResult{ID: wft.ID}, nil
+	events, stop := launcher.Watch(cbl.statusPoller(), "", id)
+
+	return &WatchTaskResult{Events: events, Stop: stop}, nil
 }
 
 // GetTaskStatus get task status
 func (cbl *Launcher) GetTaskStatus(gts *GetTaskStatusParams) (*GetTaskStatusResult, error) {
 
+	id, err := cbl.resolveHandle(gts.Handle, gts.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &codebuild.BatchGetBuildsInput{
-		Ids: []*string{aws.String(gts.ID)},
+		Ids: []*string{aws.String(id)},
 	}
 	getBuildRes, err := cbl.codeBuildSvc.BatchGetBuilds(params)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start build.")
 	}
 
+	if len(getBuildRes.Builds) == 0 {
+		return nil, errors.Errorf("build not found: %s", id)
+	}
+
 	build := getBuildRes.Builds[0]
 
 	logrus.WithFields(logrus.Fields{
@@ -172,46 +346,178 @@ func (cbl *Launcher) GetTaskStatus(gts *GetTaskStatusParams) (*GetTaskStatusResu
 		"StopTime":      aws.TimeValue(build.EndTime),
 	}).Info("Describe completed Task")
 
-	taskRes := &GetTaskStatusResult{
-		ID:          aws.StringValue(build.Id),
-		StartTime:   build.StartTime,
-		EndTime:     build.EndTime,
-		TaskStatus:  convertTaskStatus(aws.StringValue(build.BuildStatus)),
-		BuildArn:    aws.StringValue(build.Arn),
-		BuildStatus: aws.StringValue(build.BuildStatus),
+	if aws.BoolValue(build.BuildComplete) {
+		cbl.forgetLogLocation(id)
 	}
 
-	if aws.BoolValue(build.BuildComplete) {
-		if aws.StringValue(build.BuildStatus) == "SUCCEEDED" {
-			taskRes.TaskStatus = launcher.TaskSucceeded
-		} else {
-			taskRes.TaskStatus = launcher.TaskFailed
+	return newGetTaskStatusResult(build), nil
+}
+
+// GetTasksStatus get the status of many builds in batches, builds which aren't found are returned as failures
+func (cbl *Launcher) GetTasksStatus(gts *GetTasksStatusParams) (*GetTasksStatusResult, error) {
+
+	ids, err := cbl.resolveHandles(gts.Handles, gts.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]*codebuild.Build{}
+
+	for _, chunk := range launcher.ChunkIDs(ids, launcher.MaxStatusBatchSize) {
+		getBuildRes, err := cbl.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+			Ids: aws.StringSlice(chunk),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get builds.")
+		}
+
+		for _, build := range getBuildRes.Builds {
+			found[aws.StringValue(build.Id)] = build
 		}
 	}
 
-	return taskRes, nil
+	res := &GetTasksStatusResult{}
+
+	for _, id := range ids {
+		if build, ok := found[id]; ok {
+			if aws.BoolValue(build.BuildComplete) {
+				cbl.forgetLogLocation(id)
+			}
+
+			res.Tasks = append(res.Tasks, newGetTaskStatusResult(build))
+			continue
+		}
+
+		res.Failures = append(res.Failures, &launcher.TaskFailure{ID: id, Reason: "build not found"})
+	}
 
+	return res, nil
 }
 
-// StopTask stop codebuild task
+// StopTask stop the build, optionally waiting for it to stop
 func (cbl *Launcher) StopTask(stp *StopTaskParams) (*StopTaskResult, error) {
+
+	id, err := cbl.resolveHandle(stp.Handle, stp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stp.Reason != "" {
+		logrus.WithFields(logrus.Fields{
+			"ID":     id,
+			"Reason": stp.Reason,
+		}).Info("stopping build")
+	}
+
 	res, err := cbl.codeBuildSvc.StopBuild(&codebuild.StopBuildInput{
-		Id: aws.String(stp.ID),
+		Id: aws.String(id),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to stop project.")
 	}
 
-	buildStatus := aws.StringValue(res.Build.BuildStatus)
+	if !stp.Wait {
+		return newStopTaskResult(res.Build), nil
+	}
 
-	return &StopTaskResult{
-		BuildStatus: buildStatus,
-		TaskStatus:  convertTaskStatus(buildStatus),
-	}, nil
+	waitTimeout := stp.WaitTimeout
+	if waitTimeout <= 0 {
+		waitTimeout = launcher.DefaultStopTimeout
+	}
+
+	_, err = cbl.statusPoller().WaitTimeout("", id, waitTimeout)
+
+	timedOut := err == launcher.ErrWaitTimeout
+
+	if err != nil && !timedOut {
+		return nil, errors.Wrap(err, "failed to check stopped build.")
+	}
+
+	if timedOut {
+		logrus.WithField("ID", id).Warn("build didn't stop within the timeout, sending stop again")
+
+		_, err = cbl.codeBuildSvc.StopBuild(&codebuild.StopBuildInput{
+			Id: aws.String(id),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stop project.")
+		}
+	}
+
+	getBuildRes, err := cbl.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get build.")
+	}
+
+	if len(getBuildRes.Builds) == 0 {
+		return nil, errors.Errorf("build not found: %s", id)
+	}
+
+	stopRes := newStopTaskResult(getBuildRes.Builds[0])
+	stopRes.TimedOut = timedOut
+
+	return stopRes, nil
+}
+
+// StopTasks stop each of the builds, builds which can't be stopped are returned as failures
+func (cbl *Launcher) StopTasks(stp *StopTasksParams) (*StopTasksResult, error) {
+
+	ids, err := cbl.resolveHandles(stp.Handles, stp.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &StopTasksResult{}
+
+	for _, id := range ids {
+		stopRes, err := cbl.StopTask(&StopTaskParams{
+			ID:          id,
+			Reason:      stp.Reason,
+			Wait:        stp.Wait,
+			WaitTimeout: stp.WaitTimeout,
+		})
+		if err != nil {
+			res.Failures = append(res.Failures, &launcher.TaskFailure{ID: id, Reason: errors.Cause(err).Error()})
+			continue
+		}
+
+		res.Tasks = append(res.Tasks, stopRes)
+	}
+
+	return res, nil
 }
 
-// CleanupTask clean up codebuild project
+// CleanupTask clean up codebuild project, optionally stopping builds and removing the log group first
 func (cbl *Launcher) CleanupTask(ctp *CleanupTaskParams) (*CleanupTaskResult, error) {
+
+	res := &CleanupTaskResult{}
+
+	logGroupName := ""
+
+	if ctp.DeleteLogGroup {
+		projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+			Names: []*string{aws.String(ctp.ProjectName)},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project.")
+		}
+
+		if len(projRes.Projects) > 0 {
+			_, logGroupName = newDefineTaskParams(projRes.Projects[0])
+		}
+	}
+
+	if ctp.StopBuilds {
+		stopped, err := cbl.stopRunningBuilds(ctp.ProjectName)
+		res.StoppedBuilds = stopped
+		if err != nil {
+			// the builds already stopped are returned so the caller knows what was changed
+			return res, err
+		}
+	}
+
 	_, err := cbl.codeBuildSvc.DeleteProject(&codebuild.DeleteProjectInput{
 		Name: aws.String(ctp.ProjectName),
 	})
@@ -219,126 +525,1265 @@ func (cbl *Launcher) CleanupTask(ctp *CleanupTaskParams) (*CleanupTaskResult, er
 		return nil, errors.Wrap(err, "failed to delete project.")
 	}
 
-	return &CleanupTaskResult{}, nil
-}
+	res.DeletedProject = ctp.ProjectName
 
-// GetTaskLogs get task logs
-func (cbl *Launcher) GetTaskLogs(gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+	if logGroupName == "" {
+		return res, nil
+	}
 
-	logGroupName := fmt.Sprintf(CodebuildLogGroupFormat, gtlp.ProjectName)
-	streamName := fmt.Sprintf("%s/%s", CodebuildStreamPrefix, gtlp.TaskID)
+	if ctp.LogRetentionInDays != nil {
+		_, err = cbl.cwlogsSvc.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(logGroupName),
+			RetentionInDays: ctp.LogRetentionInDays,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to put log group retention policy.")
+		}
 
-	res, err := cbl.cwlogsReader.ReadLogs(&cwlogs.ReadLogsParams{
-		GroupName:  logGroupName,
-		StreamName: streamName,
-		NextToken:  gtlp.NextToken,
-	})
+		res.ExpiringLogGroup = logGroupName
+
+		return res, nil
+	}
+
+	deleted, err := cwlogs.DeleteLogGroup(cbl.cwlogsSvc, logGroupName)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve logs for task.")
+		return nil, err
 	}
 
-	return &GetTaskLogsResult{
-		LogLines:  res.LogLines,
-		NextToken: res.NextToken,
-	}, nil
+	if deleted {
+		res.DeletedLogGroup = logGroupName
+	}
+
+	return res, nil
 }
 
-func (cbl *Launcher) tryUpdateProject(dp *DefineTaskParams, logGroupName string) (string, bool, error) {
-	updateRes, err := cbl.codeBuildSvc.UpdateProject(&codebuild.UpdateProjectInput{
-		Name: aws.String(dp.ProjectName),
-		Environment: &codebuild.ProjectEnvironment{
-			ComputeType:          aws.String(dp.ComputeType),
-			Image:                aws.String(dp.Image),
-			Type:                 aws.String(codebuild.EnvironmentTypeLinuxContainer),
-			PrivilegedMode:       dp.PrivilegedMode,
-			EnvironmentVariables: convertMapToEnvironmentVariable(dp.Environment),
-		},
-		Artifacts: &codebuild.ProjectArtifacts{
-			Type: aws.String(codebuild.ArtifactsTypeNoArtifacts),
-		},
-		Source: &codebuild.ProjectSource{
-			Type:      aws.String(codebuild.SourceTypeNoSource),
-			Buildspec: aws.String(dp.Buildspec),
-		},
-		ServiceRole: aws.String(dp.ServiceRole),
-		LogsConfig: &codebuild.LogsConfig{
-			CloudWatchLogs: &codebuild.CloudWatchLogsConfig{
-				GroupName:  aws.String(logGroupName),
-				StreamName: aws.String("codebuild"),
-				Status:     aws.String(codebuild.LogsConfigStatusTypeEnabled),
-			},
-		},
-		Tags: convertMapToCodebuildTags(dp.Tags),
-	})
-	if err, ok := err.(awserr.Error); ok {
-		if err.Code() == "ResourceNotFoundException" {
-			return "", false, nil // skip this error as the job will be subsequently created
-		}
-		return "", false, errors.Wrap(err, "update codebuild project failed.")
+// stopRunningBuilds stop the in progress builds of the project and wait for them to finish, returning the IDs
+// of the builds stopped, on error the builds stopped before the failure are returned with it
+func (cbl *Launcher) stopRunningBuilds(projectName string) ([]string, error) {
+
+	ids := []string{}
+
+	ltp := &ListTasksParams{
+		ProjectName: projectName,
+		TaskFilter:  launcher.TaskFilter{TaskStatus: []string{launcher.TaskRunning}},
 	}
 
-	return aws.StringValue(updateRes.Project.Arn), true, nil
-}
+	// a page may have no in progress builds while a later one does, so every page is checked
+	for {
+		listRes, err := cbl.ListTasks(ltp)
+		if err != nil {
+			return nil, err
+		}
 
-func (cbl *Launcher) waitUntilTasksStoppedWithContext(ctx aws.Context, input *codebuild.BatchGetBuildsInput, opts ...request.WaiterOption) error {
-	w := request.Waiter{
-		Name:        "WaitUntilBuildsStopped",
-		MaxAttempts: 100,
-		Delay:       request.ConstantWaiterDelay(6 * time.Second),
-		Acceptors: []request.WaiterAcceptor{
-			{
-				State:   request.SuccessWaiterState,
-				Matcher: request.PathAllWaiterMatch, Argument: "builds[].buildComplete",
-				Expected: true,
-			},
-		},
-		NewRequest: func(opts []request.Option) (*request.Request, error) {
-			var inCpy *codebuild.BatchGetBuildsInput
-			if input != nil {
-				tmp := *input
-				inCpy = &tmp
-			}
-			req, _ := cbl.codeBuildSvc.BatchGetBuildsRequest(inCpy)
-			req.SetContext(ctx)
-			req.ApplyOptions(opts...)
-			return req, nil
-		},
+		for _, task := range listRes.Tasks {
+			ids = append(ids, task.ID)
+		}
+
+		if listRes.NextToken == nil {
+			break
+		}
+
+		ltp.NextToken = listRes.NextToken
 	}
-	w.ApplyOptions(opts...)
 
-	return w.WaitWithContext(ctx)
-}
+	stopped := []string{}
 
-func convertMapToEnvironmentVariable(env map[string]string) []*codebuild.EnvironmentVariable {
+	for _, id := range ids {
+		_, err := cbl.StopTask(&StopTaskParams{ID: id})
+		if err != nil {
+			return stopped, errors.Wrapf(err, "failed to stop build %s.", id)
+		}
 
-	codebuildEnv := []*codebuild.EnvironmentVariable{}
+		stopped = append(stopped, id)
+	}
 
-	// empty map is valid
-	if env == nil {
-		return nil
+	if len(stopped) == 0 {
+		return stopped, nil
 	}
 
-	for k, v := range env {
-		codebuildEnv = append(codebuildEnv, &codebuild.EnvironmentVariable{Name: aws.String(k), Value: aws.String(v)})
+	// the project and log group are only removed once the builds have finished with them
+	_, failures := cbl.statusPoller().WaitAll("", stopped, launcher.DefaultStopTimeout)
+	if len(failures) > 0 {
+		return stopped, errors.Errorf("build %s didn't stop: %s", failures[0].ID, failures[0].Reason)
 	}
 
-	return codebuildEnv
+	return stopped, nil
 }
 
-func convertMapToCodebuildTags(tags map[string]string) []*codebuild.Tag {
+// ListTasks list a page of the builds of the project which match the filter, most recent first
+func (cbl *Launcher) ListTasks(ltp *ListTasksParams) (*ListTasksResult, error) {
 
-	codebuildTags := []*codebuild.Tag{}
+	res := &ListTasksResult{}
 
-	// empty map is valid
-	if tags == nil {
-		return nil
+	// builds don't have tags so the filter is matched against the project
+	if len(ltp.Tags) > 0 {
+		projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+			Names: []*string{aws.String(ltp.ProjectName)},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get project.")
+		}
+
+		if len(projRes.Projects) == 0 || !ltp.MatchTags(convertCodebuildTagsToMap(projRes.Projects[0].Tags)) {
+			return res, nil
+		}
 	}
 
-	for k, v := range tags {
-		codebuildTags = append(codebuildTags, &codebuild.Tag{Key: aws.String(k), Value: aws.String(v)})
+	listRes, err := cbl.codeBuildSvc.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(ltp.ProjectName),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+		NextToken:   ltp.NextToken,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list builds.")
 	}
 
-	return codebuildTags
+	res.NextToken = listRes.NextToken
+
+	if len(listRes.Ids) == 0 {
+		return res, nil
+	}
+
+	getBuildRes, err := cbl.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: listRes.Ids,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get builds.")
+	}
+
+	// the order of the builds returned by the batch call isn't guaranteed so the whole page is filtered
+	older := 0
+
+	for _, build := range getBuildRes.Builds {
+		if ltp.StartedAfter != nil && build.StartTime != nil && build.StartTime.Before(*ltp.StartedAfter) {
+			older++
+			continue
+		}
+
+		statusRes := newGetTaskStatusResult(build)
+
+		if !ltp.MatchStatus(statusRes.TaskStatus) || !ltp.MatchStartTime(statusRes.StartTime) {
+			continue
+		}
+
+		res.Tasks = append(res.Tasks, &ListedTask{
+			ID:          statusRes.ID,
+			BuildArn:    statusRes.BuildArn,
+			BuildStatus: statusRes.BuildStatus,
+			Definition:  aws.StringValue(build.ProjectName),
+			Phase:       aws.StringValue(build.CurrentPhase),
+			TaskStatus:  statusRes.TaskStatus,
+			StartTime:   statusRes.StartTime,
+			EndTime:     statusRes.EndTime,
+		})
+	}
+
+	// builds are listed most recent first so once a whole page started before the range there is nothing more to find
+	if len(getBuildRes.Builds) > 0 && older == len(getBuildRes.Builds) {
+		res.NextToken = nil
+	}
+
+	return res, nil
+}
+
+// GetTaskLogs get task logs, the log group and stream are read from the build
+func (cbl *Launcher) GetTaskLogs(gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	id, err := cbl.resolveHandle(gtlp.Handle, gtlp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := cbl.buildLogLocation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return cbl.readBuildLogs(loc, gtlp)
+}
+
+func (cbl *Launcher) readBuildLogs(loc *logLocation, gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	if loc.s3Key != "" {
+		return cbl.readS3Logs(loc, gtlp)
+	}
+
+	res, err := cbl.cwlogsReader.ReadLogs(&cwlogs.ReadLogsParams{
+		GroupName:     loc.groupName,
+		StreamName:    loc.streamName,
+		TaskID:        loc.taskID,
+		NextToken:     gtlp.NextToken,
+		StartFromHead: gtlp.StartFromHead,
+		FieldMapping:  gtlp.FieldMapping,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve logs for task.")
+	}
+
+	return &GetTaskLogsResult{
+		LogLines:  res.LogLines,
+		NextToken: res.NextToken,
+	}, nil
+}
+
+// ExportTaskLogs export the logs of the build from the start to a local file along with a manifest
+func (cbl *Launcher) ExportTaskLogs(etlp *ExportTaskLogsParams) (*ExportTaskLogsResult, error) {
+
+	id, err := cbl.resolveHandle(etlp.Handle, etlp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := cbl.buildLogLocation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	groupName, streamName := loc.groupName, loc.streamName
+	if loc.s3Key != "" {
+		groupName, streamName = loc.s3Bucket, loc.s3Key
+	}
+
+	reader := &buildLogsReader{cbl: cbl, id: id, loc: loc}
+	defer reader.close()
+
+	res, err := cwlogs.Export(reader, &cwlogs.ExportParams{
+		GroupName:    groupName,
+		StreamName:   streamName,
+		Path:         etlp.Path,
+		Format:       etlp.Format,
+		TimeFormat:   etlp.TimeFormat,
+		ManifestPath: etlp.ManifestPath,
+		TaskID:       loc.taskID,
+		Definition:   loc.definition,
+		FieldMapping: etlp.FieldMapping,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export logs for build.")
+	}
+
+	return &ExportTaskLogsResult{
+		Path:         res.Path,
+		ManifestPath: res.ManifestPath,
+		Manifest:     res.Manifest,
+	}, nil
+}
+
+// maxLeaseUpdateAttempts the number of times the lease tag is written before giving up on concurrent updates
+const maxLeaseUpdateAttempts = 3
+
+// RenewLease extend the lease on the builds of the project by updating the project tag for the owner, expired
+// leases of other owners are removed at the same time
+func (cbl *Launcher) RenewLease(rlp *RenewLeaseParams) (*RenewLeaseResult, error) {
+
+	if rlp.Lease == nil {
+		return nil, errors.New("lease is required.")
+	}
+
+	res := &RenewLeaseResult{Expires: rlp.Lease.Expires(time.Now())}
+	if rlp.Expires != nil {
+		res.Expires = rlp.Expires.UTC()
+	}
+
+	key, value := rlp.Lease.ProjectTagKey(), launcher.FormatLeaseTime(res.Expires)
+
+	project, err := cbl.getProject(rlp.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		// the existing tags are read back as update project replaces all the tags
+		tags := launcher.PruneProjectLeases(convertCodebuildTagsToMap(project.Tags), time.Now())
+		tags[key] = value
+
+		_, err = cbl.codeBuildSvc.UpdateProject(&codebuild.UpdateProjectInput{
+			Name: aws.String(rlp.ProjectName),
+			Tags: convertMapToCodebuildTags(tags),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to tag project.")
+		}
+
+		// another owner renewing at the same time may have replaced the tags between the read and the update
+		project, err = cbl.getProject(rlp.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertCodebuildTagsToMap(project.Tags)[key] == value {
+			return res, nil
+		}
+
+		if attempt == maxLeaseUpdateAttempts {
+			return nil, errors.Errorf("lease tag on project %s was replaced by a concurrent update.", rlp.ProjectName)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"ProjectName": rlp.ProjectName,
+			"OwnerID":     rlp.Lease.OwnerID,
+		}).Warn("lease tag replaced by a concurrent update, renewing again")
+	}
+}
+
+// getProject get the project by name, an error is returned if it doesn't exist
+func (cbl *Launcher) getProject(projectName string) (*codebuild.Project, error) {
+
+	projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+		Names: []*string{aws.String(projectName)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project.")
+	}
+
+	if len(projRes.Projects) == 0 {
+		return nil, errors.Errorf("project not found: %s", projectName)
+	}
+
+	return projRes.Projects[0], nil
+}
+
+// RunTask start a build, write the logs to the sinks as they arrive and wait for it to complete, retrying as the policy allows
+func (cbl *Launcher) RunTask(rtp *RunTaskParams, sinks ...cwlogs.LogSink) (*RunTaskResult, error) {
+
+	br := &buildRun{cbl: cbl, rtp: rtp}
+
+	attempts, err := launcher.RunTask(cbl.statusPoller(), br, &launcher.RunParams{
+		FieldMapping:     rtp.FieldMapping,
+		PollInterval:     rtp.PollInterval,
+		DrainQuietPeriod: rtp.DrainQuietPeriod,
+		Lease:            rtp.Lease,
+		StopOnInterrupt:  rtp.StopOnInterrupt,
+		StopTimeout:      rtp.StopTimeout,
+		NotifyInterrupt:  cbl.notifyInterrupt,
+		RetryPolicy:      rtp.RetryPolicy,
+	}, sinks...)
+
+	if br.res == nil {
+		// the attempts are still returned when the last of the retries couldn't be started
+		if rtp.RetryPolicy == nil || len(attempts) == 0 {
+			return nil, err
+		}
+
+		return &RunTaskResult{TaskStatus: launcher.TaskFailed, Attempts: attempts}, err
+	}
+
+	if rtp.RetryPolicy != nil {
+		br.res.Attempts = attempts
+	}
+
+	return br.res, err
+}
+
+// buildRun the backend of a run, this keeps the builds started by the current attempt
+type buildRun struct {
+	cbl *Launcher
+	rtp *RunTaskParams
+
+	launchRes *LaunchTaskResult
+	reader    *buildLogsReader
+	res       *RunTaskResult
+}
+
+func (br *buildRun) Launch() (*launcher.RunLaunch, error) {
+	br.res = nil
+
+	launchRes, err := br.cbl.LaunchTask(&br.rtp.LaunchTaskParams)
+	if err != nil {
+		return nil, err
+	}
+
+	br.launchRes = launchRes
+	br.reader = &buildLogsReader{cbl: br.cbl, id: launchRes.ID}
+
+	return &launcher.RunLaunch{
+		TaskID: shortenBuildID(launchRes.ID),
+		IDs:    buildIDs(launchRes),
+		Reader: br.reader,
+	}, nil
+}
+
+func (br *buildRun) RenewLease(expires time.Time) error {
+	_, err := br.cbl.RenewLease(&RenewLeaseParams{ProjectName: br.rtp.ProjectName, Lease: br.rtp.Lease, Expires: &expires})
+	return err
+}
+
+func (br *buildRun) Stop() error {
+	return br.cbl.stopInterrupted(br.rtp, br.launchRes)
+}
+
+func (br *buildRun) Complete(ftr *launcher.FollowTaskResult, err error) (*launcher.RunAttempt, error) {
+	br.reader.close()
+
+	// the logs have been drained so the location is no longer needed
+	br.cbl.forgetLogLocation(br.launchRes.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	statusRes, err := br.cbl.GetTaskStatus(&GetTaskStatusParams{ID: br.launchRes.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RunTaskResult{
+		ID:           statusRes.ID,
+		TaskStatus:   statusRes.TaskStatus,
+		StartTime:    statusRes.StartTime,
+		EndTime:      statusRes.EndTime,
+		BuildArn:     statusRes.BuildArn,
+		BuildStatus:  statusRes.BuildStatus,
+		ExitCode:     statusRes.ExitCode,
+		LogLineCount: ftr.LineCount,
+		Handle:       br.launchRes.Handle,
+		Interrupted:  ftr.Stopped,
+	}
+
+	if br.reader.loc != nil {
+		res.LogGroupName = br.reader.loc.groupName
+		res.LogStreamName = br.reader.loc.streamName
+	}
+
+	br.res = res
+
+	return &launcher.RunAttempt{
+		ID:            res.ID,
+		TaskStatus:    res.TaskStatus,
+		StartTime:     res.StartTime,
+		EndTime:       res.EndTime,
+		Status:        res.BuildStatus,
+		ExitCode:      res.ExitCode,
+		Handle:        res.Handle,
+		LogGroupName:  res.LogGroupName,
+		LogStreamName: res.LogStreamName,
+		LogLineCount:  res.LogLineCount,
+	}, nil
+}
+
+// buildIDs the IDs of all the builds started by the launch
+func buildIDs(launchRes *LaunchTaskResult) []string {
+	ids := []string{}
+	for _, task := range launchRes.Tasks {
+		ids = append(ids, task.ID)
+	}
+
+	return ids
+}
+
+// stopInterrupted stop all the builds started by the run
+func (cbl *Launcher) stopInterrupted(rtp *RunTaskParams, launchRes *LaunchTaskResult) error {
+
+	reason := rtp.StopReason
+	if reason == "" {
+		reason = launcher.DefaultStopReason
+	}
+
+	for _, task := range launchRes.Tasks {
+		_, err := cbl.StopTask(&StopTaskParams{ID: task.ID, Reason: reason})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildLogsReader reads the logs of a single build, the log location is discovered on the first read which
+// finds it and kept for the reads which follow
+type buildLogsReader struct {
+	cbl *Launcher
+	id  string
+	loc *logLocation
+
+	// the S3 log object is kept open between pages
+	s3Logs *s3LogsStream
+}
+
+func (blr *buildLogsReader) ReadLogs(rlp *cwlogs.ReadLogsParams) (*cwlogs.ReadLogsResult, error) {
+	gtlp := &GetTaskLogsParams{
+		ID:            blr.id,
+		NextToken:     rlp.NextToken,
+		StartFromHead: rlp.StartFromHead,
+		FieldMapping:  rlp.FieldMapping,
+	}
+
+	if blr.loc == nil {
+		loc, err := blr.cbl.buildLogLocation(blr.id)
+		if err != nil {
+			return nil, err
+		}
+
+		blr.loc = loc
+	}
+
+	if blr.loc.s3Key != "" {
+		return blr.readS3Logs(gtlp)
+	}
+
+	res, err := blr.cbl.readBuildLogs(blr.loc, gtlp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cwlogs.ReadLogsResult{LogLines: res.LogLines, NextToken: res.NextToken}, nil
+}
+
+// readS3Logs continue reading the open S3 log object when the token is where it stopped, otherwise it is opened
+// again at the position of the token
+func (blr *buildLogsReader) readS3Logs(gtlp *GetTaskLogsParams) (*cwlogs.ReadLogsResult, error) {
+
+	pos, err := parseS3LogsToken(gtlp.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if blr.s3Logs == nil || blr.s3Logs.pos != *pos {
+		blr.close()
+
+		blr.s3Logs, err = blr.cbl.openS3Logs(blr.loc, pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := blr.s3Logs.readPage(blr.loc, gtlp.FieldMapping)
+	if err != nil {
+		blr.close()
+		return nil, err
+	}
+
+	return &cwlogs.ReadLogsResult{LogLines: res.LogLines, NextToken: res.NextToken}, nil
+}
+
+// close the S3 log object if it is still open
+func (blr *buildLogsReader) close() {
+	if blr.s3Logs != nil {
+		blr.s3Logs.close()
+		blr.s3Logs = nil
+	}
+}
+
+type logLocation struct {
+	groupName  string
+	streamName string
+	taskID     string
+	definition string
+
+	// set when the build logs are only available in S3
+	s3Bucket string
+	s3Key    string
+}
+
+// buildLogLocation read the cloudwatch log group and stream from the build, the stream
+// name isn't assigned until the build has started, locations are only cached until the build completes
+func (cbl *Launcher) buildLogLocation(buildID string) (*logLocation, error) {
+
+	if v, ok := cbl.logLocations.Load(buildID); ok {
+		return v.(*logLocation), nil
+	}
+
+	getBuildRes, err := cbl.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: []*string{aws.String(buildID)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get build.")
+	}
+
+	if len(getBuildRes.Builds) == 0 {
+		return nil, errors.Errorf("build not found: %s", buildID)
+	}
+
+	build := getBuildRes.Builds[0]
+	logs := build.Logs
+
+	if logs == nil {
+		return nil, launcher.ErrLogsNotAvailable
+	}
+
+	var loc *logLocation
+
+	switch {
+	case cloudwatchLogsEnabled(logs) && aws.StringValue(logs.GroupName) != "":
+		if aws.StringValue(logs.StreamName) == "" {
+			return nil, cwlogs.ErrLogStreamNotFound
+		}
+
+		loc = &logLocation{
+			groupName:  aws.StringValue(logs.GroupName),
+			streamName: aws.StringValue(logs.StreamName),
+			taskID:     shortenBuildID(buildID),
+			definition: aws.StringValue(build.ProjectName),
+		}
+	case logs.S3Logs != nil && aws.StringValue(logs.S3Logs.Status) == codebuild.LogsConfigStatusTypeEnabled:
+		if aws.StringValue(logs.S3LogsArn) == "" {
+			return nil, cwlogs.ErrLogStreamNotFound
+		}
+
+		bucket, key, err := parseS3Arn(aws.StringValue(logs.S3LogsArn))
+		if err != nil {
+			return nil, err
+		}
+
+		loc = &logLocation{
+			taskID:     shortenBuildID(buildID),
+			definition: aws.StringValue(build.ProjectName),
+			s3Bucket:   bucket,
+			s3Key:      key,
+		}
+	default:
+		return nil, launcher.ErrLogsNotAvailable
+	}
+
+	if !aws.BoolValue(build.BuildComplete) {
+		cbl.logLocations.Store(buildID, loc)
+	}
+
+	return loc, nil
+}
+
+// forgetLogLocation drop the cached log location of a build once it has completed
+func (cbl *Launcher) forgetLogLocation(buildID string) {
+	cbl.logLocations.Delete(buildID)
+}
+
+// readS3Logs read a page of the build logs uploaded to S3, the next token is the line offset of the next page
+func (cbl *Launcher) readS3Logs(loc *logLocation, gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	pos, err := parseS3LogsToken(gtlp.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := cbl.openS3Logs(loc, pos)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.close()
+
+	return stream.readPage(loc, gtlp.FieldMapping)
+}
+
+// openS3Logs open the S3 log object at the position, compressed objects are decompressed from the start
+func (cbl *Launcher) openS3Logs(loc *logLocation, pos *s3LogsPosition) (*s3LogsStream, error) {
+
+	compressed := strings.HasSuffix(loc.s3Key, ".gz")
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(loc.s3Bucket),
+		Key:    aws.String(loc.s3Key),
+	}
+
+	// compressed objects can't be resumed part way through so only plain text objects are read from the offset
+	if pos.offset > 0 && !compressed {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", pos.offset))
+	}
+
+	obj, err := cbl.s3Svc.GetObject(input)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			// logs are uploaded to S3 once the build completes
+			case s3.ErrCodeNoSuchKey:
+				return nil, cwlogs.ErrLogStreamNotFound
+			// the offset is at the end of the object so there is nothing more to read
+			case "InvalidRange":
+				return &s3LogsStream{pos: *pos, eof: true}, nil
+			}
+		}
+		return nil, errors.Wrap(err, "failed to retrieve logs for task from S3.")
+	}
+
+	var rd io.Reader = obj.Body
+
+	if compressed {
+		gr, err := gzip.NewReader(obj.Body)
+		if err != nil {
+			obj.Body.Close()
+			return nil, errors.Wrap(err, "failed to decompress logs for task from S3.")
+		}
+
+		// skip the bytes already read without splitting them into lines
+		_, err = io.CopyN(ioutil.Discard, gr, pos.offset)
+		if err != nil && err != io.EOF {
+			obj.Body.Close()
+			return nil, errors.Wrap(err, "failed to read logs for task from S3.")
+		}
+		rd = gr
+	}
+
+	return &s3LogsStream{body: obj.Body, br: bufio.NewReader(rd), pos: *pos}, nil
+}
+
+// s3LogsStream an S3 log object open at a position, this is kept between pages by the build logs reader so
+// compressed objects are only decompressed once
+type s3LogsStream struct {
+	body io.Closer
+	br   *bufio.Reader
+	pos  s3LogsPosition
+	eof  bool
+}
+
+// readPage read the next page of lines from the stream, the object is closed once the end is reached
+func (st *s3LogsStream) readPage(loc *logLocation, fieldMapping *cwlogs.FieldMapping) (*GetTaskLogsResult, error) {
+
+	logLines := []*cwlogs.LogLine{}
+
+	for !st.eof && len(logLines) < DefaultS3LogsPageSize {
+		text, err := st.br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, errors.Wrap(err, "failed to read logs for task from S3.")
+		}
+
+		if err == io.EOF {
+			st.eof = true
+			st.close()
+		}
+
+		if text == "" {
+			break
+		}
+
+		st.pos.offset += int64(len(text))
+
+		msg := strings.TrimRight(text, "\r\n")
+
+		line := &cwlogs.LogLine{
+			Message:    msg,
+			GroupName:  loc.s3Bucket,
+			StreamName: loc.s3Key,
+			TaskID:     loc.taskID,
+			// S3 logs have no timestamps so the line number keeps the event key unique
+			EventID: cwlogs.EventKey(loc.s3Bucket, loc.s3Key, &cloudwatchlogs.OutputLogEvent{
+				Message:   aws.String(msg),
+				Timestamp: aws.Int64(st.pos.line),
+			}, 0),
+		}
+
+		st.pos.line++
+
+		if fieldMapping != nil {
+			cwlogs.ParseJSONFields(line, fieldMapping)
+		}
+
+		logLines = append(logLines, line)
+	}
+
+	return &GetTaskLogsResult{
+		LogLines:  logLines,
+		NextToken: st.pos.token(),
+	}, nil
+}
+
+func (st *s3LogsStream) close() {
+	if st.body != nil {
+		st.body.Close()
+		st.body = nil
+	}
+}
+
+// s3LogsPosition the position reached in an S3 log object, the byte offset is where the next read starts and
+// the line number is used to keep event keys unique
+type s3LogsPosition struct {
+	offset int64
+	line   int64
+}
+
+func (pos *s3LogsPosition) token() *string {
+	return aws.String(fmt.Sprintf("%d:%d", pos.offset, pos.line))
+}
+
+// parseS3LogsToken parse a next token in the form "<byte offset>:<line number>"
+func parseS3LogsToken(nextToken *string) (*s3LogsPosition, error) {
+	pos := &s3LogsPosition{}
+
+	if nextToken == nil {
+		return pos, nil
+	}
+
+	tokens := strings.Split(aws.StringValue(nextToken), ":")
+	if len(tokens) != 2 {
+		return nil, errors.Errorf("invalid next token for S3 logs: %s", aws.StringValue(nextToken))
+	}
+
+	var err error
+
+	pos.offset, err = strconv.ParseInt(tokens[0], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid next token for S3 logs.")
+	}
+
+	pos.line, err = strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid next token for S3 logs.")
+	}
+
+	return pos, nil
+}
+
+func (cbl *Launcher) tryUpdateProject(dp *DefineTaskParams, logGroupName string) (string, bool, error) {
+	projRes, err := cbl.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+		Names: []*string{aws.String(dp.ProjectName)},
+	})
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to get project.")
+	}
+
+	if len(projRes.Projects) == 0 {
+		return "", false, nil
+	}
+
+	// update project replaces all the tags so the live leases on the builds of the project are carried over
+	tags := projectTags(dp)
+	for k, v := range launcher.ProjectLeaseTags(convertCodebuildTagsToMap(projRes.Projects[0].Tags), time.Now()) {
+		tags[k] = v
+	}
+
+	updateRes, err := cbl.codeBuildSvc.UpdateProject(&codebuild.UpdateProjectInput{
+		Name: aws.String(dp.ProjectName),
+		Environment: &codebuild.ProjectEnvironment{
+			ComputeType:          aws.String(dp.ComputeType),
+			Image:                aws.String(dp.Image),
+			Type:                 aws.String(codebuild.EnvironmentTypeLinuxContainer),
+			PrivilegedMode:       dp.PrivilegedMode,
+			EnvironmentVariables: convertMapToEnvironmentVariable(dp.Environment),
+		},
+		Artifacts: &codebuild.ProjectArtifacts{
+			Type: aws.String(codebuild.ArtifactsTypeNoArtifacts),
+		},
+		Source: &codebuild.ProjectSource{
+			Type:      aws.String(codebuild.SourceTypeNoSource),
+			Buildspec: aws.String(dp.Buildspec),
+		},
+		ServiceRole: aws.String(dp.ServiceRole),
+		LogsConfig:  buildLogsConfig(dp, logGroupName),
+		Tags:        convertMapToCodebuildTags(tags),
+	})
+	if err, ok := err.(awserr.Error); ok {
+		if err.Code() == "ResourceNotFoundException" {
+			return "", false, nil // skip this error as the job will be subsequently created
+		}
+		return "", false, errors.Wrap(err, "update codebuild project failed.")
+	}
+
+	return aws.StringValue(updateRes.Project.Arn), true, nil
+}
+
+// statusPoller the poller shared by all the tasks waiting on this launcher, it is attached to the status feed
+// if one was configured
+func (cbl *Launcher) statusPoller() *launcher.Poller {
+	cbl.pollerOnce.Do(func() {
+		if cbl.poller == nil {
+			cbl.poller = launcher.NewPoller(launcher.WithDoneHook(NewStatusFetcher(cbl.codeBuildSvc), cbl.forgetLogLocation), cbl.pollerConfig)
+		}
+
+		if cbl.statusFeed != nil {
+			cbl.statusFeed.Attach(cbl.poller)
+		}
+	})
+
+	return cbl.poller
+}
+
+// StatusFetcher gets batches of builds for the poller
+type StatusFetcher struct {
+	codeBuildSvc codebuildiface.CodeBuildAPI
+}
+
+// NewStatusFetcher create a status fetcher using the codebuild service
+func NewStatusFetcher(codeBuildSvc codebuildiface.CodeBuildAPI) *StatusFetcher {
+	return &StatusFetcher{codeBuildSvc: codeBuildSvc}
+}
+
+// FetchStatuses get the builds, the batch key isn't used as builds aren't grouped
+func (sf *StatusFetcher) FetchStatuses(batchKey string, ids []string) ([]*launcher.StatusUpdate, error) {
+	getBuildRes, err := sf.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice(ids),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get builds.")
+	}
+
+	updates := make([]*launcher.StatusUpdate, 0, len(ids))
+
+	for _, build := range getBuildRes.Builds {
+		updates = append(updates, BuildStatusUpdate(build))
+	}
+
+	for _, id := range getBuildRes.BuildsNotFound {
+		updates = append(updates, &launcher.StatusUpdate{
+			ID:  aws.StringValue(id),
+			Err: errors.Errorf("build not found: %s", aws.StringValue(id)),
+		})
+	}
+
+	return updates, nil
+}
+
+// BuildStatusUpdate convert a codebuild build into a status update
+func BuildStatusUpdate(build *codebuild.Build) *launcher.StatusUpdate {
+	su := &launcher.StatusUpdate{
+		ID:         aws.StringValue(build.Id),
+		TaskStatus: launcher.TaskRunning,
+		LastStatus: aws.StringValue(build.BuildStatus),
+		StartTime:  build.StartTime,
+		EndTime:    build.EndTime,
+		Timestamp:  launcher.LatestTime(build.StartTime, build.EndTime),
+	}
+
+	// each phase change is a change in the status of the build
+	for _, phase := range build.Phases {
+		su.Timestamp = launcher.LatestTime(su.Timestamp, phase.StartTime, phase.EndTime)
+	}
+
+	if aws.StringValue(build.CurrentPhase) != "" {
+		su.Details = map[string]string{"phase": aws.StringValue(build.CurrentPhase)}
+	}
+
+	// the build status can change before the build has completed its final phases
+	if aws.BoolValue(build.BuildComplete) {
+		if aws.StringValue(build.BuildStatus) == codebuild.StatusTypeSucceeded {
+			su.TaskStatus = launcher.TaskSucceeded
+		} else {
+			su.TaskStatus = launcher.TaskFailed
+		}
+	}
+
+	return su
+}
+
+// buildHandle the handle used to find the build again
+func buildHandle(rt *LaunchTaskParams, build *codebuild.Build) *launcher.TaskHandle {
+	return &launcher.TaskHandle{
+		Backend: launcher.BackendCodebuild,
+		Region:  launcher.RegionFromArn(aws.StringValue(build.Arn)),
+		Project: rt.ProjectName,
+		ID:      aws.StringValue(build.Id),
+	}
+}
+
+// resolveHandle the build ID in the handle, the supplied ID is returned when there is no handle
+func (cbl *Launcher) resolveHandle(handle, id string) (string, error) {
+	if handle == "" {
+		return id, nil
+	}
+
+	th, err := launcher.DecodeBackendHandle(handle, launcher.BackendCodebuild)
+	if err != nil {
+		return "", err
+	}
+
+	err = th.CheckRegion(cbl.region)
+	if err != nil {
+		return "", err
+	}
+
+	return th.ID, nil
+}
+
+// resolveHandles the build IDs in the handles are added to the IDs
+func (cbl *Launcher) resolveHandles(handles []string, ids []string) ([]string, error) {
+	resolved := append([]string{}, ids...)
+
+	for _, handle := range handles {
+		id, err := cbl.resolveHandle(handle, "")
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = append(resolved, id)
+	}
+
+	return resolved, nil
+}
+
+func newStopTaskResult(build *codebuild.Build) *StopTaskResult {
+	buildStatus := aws.StringValue(build.BuildStatus)
+
+	return &StopTaskResult{
+		ID:          aws.StringValue(build.Id),
+		BuildStatus: buildStatus,
+		TaskStatus:  convertTaskStatus(buildStatus),
+		ExitCode:    buildExitCode(build),
+		EndTime:     build.EndTime,
+	}
+}
+
+// commandExecutionError the phase context status code recorded when a build command fails
+const commandExecutionError = "COMMAND_EXECUTION_ERROR"
+
+// exitStatusRe matches the end of the message CodeBuild records when a command fails, for example
+// "Error while executing command: make test. Reason: exit status 2"
+var exitStatusRe = regexp.MustCompile(`Reason: exit status (\d+)$`)
+
+// buildExitCode CodeBuild only reports the exit code of a failed command in the phase context messages, this is
+// a heuristic which relies on the wording of the message, nil is returned when no message matches as the build
+// may have failed for another reason such as a timeout or being stopped
+func buildExitCode(build *codebuild.Build) *int64 {
+	if !aws.BoolValue(build.BuildComplete) {
+		return nil
+	}
+
+	if aws.StringValue(build.BuildStatus) == codebuild.StatusTypeSucceeded {
+		return aws.Int64(0)
+	}
+
+	for _, phase := range build.Phases {
+		for _, pc := range phase.Contexts {
+			if aws.StringValue(pc.StatusCode) != commandExecutionError {
+				continue
+			}
+
+			m := exitStatusRe.FindStringSubmatch(aws.StringValue(pc.Message))
+			if m == nil {
+				continue
+			}
+
+			code, err := strconv.ParseInt(m[1], 10, 64)
+			if err == nil {
+				return aws.Int64(code)
+			}
+		}
+	}
+
+	return nil
+}
+
+func newGetTaskStatusResult(build *codebuild.Build) *GetTaskStatusResult {
+	taskRes := &GetTaskStatusResult{
+		ID:          aws.StringValue(build.Id),
+		StartTime:   build.StartTime,
+		EndTime:     build.EndTime,
+		TaskStatus:  convertTaskStatus(aws.StringValue(build.BuildStatus)),
+		BuildArn:    aws.StringValue(build.Arn),
+		BuildStatus: aws.StringValue(build.BuildStatus),
+		ExitCode:    buildExitCode(build),
+	}
+
+	if aws.BoolValue(build.BuildComplete) {
+		if aws.StringValue(build.BuildStatus) == "SUCCEEDED" {
+			taskRes.TaskStatus = launcher.TaskSucceeded
+		} else {
+			taskRes.TaskStatus = launcher.TaskFailed
+		}
+	}
+
+	return taskRes
+}
+
+// newDefineTaskParams reverse the conversion done by define task, returning the log group used by the project
+func newDefineTaskParams(project *codebuild.Project) (*DefineTaskParams, string) {
+
+	dp := &DefineTaskParams{
+		ProjectName: aws.StringValue(project.Name),
+		ServiceRole: aws.StringValue(project.ServiceRole),
+		Region:      launcher.RegionFromArn(aws.StringValue(project.Arn)),
+		Tags:        launcher.StripOwnershipTags(convertCodebuildTagsToMap(project.Tags)),
+	}
+
+	if project.Environment != nil {
+		dp.ComputeType = aws.StringValue(project.Environment.ComputeType)
+		dp.PrivilegedMode = project.Environment.PrivilegedMode
+		dp.Image = aws.StringValue(project.Environment.Image)
+		dp.Environment = convertEnvironmentVariableToMap(project.Environment.EnvironmentVariables)
+	}
+
+	if project.Source != nil {
+		dp.Buildspec = aws.StringValue(project.Source.Buildspec)
+	}
+
+	logGroupName := ""
+
+	if project.LogsConfig != nil {
+		if project.LogsConfig.S3Logs != nil && aws.StringValue(project.LogsConfig.S3Logs.Status) == codebuild.LogsConfigStatusTypeEnabled {
+			dp.S3Logs = &S3LogsConfig{
+				Location:           aws.StringValue(project.LogsConfig.S3Logs.Location),
+				EncryptionDisabled: project.LogsConfig.S3Logs.EncryptionDisabled,
+			}
+		}
+
+		cwLogs := project.LogsConfig.CloudWatchLogs
+		if cwLogs != nil && aws.StringValue(cwLogs.Status) == codebuild.LogsConfigStatusTypeDisabled {
+			dp.DisableCloudwatchLogs = true
+			return dp, ""
+		}
+
+		if cwLogs != nil {
+			logGroupName = aws.StringValue(cwLogs.GroupName)
+		}
+	}
+
+	// projects without a group name log to the codebuild default
+	if logGroupName == "" {
+		logGroupName = fmt.Sprintf(CodebuildLogGroupFormat, dp.ProjectName)
+	}
+
+	return dp, logGroupName
+}
+
+func newDefineTaskResult(dp *DefineTaskParams, projectArn, logGroupName string) *DefineTaskResult {
+	defRes := &DefineTaskResult{
+		ID:                     projectArn,
+		CloudwatchLogGroupName: logGroupName,
+	}
+
+	if logGroupName != "" {
+		defRes.CloudwatchStreamPrefix = CodebuildStreamPrefix
+	}
+
+	if dp.S3Logs != nil {
+		defRes.S3LogsLocation = dp.S3Logs.Location
+	}
+
+	return defRes
+}
+
+func buildLogsConfig(dp *DefineTaskParams, logGroupName string) *codebuild.LogsConfig {
+	logsConfig := &codebuild.LogsConfig{
+		CloudWatchLogs: &codebuild.CloudWatchLogsConfig{
+			Status: aws.String(codebuild.LogsConfigStatusTypeDisabled),
+		},
+	}
+
+	if !dp.DisableCloudwatchLogs {
+		logsConfig.CloudWatchLogs = &codebuild.CloudWatchLogsConfig{
+			GroupName:  aws.String(logGroupName),
+			StreamName: aws.String(CodebuildStreamPrefix),
+			Status:     aws.String(codebuild.LogsConfigStatusTypeEnabled),
+		}
+	}
+
+	if dp.S3Logs != nil {
+		logsConfig.S3Logs = &codebuild.S3LogsConfig{
+			Location:           aws.String(dp.S3Logs.Location),
+			EncryptionDisabled: dp.S3Logs.EncryptionDisabled,
+			Status:             aws.String(codebuild.LogsConfigStatusTypeEnabled),
+		}
+	}
+
+	return logsConfig
+}
+
+func cloudwatchLogsEnabled(logs *codebuild.LogsLocation) bool {
+	// builds of projects created before logs were configurable have no cloudwatch config
+	if logs.CloudWatchLogs == nil {
+		return true
+	}
+
+	return aws.StringValue(logs.CloudWatchLogs.Status) != codebuild.LogsConfigStatusTypeDisabled
+}
+
+// parseS3Arn split an S3 object ARN, "arn:<partition>:s3:::<bucket>/<key>", into the bucket and key
+func parseS3Arn(s3Arn string) (string, string, error) {
+	a, err := arn.Parse(s3Arn)
+	if err != nil || a.Service != s3.ServiceName {
+		return "", "", errors.Errorf("invalid S3 logs ARN: %s", s3Arn)
+	}
+
+	tokens := strings.SplitN(a.Resource, "/", 2)
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return "", "", errors.Errorf("invalid S3 logs ARN: %s", s3Arn)
+	}
+
+	return tokens[0], tokens[1], nil
+}
+
+// shortenBuildID strip the project name from the build identifier, "<project>:<uuid>"
+func shortenBuildID(buildID string) string {
+	tokens := strings.Split(buildID, ":")
+	return tokens[len(tokens)-1]
+}
+
+// projectTags projects are tagged with their owner along with the log group name format so it can be described
+func projectTags(dp *DefineTaskParams) map[string]string {
+	tags := launcher.OwnershipTags(dp.Tags)
+
+	if dp.LogGroup != nil && dp.LogGroup.NameFormat != "" {
+		tags[launcher.LogGroupFormatTagKey] = launcher.LogGroupFormatTag(dp.LogGroup.NameFormat)
+	}
+
+	return tags
+}
+
+// buildEnvironment builds can't be tagged so the lease is passed to the build as environment markers
+func buildEnvironment(rt *LaunchTaskParams) map[string]string {
+	if rt.Lease == nil {
+		return rt.Environment
+	}
+
+	env := map[string]string{}
+	for k, v := range rt.Environment {
+		env[k] = v
+	}
+
+	env[launcher.LeaseOwnerEnvVar] = rt.Lease.OwnerID
+	env[launcher.LeaseExpiresEnvVar] = launcher.FormatLeaseTime(rt.Lease.Expires(time.Now()))
+
+	return env
+}
+
+func convertMapToEnvironmentVariable(env map[string]string) []*codebuild.EnvironmentVariable {
+
+	codebuildEnv := []*codebuild.EnvironmentVariable{}
+
+	// empty map is valid
+	if env == nil {
+		return nil
+	}
+
+	for k, v := range env {
+		codebuildEnv = append(codebuildEnv, &codebuild.EnvironmentVariable{Name: aws.String(k), Value: aws.String(v)})
+	}
+
+	return codebuildEnv
+}
+
+func convertEnvironmentVariableToMap(codebuildEnv []*codebuild.EnvironmentVariable) map[string]string {
+	if len(codebuildEnv) == 0 {
+		return nil
+	}
+
+	env := map[string]string{}
+
+	for _, ev := range codebuildEnv {
+		env[aws.StringValue(ev.Name)] = aws.StringValue(ev.Value)
+	}
+
+	return env
+}
+
+func convertMapToCodebuildTags(tags map[string]string) []*codebuild.Tag {
+
+	codebuildTags := []*codebuild.Tag{}
+
+	// empty map is valid
+	if tags == nil {
+		return nil
+	}
+
+	keys := []string{}
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	// sorted so the tags are the same on every update
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		codebuildTags = append(codebuildTags, &codebuild.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+
+	return codebuildTags
+}
+
+func convertCodebuildTagsToMap(tags []*codebuild.Tag) map[string]string {
+	tagsMap := map[string]string{}
+
+	for _, tag := range tags {
+		tagsMap[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tagsMap
 }
 
 func convertTaskStatus(codebuildStatus string) string {
@@ -1,11 +1,22 @@
 package codebuild
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/wolfeidau/aws-launch/awsmocks"
@@ -43,11 +54,28 @@ func TestLauncher_LaunchTask(t *testing.T) {
 		Image: aws.String("wolfeidau/codebuild-docker-buildkite:17.09.0"),
 	}
 
+	handle := (&launcher.TaskHandle{
+		Backend: launcher.BackendCodebuild,
+		Region:  "ap-southeast-2",
+		Project: "testing-1",
+		ID:      "abc123",
+	}).Encode()
+
 	want := &LaunchTaskResult{
 		ID:          "abc123",
 		TaskStatus:  launcher.TaskRunning,
 		BuildArn:    codebuildArn,
 		BuildStatus: codebuild.StatusTypeInProgress,
+		Handle:      handle,
+		Tasks: []*LaunchedTask{
+			{
+				ID:          "abc123",
+				TaskStatus:  launcher.TaskRunning,
+				BuildArn:    codebuildArn,
+				BuildStatus: codebuild.StatusTypeInProgress,
+				Handle:      handle,
+			},
+		},
 	}
 	cbl := &Launcher{
 		codeBuildSvc: codeBuildSvcMock,
@@ -59,12 +87,235 @@ func TestLauncher_LaunchTask(t *testing.T) {
 
 }
 
+func TestLauncher_LaunchTask_Count(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(&codebuild.StartBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String("testing-1:abc1"),
+			BuildStatus: aws.String(codebuild.StatusTypeInProgress),
+		},
+	}, nil).Twice()
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(nil, errors.New("account limit exceeded")).Once()
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{ProjectName: "testing-1", Count: 3})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 2)
+	require.Equal(t, []*launcher.TaskFailure{{Reason: "account limit exceeded"}}, got.Failures)
+}
+
+func TestLauncher_GetTasksStatus(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc1", "testing-1:abc2"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:abc1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+			},
+		},
+		BuildsNotFound: aws.StringSlice([]string{"testing-1:abc2"}),
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.GetTasksStatus(&GetTasksStatusParams{IDs: []string{"testing-1:abc1", "testing-1:abc2"}})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 1)
+	require.Equal(t, launcher.TaskSucceeded, got.Tasks[0].TaskStatus)
+	require.Equal(t, []*launcher.TaskFailure{{ID: "testing-1:abc2", Reason: "build not found"}}, got.Failures)
+}
+
+func TestLauncher_GetTasksStatus_Handles(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc1", "testing-1:abc2"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:abc1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+			},
+			{
+				Id:            aws.String("testing-1:abc2"),
+				BuildStatus:   aws.String(codebuild.StatusTypeFailed),
+				BuildComplete: aws.Bool(true),
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		region:       "ap-southeast-2",
+	}
+
+	got, err := cbl.GetTasksStatus(&GetTasksStatusParams{
+		IDs:     []string{"testing-1:abc1"},
+		Handles: []string{(&launcher.TaskHandle{Backend: launcher.BackendCodebuild, Region: "ap-southeast-2", ID: "testing-1:abc2"}).Encode()},
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 2)
+	require.Equal(t, launcher.TaskFailed, got.Tasks[1].TaskStatus)
+
+	_, err = cbl.GetTasksStatus(&GetTasksStatusParams{
+		Handles: []string{(&launcher.TaskHandle{Backend: launcher.BackendCodebuild, Region: "us-east-1", ID: "testing-1:abc2"}).Encode()},
+	})
+	require.EqualError(t, err, "handle is for region us-east-1 not ap-southeast-2: invalid task handle")
+}
+
+func TestLauncher_ListTasks(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	after := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	codeBuildSvcMock.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("testing-1"),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids:       aws.StringSlice([]string{"testing-1:abc3", "testing-1:abc2", "testing-1:abc1"}),
+		NextToken: aws.String("next"),
+	}, nil)
+	codeBuildSvcMock.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("testing-1"),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+		NextToken:   aws.String("next"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids:       aws.StringSlice([]string{"testing-1:abc0"}),
+		NextToken: aws.String("more"),
+	}, nil)
+
+	// the batch call doesn't return the builds in the order they were listed
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc3", "testing-1:abc2", "testing-1:abc1"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:abc1"),
+				ProjectName:   aws.String("testing-1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+				StartTime:     aws.Time(after.Add(-time.Hour)),
+			},
+			{
+				Id:            aws.String("testing-1:abc3"),
+				ProjectName:   aws.String("testing-1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeInProgress),
+				BuildComplete: aws.Bool(false),
+				StartTime:     aws.Time(after.Add(2 * time.Hour)),
+			},
+			{
+				Id:            aws.String("testing-1:abc2"),
+				ProjectName:   aws.String("testing-1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+				StartTime:     aws.Time(after.Add(time.Hour)),
+			},
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc0"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:abc0"),
+				ProjectName:   aws.String("testing-1"),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+				StartTime:     aws.Time(after.Add(-2 * time.Hour)),
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	ltp := &ListTasksParams{
+		ProjectName: "testing-1",
+		TaskFilter: launcher.TaskFilter{
+			TaskStatus:   []string{launcher.TaskSucceeded},
+			StartedAfter: &after,
+		},
+	}
+
+	got, err := cbl.ListTasks(ltp)
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 1)
+	require.Equal(t, "testing-1:abc2", got.Tasks[0].ID)
+	require.Equal(t, "testing-1", got.Tasks[0].Definition)
+
+	// some builds in the page are in the range so there may be more
+	require.Equal(t, "next", aws.StringValue(got.NextToken))
+
+	ltp.NextToken = got.NextToken
+
+	got, err = cbl.ListTasks(ltp)
+	require.Nil(t, err)
+	require.Empty(t, got.Tasks)
+
+	// the whole page started before the range so there are no more pages
+	require.Nil(t, got.NextToken)
+}
+
+func TestLauncher_StopTasks(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String("testing-1:abc1")}).Return(&codebuild.StopBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String("testing-1:abc1"),
+			BuildStatus: aws.String(codebuild.StatusTypeStopped),
+		},
+	}, nil)
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String("testing-1:abc2")}).Return(nil, errors.New("build not found"))
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.StopTasks(&StopTasksParams{IDs: []string{"testing-1:abc1", "testing-1:abc2"}})
+	require.Nil(t, err)
+	require.Equal(t, []*StopTaskResult{{ID: "testing-1:abc1", BuildStatus: codebuild.StatusTypeStopped, TaskStatus: launcher.TaskStopped}}, got.Tasks)
+	require.Equal(t, []*launcher.TaskFailure{{ID: "testing-1:abc2", Reason: "build not found"}}, got.Failures)
+}
+
 func TestLauncher_DefineTask_With_Update(t *testing.T) {
 
 	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
 	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
 
+	leaseExpires := launcher.FormatLeaseTime(time.Now().Add(time.Hour))
+
 	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Name: aws.String("testing-1"),
+				Tags: []*codebuild.Tag{
+					{Key: aws.String("OldTag"), Value: aws.String("old")},
+					{Key: aws.String("leaseExpires:worker-1"), Value: aws.String(leaseExpires)},
+					{Key: aws.String("leaseExpires:worker-2"), Value: aws.String("2020-01-02T03:04:05Z")},
+				},
+			},
+		},
+	}, nil)
 	codeBuildSvcMock.On("UpdateProject", &codebuild.UpdateProjectInput{
 		Environment: &codebuild.ProjectEnvironment{
 			ComputeType: aws.String("BUILD_GENERAL1_SMALL"),
@@ -95,6 +346,15 @@ func TestLauncher_DefineTask_With_Update(t *testing.T) {
 				Key:   aws.String("TestTag"),
 				Value: aws.String("test"),
 			},
+			{
+				Key:   aws.String(launcher.CreatedByTagKey),
+				Value: aws.String(launcher.CreatedByTagValue),
+			},
+			{
+				// the live lease is kept while the expired one is dropped
+				Key:   aws.String("leaseExpires:worker-1"),
+				Value: aws.String(leaseExpires),
+			},
 		},
 	}).Return(&codebuild.UpdateProjectOutput{
 		Project: &codebuild.Project{
@@ -130,6 +390,60 @@ func TestLauncher_DefineTask_With_Update(t *testing.T) {
 	require.Equal(t, want, got)
 }
 
+func TestLauncher_DefineTask_With_LogGroup(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	cwlogsSvcMock.On("CreateLogGroup", &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String("/ci/testing-1"),
+		Tags:         aws.StringMap(launcher.OwnershipTags(nil)),
+	}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvcMock.On("PutRetentionPolicy", &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/ci/testing-1"),
+		RetentionInDays: aws.Int64(14),
+	}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{{Name: aws.String("testing-1")}},
+	}, nil)
+	codeBuildSvcMock.On("UpdateProject", mock.MatchedBy(func(input *codebuild.UpdateProjectInput) bool {
+		return aws.StringValue(input.LogsConfig.CloudWatchLogs.GroupName) == "/ci/testing-1"
+	})).Return(&codebuild.UpdateProjectOutput{
+		Project: &codebuild.Project{
+			Arn: aws.String("abc123/codebuild/whatever"),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ProjectName: "testing-1",
+		ComputeType: "BUILD_GENERAL1_SMALL",
+		Image:       "wolfeidau/codebuild-docker-buildkite:17.09.0",
+		ServiceRole: "abc123Role",
+		LogGroup: &cwlogs.LogGroupConfig{
+			NameFormat:      "/ci/%s",
+			RetentionInDays: aws.Int64(14),
+		},
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    cwlogsSvcMock,
+	}
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, "/ci/testing-1", got.CloudwatchLogGroupName)
+
+	// formats without exactly one %s are rejected before anything is created
+	dp.LogGroup.NameFormat = "/ci/shared"
+
+	_, err = cbl.DefineTask(dp)
+	require.Error(t, err)
+	cwlogsSvcMock.AssertNumberOfCalls(t, "CreateLogGroup", 1)
+}
+
 func TestLauncher_GetTaskStatus(t *testing.T) {
 
 	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
@@ -190,6 +504,159 @@ func TestLauncher_StopTask(t *testing.T) {
 	require.Equal(t, want, got)
 }
 
+func Test_buildExitCode(t *testing.T) {
+	require.Nil(t, buildExitCode(&codebuild.Build{BuildComplete: aws.Bool(false)}))
+	require.Equal(t, aws.Int64(0), buildExitCode(&codebuild.Build{BuildComplete: aws.Bool(true), BuildStatus: aws.String(codebuild.StatusTypeSucceeded)}))
+	require.Equal(t, aws.Int64(2), buildExitCode(&codebuild.Build{
+		BuildComplete: aws.Bool(true),
+		BuildStatus:   aws.String(codebuild.StatusTypeFailed),
+		Phases: []*codebuild.BuildPhase{
+			{PhaseType: aws.String(codebuild.BuildPhaseTypeProvisioning)},
+			{
+				PhaseType: aws.String(codebuild.BuildPhaseTypeBuild),
+				Contexts: []*codebuild.PhaseContext{
+					{StatusCode: aws.String("COMMAND_EXECUTION_ERROR"), Message: aws.String("Error while executing command: make test. Reason: exit status 2")},
+				},
+			},
+		},
+	}))
+
+	// failures without a command exit status have no exit code
+	require.Nil(t, buildExitCode(&codebuild.Build{
+		BuildComplete: aws.Bool(true),
+		BuildStatus:   aws.String(codebuild.StatusTypeTimedOut),
+		Phases: []*codebuild.BuildPhase{
+			{
+				PhaseType: aws.String(codebuild.BuildPhaseTypeBuild),
+				Contexts: []*codebuild.PhaseContext{
+					{StatusCode: aws.String("BUILD_TIMED_OUT"), Message: aws.String("Build has timed out.")},
+				},
+			},
+		},
+	}))
+	require.Nil(t, buildExitCode(&codebuild.Build{
+		BuildComplete: aws.Bool(true),
+		BuildStatus:   aws.String(codebuild.StatusTypeFailed),
+		Phases: []*codebuild.BuildPhase{
+			{
+				PhaseType: aws.String(codebuild.BuildPhaseTypeBuild),
+				Contexts: []*codebuild.PhaseContext{
+					{StatusCode: aws.String("CLIENT_ERROR"), Message: aws.String("exit status 1 reported by the docker daemon, retrying")},
+				},
+			},
+		},
+	}))
+}
+
+func TestLauncher_RenewLease(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	expires := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	renewed := []*codebuild.Tag{
+		{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)},
+		{Key: aws.String("leaseExpires:worker-1"), Value: aws.String("2020-01-02T03:04:05Z")},
+	}
+
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Name: aws.String("testing-1"),
+				Tags: []*codebuild.Tag{
+					{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)},
+					{Key: aws.String("leaseExpires:worker-2"), Value: aws.String("2020-01-02T03:04:05Z")},
+				},
+			},
+		},
+	}, nil).Once()
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{{Name: aws.String("testing-1"), Tags: renewed}},
+	}, nil).Once()
+
+	// the expired lease of worker-2 is removed
+	codeBuildSvcMock.On("UpdateProject", &codebuild.UpdateProjectInput{
+		Name: aws.String("testing-1"),
+		Tags: renewed,
+	}).Return(&codebuild.UpdateProjectOutput{}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.RenewLease(&RenewLeaseParams{
+		ProjectName: "testing-1",
+		Lease:       &launcher.LeaseConfig{OwnerID: "worker-1"},
+		Expires:     &expires,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &RenewLeaseResult{Expires: expires}, got)
+}
+
+func TestLauncher_RenewLease_Concurrent(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	other := launcher.FormatLeaseTime(time.Now().Add(time.Hour))
+
+	project := func(tags map[string]string) *codebuild.BatchGetProjectsOutput {
+		return &codebuild.BatchGetProjectsOutput{
+			Projects: []*codebuild.Project{{Name: aws.String("testing-1"), Tags: convertMapToCodebuildTags(tags)}},
+		}
+	}
+
+	input := aws.StringSlice([]string{"testing-1"})
+
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{Names: input}).Return(project(map[string]string{}), nil).Once()
+	// worker-2 renewed between the read and the update, replacing the lease of worker-1
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{Names: input}).Return(project(map[string]string{
+		"leaseExpires:worker-2": other,
+	}), nil).Once()
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{Names: input}).Return(project(map[string]string{
+		"leaseExpires:worker-1": launcher.FormatLeaseTime(expires),
+		"leaseExpires:worker-2": other,
+	}), nil).Once()
+	codeBuildSvcMock.On("UpdateProject", mock.AnythingOfType("*codebuild.UpdateProjectInput")).Return(&codebuild.UpdateProjectOutput{}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.RenewLease(&RenewLeaseParams{
+		ProjectName: "testing-1",
+		Lease:       &launcher.LeaseConfig{OwnerID: "worker-1"},
+		Expires:     &expires,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &RenewLeaseResult{Expires: expires}, got)
+
+	// the second update merges the lease of worker-2
+	codeBuildSvcMock.AssertNumberOfCalls(t, "UpdateProject", 2)
+	codeBuildSvcMock.AssertCalled(t, "UpdateProject", &codebuild.UpdateProjectInput{
+		Name: aws.String("testing-1"),
+		Tags: convertMapToCodebuildTags(map[string]string{
+			"leaseExpires:worker-1": launcher.FormatLeaseTime(expires),
+			"leaseExpires:worker-2": other,
+		}),
+	})
+}
+
+func Test_buildEnvironment(t *testing.T) {
+	env := buildEnvironment(&LaunchTaskParams{
+		Environment: map[string]string{"TestEnv": "test"},
+		Lease:       &launcher.LeaseConfig{OwnerID: "worker-1"},
+	})
+
+	require.Equal(t, "test", env["TestEnv"])
+	require.Equal(t, "worker-1", env[launcher.LeaseOwnerEnvVar])
+	require.NotNil(t, launcher.ParseLeaseTime(env[launcher.LeaseExpiresEnvVar]))
+}
+
 func TestLauncher_CleanUpTask(t *testing.T) {
 
 	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
@@ -199,7 +666,9 @@ func TestLauncher_CleanUpTask(t *testing.T) {
 	ct := &CleanupTaskParams{
 		ProjectName: "testing-1",
 	}
-	want := &CleanupTaskResult{}
+	want := &CleanupTaskResult{
+		DeletedProject: "testing-1",
+	}
 
 	cbl := &Launcher{
 		codeBuildSvc: codeBuildSvcMock,
@@ -210,27 +679,899 @@ func TestLauncher_CleanUpTask(t *testing.T) {
 	require.Equal(t, want, got)
 }
 
-func TestLauncher_GetTaskLogs(t *testing.T) {
+func TestLauncher_CleanUpTask_StopBuilds(t *testing.T) {
 
-	cwlogsReader := &mocks.LogsReader{}
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
 
-	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
-		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
-		NextToken: aws.String("f/123456789"),
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Arn:  aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"),
+				Name: aws.String("testing-1"),
+			},
+		},
 	}, nil)
+	codeBuildSvcMock.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("testing-1"),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2", "testing-1:abc1"}),
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2", "testing-1:abc1"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{Id: aws.String("testing-1:abc2"), BuildStatus: aws.String(codebuild.StatusTypeInProgress), BuildComplete: aws.Bool(false)},
+			{Id: aws.String("testing-1:abc1"), BuildStatus: aws.String(codebuild.StatusTypeSucceeded), BuildComplete: aws.Bool(true)},
+		},
+	}, nil)
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String("testing-1:abc2")}).Return(&codebuild.StopBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String("testing-1:abc2"),
+			BuildStatus: aws.String(codebuild.StatusTypeInProgress),
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{Id: aws.String("testing-1:abc2"), BuildStatus: aws.String(codebuild.StatusTypeStopped), BuildComplete: aws.Bool(true)},
+		},
+	}, nil)
+	codeBuildSvcMock.On("DeleteProject", &codebuild.DeleteProjectInput{Name: aws.String("testing-1")}).Return(&codebuild.DeleteProjectOutput{}, nil)
+	cwlogsSvcMock.On("PutRetentionPolicy", &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/aws/codebuild/testing-1"),
+		RetentionInDays: aws.Int64(7),
+	}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
 
-	gt := &GetTaskLogsParams{}
-
-	want := &GetTaskLogsResult{
-		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
-		NextToken: aws.String("f/123456789"),
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    cwlogsSvcMock,
+		pollerConfig: &launcher.PollerConfig{MinInterval: time.Millisecond},
 	}
 
+	got, err := cbl.CleanupTask(&CleanupTaskParams{
+		ProjectName:        "testing-1",
+		StopBuilds:         true,
+		DeleteLogGroup:     true,
+		LogRetentionInDays: aws.Int64(7),
+	})
+	require.Nil(t, err)
+	require.Equal(t, &CleanupTaskResult{
+		DeletedProject:   "testing-1",
+		StoppedBuilds:    []string{"testing-1:abc2"},
+		ExpiringLogGroup: "/aws/codebuild/testing-1",
+	}, got)
+	cwlogsSvcMock.AssertNotCalled(t, "DeleteLogGroup", mock.Anything)
+	codeBuildSvcMock.AssertCalled(t, "BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2"}),
+	})
+}
+
+func TestLauncher_CleanUpTask_StopBuilds_Failed(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("testing-1"),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids:       aws.StringSlice([]string{"testing-1:abc3"}),
+		NextToken: aws.String("page-2"),
+	}, nil)
+	codeBuildSvcMock.On("ListBuildsForProject", &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String("testing-1"),
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+		NextToken:   aws.String("page-2"),
+	}).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2", "testing-1:abc1"}),
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc3"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{Id: aws.String("testing-1:abc3"), BuildStatus: aws.String(codebuild.StatusTypeSucceeded), BuildComplete: aws.Bool(true)},
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", &codebuild.BatchGetBuildsInput{
+		Ids: aws.StringSlice([]string{"testing-1:abc2", "testing-1:abc1"}),
+	}).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{Id: aws.String("testing-1:abc2"), BuildStatus: aws.String(codebuild.StatusTypeInProgress), BuildComplete: aws.Bool(false)},
+			{Id: aws.String("testing-1:abc1"), BuildStatus: aws.String(codebuild.StatusTypeInProgress), BuildComplete: aws.Bool(false)},
+		},
+	}, nil)
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String("testing-1:abc2")}).Return(&codebuild.StopBuildOutput{
+		Build: &codebuild.Build{Id: aws.String("testing-1:abc2")},
+	}, nil)
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String("testing-1:abc1")}).Return(nil, errors.New("access denied"))
+
 	cbl := &Launcher{
-		cwlogsReader: cwlogsReader,
+		codeBuildSvc: codeBuildSvcMock,
 	}
 
-	got, err := cbl.GetTaskLogs(gt)
-	require.Nil(t, err)
-	require.Equal(t, want, got)
+	got, err := cbl.CleanupTask(&CleanupTaskParams{
+		ProjectName: "testing-1",
+		StopBuilds:  true,
+	})
+	require.Error(t, err)
+	require.Equal(t, &CleanupTaskResult{
+		StoppedBuilds: []string{"testing-1:abc2"},
+	}, got)
+	codeBuildSvcMock.AssertNotCalled(t, "DeleteProject", mock.Anything)
+}
+
+func TestLauncher_GetTaskLogs(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/custom/testing-1"),
+					StreamName: aws.String("custom/b17dddde-97c6-4592-b7be-216524f8422b"),
+				},
+			},
+		},
+	}, nil)
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/testing-1",
+		StreamName: "custom/b17dddde-97c6-4592-b7be-216524f8422b",
+		TaskID:     "b17dddde-97c6-4592-b7be-216524f8422b",
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
+		NextToken: aws.String("f/123456789"),
+	}, nil)
+
+	gt := &GetTaskLogsParams{
+		ID: "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+	}
+
+	want := &GetTaskLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
+		NextToken: aws.String("f/123456789"),
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsReader: cwlogsReader,
+	}
+
+	got, err := cbl.GetTaskLogs(gt)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+
+	// the location of a running build is cached until it completes
+	_, ok := cbl.logLocations.Load(gt.ID)
+	require.True(t, ok)
+
+	cbl.forgetLogLocation(gt.ID)
+
+	_, ok = cbl.logLocations.Load(gt.ID)
+	require.False(t, ok)
+}
+
+func TestLauncher_GetTaskLogs_Completed(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				BuildComplete: aws.Bool(true),
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/aws/codebuild/testing-1"),
+					StreamName: aws.String("b17dddde-97c6-4592-b7be-216524f8422b"),
+				},
+			},
+		},
+	}, nil)
+
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
+		NextToken: aws.String("f/123456789"),
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsReader: cwlogsReader,
+	}
+
+	_, err := cbl.GetTaskLogs(&GetTaskLogsParams{ID: "testing-1:b17dddde-97c6-4592-b7be-216524f8422b"})
+	require.Nil(t, err)
+
+	// completed builds aren't cached
+	_, ok := cbl.logLocations.Load("testing-1:b17dddde-97c6-4592-b7be-216524f8422b")
+	require.False(t, ok)
+}
+
+func TestLauncher_GetTaskLogs_NotStarted(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				Logs: &codebuild.LogsLocation{
+					GroupName: aws.String("/aws/codebuild/testing-1"),
+				},
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	_, err := cbl.GetTaskLogs(&GetTaskLogsParams{ID: "testing-1:b17dddde-97c6-4592-b7be-216524f8422b"})
+	require.Equal(t, cwlogs.ErrLogStreamNotFound, err)
+}
+
+func TestLauncher_DefineTask_With_S3Logs(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{{Name: aws.String("testing-1")}},
+	}, nil)
+	codeBuildSvcMock.On("UpdateProject", mock.MatchedBy(func(in *codebuild.UpdateProjectInput) bool {
+		return aws.StringValue(in.LogsConfig.CloudWatchLogs.Status) == codebuild.LogsConfigStatusTypeDisabled &&
+			aws.StringValue(in.LogsConfig.S3Logs.Status) == codebuild.LogsConfigStatusTypeEnabled &&
+			aws.StringValue(in.LogsConfig.S3Logs.Location) == "build-logs-bucket/testing-1"
+	})).Return(&codebuild.UpdateProjectOutput{
+		Project: &codebuild.Project{
+			Arn: aws.String("abc123/codebuild/whatever"),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ProjectName: "testing-1",
+		ComputeType: "BUILD_GENERAL1_SMALL",
+		Image:       "wolfeidau/codebuild-docker-buildkite:17.09.0",
+		ServiceRole: "abc123Role",
+		S3Logs: &S3LogsConfig{
+			Location: "build-logs-bucket/testing-1",
+		},
+		DisableCloudwatchLogs: true,
+	}
+	want := &DefineTaskResult{
+		ID:             "abc123/codebuild/whatever",
+		S3LogsLocation: "build-logs-bucket/testing-1",
+	}
+
+	// no cloudwatch log group is created as it isn't used
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    &awsmocks.CloudWatchLogsAPI{},
+	}
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestLauncher_DescribeDefinition(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	dp := &DefineTaskParams{
+		ProjectName:    "testing-1",
+		ComputeType:    "BUILD_GENERAL1_SMALL",
+		PrivilegedMode: aws.Bool(true),
+		Buildspec:      "version: 0.2",
+		Image:          "wolfeidau/codebuild-docker-buildkite:17.09.0",
+		ServiceRole:    "arn:aws:iam::123456789012:role/abc123Role",
+		Region:         "ap-southeast-2",
+		Environment:    map[string]string{"TEST": "true"},
+		Tags:           map[string]string{"team": "build"},
+		LogGroup: &cwlogs.LogGroupConfig{
+			RetentionInDays: aws.Int64(7),
+		},
+	}
+
+	var updated *codebuild.UpdateProjectInput
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvcMock.On("PutRetentionPolicy", mock.AnythingOfType("*cloudwatchlogs.PutRetentionPolicyInput")).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+	cwlogsSvcMock.On("DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/aws/codebuild/testing-1"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("/aws/codebuild/testing-1"), RetentionInDays: aws.Int64(7)},
+		},
+	}, nil)
+	cwlogsSvcMock.On("ListTagsLogGroup", &cloudwatchlogs.ListTagsLogGroupInput{
+		LogGroupName: aws.String("/aws/codebuild/testing-1"),
+	}).Return(&cloudwatchlogs.ListTagsLogGroupOutput{
+		Tags: aws.StringMap(map[string]string{launcher.CreatedByTagKey: launcher.CreatedByTagValue}),
+	}, nil)
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{{Name: aws.String("testing-1")}},
+	}, nil).Once()
+	codeBuildSvcMock.On("UpdateProject", mock.AnythingOfType("*codebuild.UpdateProjectInput")).Run(func(args mock.Arguments) {
+		updated = args.Get(0).(*codebuild.UpdateProjectInput)
+	}).Return(&codebuild.UpdateProjectOutput{
+		Project: &codebuild.Project{
+			Arn: aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"),
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    cwlogsSvcMock,
+	}
+
+	_, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+
+	// the live project is built from what was updated
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Arn:         aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"),
+				Name:        updated.Name,
+				Environment: updated.Environment,
+				Source:      updated.Source,
+				ServiceRole: updated.ServiceRole,
+				LogsConfig:  updated.LogsConfig,
+				Tags:        updated.Tags,
+			},
+		},
+	}, nil)
+
+	got, err := cbl.DescribeDefinition(&DescribeDefinitionParams{ProjectName: "testing-1"})
+	require.Nil(t, err)
+	require.Equal(t, "arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1", got.ID)
+	require.Equal(t, "/aws/codebuild/testing-1", got.CloudwatchLogGroupName)
+	require.Equal(t, dp, got.Definition)
+}
+
+func TestLauncher_GetTaskLogs_S3(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	s3SvcMock := &awsmocks.S3API{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				Logs: &codebuild.LogsLocation{
+					CloudWatchLogs: &codebuild.CloudWatchLogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeDisabled)},
+					S3Logs:         &codebuild.S3LogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeEnabled)},
+					S3LogsArn:      aws.String("arn:aws:s3:::build-logs-bucket/testing-1/b17dddde-97c6-4592-b7be-216524f8422b.gz"),
+				},
+			},
+		},
+	}, nil)
+
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	_, err := gw.Write([]byte("first\nsecond\nthird\n"))
+	require.Nil(t, err)
+	require.Nil(t, gw.Close())
+
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.gz"),
+	}).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader(buf.Bytes())),
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		s3Svc:        s3SvcMock,
+	}
+
+	got, err := cbl.GetTaskLogs(&GetTaskLogsParams{
+		ID:        "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+		NextToken: aws.String("6:1"),
+	})
+	require.Nil(t, err)
+	require.Len(t, got.LogLines, 2)
+	require.Equal(t, "second", got.LogLines[0].Message)
+	require.Equal(t, "b17dddde-97c6-4592-b7be-216524f8422b", got.LogLines[0].TaskID)
+	require.Equal(t, "19:3", aws.StringValue(got.NextToken))
+}
+
+func TestLauncher_GetTaskLogs_S3_Range(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	s3SvcMock := &awsmocks.S3API{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				Logs: &codebuild.LogsLocation{
+					S3Logs:    &codebuild.S3LogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeEnabled)},
+					S3LogsArn: aws.String("arn:aws-cn:s3:::build-logs-bucket/testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+				},
+			},
+		},
+	}, nil)
+
+	// only the bytes after the offset are requested
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+		Range:  aws.String("bytes=6-"),
+	}).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader("second\r\nthird")),
+	}, nil)
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+		Range:  aws.String("bytes=19-"),
+	}).Return(nil, awserr.New("InvalidRange", "The requested range is not satisfiable", nil))
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		s3Svc:        s3SvcMock,
+	}
+
+	got, err := cbl.GetTaskLogs(&GetTaskLogsParams{
+		ID:        "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+		NextToken: aws.String("6:1"),
+	})
+	require.Nil(t, err)
+	require.Len(t, got.LogLines, 2)
+	require.Equal(t, "second", got.LogLines[0].Message)
+	require.Equal(t, "third", got.LogLines[1].Message)
+	require.Equal(t, "19:3", aws.StringValue(got.NextToken))
+
+	got, err = cbl.GetTaskLogs(&GetTaskLogsParams{
+		ID:        "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+		NextToken: got.NextToken,
+	})
+	require.Nil(t, err)
+	require.Len(t, got.LogLines, 0)
+	require.Equal(t, "19:3", aws.StringValue(got.NextToken))
+}
+
+func TestLauncher_GetTaskLogs_NotAvailable(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id: aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				Logs: &codebuild.LogsLocation{
+					CloudWatchLogs: &codebuild.CloudWatchLogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeDisabled)},
+				},
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	_, err := cbl.GetTaskLogs(&GetTaskLogsParams{ID: "testing-1:b17dddde-97c6-4592-b7be-216524f8422b"})
+	require.Equal(t, launcher.ErrLogsNotAvailable, err)
+}
+
+func TestLauncher_RunTask(t *testing.T) {
+
+	buildID := "testing-1:b17dddde-97c6-4592-b7be-216524f8422b"
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(&codebuild.StartBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String(buildID),
+			BuildStatus: aws.String(codebuild.StatusTypeInProgress),
+			Arn:         aws.String(codebuildArn),
+		},
+	}, nil)
+
+	getBuildsRes := &codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String(buildID),
+				BuildStatus:   aws.String(codebuild.StatusTypeSucceeded),
+				BuildComplete: aws.Bool(true),
+				Arn:           aws.String(codebuildArn),
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/aws/codebuild/testing-1"),
+					StreamName: aws.String("codebuild/b17dddde-97c6-4592-b7be-216524f8422b"),
+				},
+			},
+		},
+	}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(getBuildsRes, nil)
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:     "/aws/codebuild/testing-1",
+		StreamName:    "codebuild/b17dddde-97c6-4592-b7be-216524f8422b",
+		TaskID:        "b17dddde-97c6-4592-b7be-216524f8422b",
+		StartFromHead: true,
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
+		NextToken: aws.String("f/1"),
+	}, nil)
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/1"),
+	}, nil)
+
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ProjectName: "testing-1",
+		},
+	}
+
+	want := &RunTaskResult{
+		ID:           buildID,
+		TaskStatus:   launcher.TaskSucceeded,
+		BuildArn:     codebuildArn,
+		BuildStatus:  codebuild.StatusTypeSucceeded,
+		LogLineCount: 1,
+		ExitCode:     aws.Int64(0),
+		Handle: (&launcher.TaskHandle{
+			Backend: launcher.BackendCodebuild,
+			Region:  "ap-southeast-2",
+			Project: "testing-1",
+			ID:      buildID,
+		}).Encode(),
+		LogGroupName:  "/aws/codebuild/testing-1",
+		LogStreamName: "codebuild/b17dddde-97c6-4592-b7be-216524f8422b",
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsReader: cwlogsReader,
+	}
+
+	buf := new(bytes.Buffer)
+
+	got, err := cbl.RunTask(rt, cwlogs.NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, "whatever\n", buf.String())
+}
+
+func TestLauncher_RunTask_Retry(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(nil,
+		awserr.New(codebuild.ErrCodeAccountLimitExceededException, "Cannot have more than 60 active builds", nil)).Once()
+
+	buildStatus := map[string]string{
+		"abc1": codebuild.StatusTypeFault,
+		"abc2": codebuild.StatusTypeSucceeded,
+	}
+
+	for _, id := range []string{"abc1", "abc2"} {
+		buildID := "testing-1:" + id
+
+		codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(&codebuild.StartBuildOutput{
+			Build: &codebuild.Build{Id: aws.String(buildID), BuildStatus: aws.String(codebuild.StatusTypeInProgress)},
+		}, nil).Once()
+		codeBuildSvcMock.On("BatchGetBuilds", mock.MatchedBy(func(in *codebuild.BatchGetBuildsInput) bool {
+			return aws.StringValue(in.Ids[0]) == buildID
+		})).Return(&codebuild.BatchGetBuildsOutput{
+			Builds: []*codebuild.Build{
+				{
+					Id:            aws.String(buildID),
+					BuildStatus:   aws.String(buildStatus[id]),
+					BuildComplete: aws.Bool(true),
+					Logs: &codebuild.LogsLocation{
+						GroupName:  aws.String("/aws/codebuild/testing-1"),
+						StreamName: aws.String("codebuild/" + id),
+					},
+				},
+			},
+		}, nil)
+		cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+			GroupName:     "/aws/codebuild/testing-1",
+			StreamName:    "codebuild/" + id,
+			TaskID:        id,
+			StartFromHead: true,
+		}).Return(&cwlogs.ReadLogsResult{
+			LogLines:  []*cwlogs.LogLine{{Message: "output of " + id, TaskID: id}},
+			NextToken: aws.String("f/1"),
+		}, nil)
+	}
+
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/1"),
+	}, nil)
+
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ProjectName: "testing-1",
+		},
+		RetryPolicy: &launcher.RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+			Statuses:    []string{launcher.StatusLaunchFailed, codebuild.StatusTypeFault},
+		},
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsReader: cwlogsReader,
+		pollerConfig: &launcher.PollerConfig{MinInterval: time.Millisecond},
+	}
+
+	buf := new(bytes.Buffer)
+
+	got, err := cbl.RunTask(rt, cwlogs.NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Equal(t, "testing-1:abc2", got.ID)
+	require.Equal(t, launcher.TaskSucceeded, got.TaskStatus)
+	require.Len(t, got.Attempts, 3)
+
+	require.Equal(t, launcher.StatusLaunchFailed, got.Attempts[0].Status)
+	require.Contains(t, got.Attempts[0].Reason, codebuild.ErrCodeAccountLimitExceededException)
+
+	require.Equal(t, "testing-1:abc1", got.Attempts[1].ID)
+	require.Equal(t, codebuild.StatusTypeFault, got.Attempts[1].Status)
+	require.Equal(t, "codebuild/abc1", got.Attempts[1].LogStreamName)
+	require.Equal(t, int64(1), got.Attempts[1].LogLineCount)
+	require.NotEmpty(t, got.Attempts[1].Handle)
+
+	require.Equal(t, "codebuild/abc2", got.Attempts[2].LogStreamName)
+
+	// each retry is preceded by a boundary so the attempts can be told apart
+	require.Equal(t, "--- attempt 2: abc1\noutput of abc1\n--- attempt 3: abc2\noutput of abc2\n", buf.String())
+}
+
+func TestLauncher_RunTask_Retry_LaunchFailed(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(nil,
+		awserr.New(codebuild.ErrCodeAccountLimitExceededException, "Cannot have more than 60 active builds", nil))
+
+	rt := &RunTaskParams{
+		LaunchTaskParams: LaunchTaskParams{
+			ProjectName: "testing-1",
+		},
+		RetryPolicy: &launcher.RetryPolicy{
+			MaxAttempts: 2,
+			Backoff:     time.Millisecond,
+			Statuses:    []string{launcher.StatusLaunchFailed},
+		},
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+	}
+
+	got, err := cbl.RunTask(rt)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to start build.")
+	require.Equal(t, launcher.TaskFailed, got.TaskStatus)
+	require.Len(t, got.Attempts, 2)
+	codeBuildSvcMock.AssertNumberOfCalls(t, "StartBuild", 2)
+
+	// without a retry policy the launch error is returned on its own
+	rt.RetryPolicy = nil
+
+	got, err = cbl.RunTask(rt)
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestLauncher_RunTask_Interrupted(t *testing.T) {
+
+	buildID := "testing-1:b17dddde-97c6-4592-b7be-216524f8422b"
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	codeBuildSvcMock.On("StartBuild", mock.AnythingOfType("*codebuild.StartBuildInput")).Return(&codebuild.StartBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String(buildID),
+			BuildStatus: aws.String(codebuild.StatusTypeInProgress),
+			Arn:         aws.String(codebuildArn),
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String(buildID),
+				BuildStatus:   aws.String(codebuild.StatusTypeInProgress),
+				BuildComplete: aws.Bool(false),
+				Arn:           aws.String(codebuildArn),
+				Logs: &codebuild.LogsLocation{
+					GroupName:  aws.String("/aws/codebuild/testing-1"),
+					StreamName: aws.String("codebuild/b17dddde-97c6-4592-b7be-216524f8422b"),
+				},
+			},
+		},
+	}, nil)
+	codeBuildSvcMock.On("StopBuild", &codebuild.StopBuildInput{Id: aws.String(buildID)}).Return(&codebuild.StopBuildOutput{
+		Build: &codebuild.Build{
+			Id:          aws.String(buildID),
+			BuildStatus: aws.String(codebuild.StatusTypeInProgress),
+		},
+	}, nil)
+
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/1"),
+	}, nil)
+
+	interrupted := make(chan struct{})
+	close(interrupted)
+
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ProjectName: "testing-1",
+		},
+		StopOnInterrupt: true,
+		// the build never stops in this test
+		StopTimeout: 10 * time.Millisecond,
+	}
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsReader: cwlogsReader,
+		pollerConfig: &launcher.PollerConfig{MinInterval: time.Millisecond},
+		notifyInterrupt: func() (<-chan struct{}, func()) {
+			return interrupted, func() {}
+		},
+	}
+
+	got, err := cbl.RunTask(rt)
+	require.Equal(t, launcher.ErrInterrupted, err)
+	require.True(t, got.Interrupted)
+	require.Equal(t, buildID, got.ID)
+	require.Equal(t, launcher.TaskRunning, got.TaskStatus)
+
+	codeBuildSvcMock.AssertCalled(t, "StopBuild", &codebuild.StopBuildInput{Id: aws.String(buildID)})
+}
+
+func Test_parseS3Arn(t *testing.T) {
+	bucket, key, err := parseS3Arn("arn:aws-us-gov:s3:::build-logs/testing-1/abc.gz")
+	require.Nil(t, err)
+	require.Equal(t, "build-logs", bucket)
+	require.Equal(t, "testing-1/abc.gz", key)
+
+	_, _, err = parseS3Arn("build-logs/testing-1/abc.gz")
+	require.Error(t, err)
+
+	_, _, err = parseS3Arn("arn:aws:logs:::build-logs/testing-1/abc.gz")
+	require.Error(t, err)
+
+	_, _, err = parseS3Arn("arn:aws:s3:::build-logs")
+	require.Error(t, err)
+}
+
+func TestLauncher_ExportTaskLogs_S3(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	s3SvcMock := &awsmocks.S3API{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				BuildComplete: aws.Bool(true),
+				Logs: &codebuild.LogsLocation{
+					S3Logs:    &codebuild.S3LogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeEnabled)},
+					S3LogsArn: aws.String("arn:aws:s3:::build-logs-bucket/testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+				},
+			},
+		},
+	}, nil)
+
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+	}).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader("first\nsecond\n")),
+	}, nil)
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log"),
+		Range:  aws.String("bytes=13-"),
+	}).Return(nil, awserr.New("InvalidRange", "The requested range is not satisfiable", nil))
+
+	dir, err := ioutil.TempDir("", "export")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		s3Svc:        s3SvcMock,
+	}
+
+	got, err := cbl.ExportTaskLogs(&ExportTaskLogsParams{
+		ID:   "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+		Path: filepath.Join(dir, "build.log"),
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(2), got.Manifest.LineCount)
+	require.Equal(t, "build-logs-bucket", got.Manifest.GroupName)
+	require.Equal(t, "testing-1/b17dddde-97c6-4592-b7be-216524f8422b.log", got.Manifest.StreamName)
+	require.Equal(t, "b17dddde-97c6-4592-b7be-216524f8422b", got.Manifest.TaskID)
+
+	data, err := ioutil.ReadFile(got.Path)
+	require.Nil(t, err)
+	require.Contains(t, string(data), "first")
+	require.Contains(t, string(data), "second")
+}
+
+func TestLauncher_ExportTaskLogs_S3_Gzip(t *testing.T) {
+
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	s3SvcMock := &awsmocks.S3API{}
+
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:b17dddde-97c6-4592-b7be-216524f8422b"),
+				BuildComplete: aws.Bool(true),
+				Logs: &codebuild.LogsLocation{
+					S3Logs:    &codebuild.S3LogsConfig{Status: aws.String(codebuild.LogsConfigStatusTypeEnabled)},
+					S3LogsArn: aws.String("arn:aws:s3:::build-logs-bucket/testing-1/b17dddde-97c6-4592-b7be-216524f8422b.gz"),
+				},
+			},
+		},
+	}, nil)
+
+	lineCount := DefaultS3LogsPageSize*2 + 500
+
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	for i := 0; i < lineCount; i++ {
+		_, err := fmt.Fprintf(gw, "line %d\n", i)
+		require.Nil(t, err)
+	}
+	require.Nil(t, gw.Close())
+
+	// the object spans several pages but is only downloaded and decompressed once
+	s3SvcMock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("build-logs-bucket"),
+		Key:    aws.String("testing-1/b17dddde-97c6-4592-b7be-216524f8422b.gz"),
+	}).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader(buf.Bytes())),
+	}, nil).Once()
+
+	dir, err := ioutil.TempDir("", "export")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cbl := &Launcher{
+		codeBuildSvc: codeBuildSvcMock,
+		s3Svc:        s3SvcMock,
+	}
+
+	got, err := cbl.ExportTaskLogs(&ExportTaskLogsParams{
+		ID:   "testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+		Path: filepath.Join(dir, "build.log"),
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(lineCount), got.Manifest.LineCount)
+	s3SvcMock.AssertNumberOfCalls(t, "GetObject", 1)
+
+	data, err := ioutil.ReadFile(got.Path)
+	require.Nil(t, err)
+	require.Contains(t, string(data), fmt.Sprintf("line %d\n", lineCount-1))
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
 )
 
 const (
@@ -18,17 +19,56 @@ const (
 
 	// ECSLogGroupFormat the name format for ECS cloudwatch log group names
 	ECSLogGroupFormat = "/aws/fargate/%s"
+
+	// MaxRunTaskCount the most tasks which can be started by a single call to run task
+	MaxRunTaskCount = 10
+
+	// DefaultStopTaskReason the reason recorded when a task is stopped without one
+	DefaultStopTaskReason = "request stop task"
+
+	// FireLensContainerName the name of the log router container added when using the awsfirelens driver
+	FireLensContainerName = "log_router"
+
+	// FireLensStreamPrefix the prefix used in the cloudwatch log stream name of the log router container
+	FireLensStreamPrefix = "firelens"
+
+	// TaskStopCodeSpotInterruption stop code of a task running on Fargate Spot capacity which was reclaimed,
+	// this isn't defined by the version of the SDK in use
+	TaskStopCodeSpotInterruption = "SpotInterruption"
+
+	// CapacityProviderFargate the capacity provider for fargate tasks
+	CapacityProviderFargate = "FARGATE"
+	// CapacityProviderFargateSpot the capacity provider for fargate tasks running on spare capacity
+	CapacityProviderFargateSpot = "FARGATE_SPOT"
 )
 
 // LauncherAPI build the definition, then launch a container based task
 type LauncherAPI interface {
 	DefineTask(*DefineTaskParams) (*DefineTaskResult, error)
+	ListDefinitions(*ListDefinitionsParams) (*ListDefinitionsResult, error)
+	DescribeDefinition(*DescribeDefinitionParams) (*DescribeDefinitionResult, error)
 	LaunchTask(*LaunchTaskParams) (*LaunchTaskResult, error)
 	GetTaskStatus(*GetTaskStatusParams) (*GetTaskStatusResult, error)
+	GetTasksStatus(*GetTasksStatusParams) (*GetTasksStatusResult, error)
 	WaitForTask(*WaitForTaskParams) (*WaitForTaskResult, error)
+	WaitForTasks(*WaitForTasksParams) (*WaitForTasksResult, error)
+	WatchTask(*WatchTaskParams) (*WatchTaskResult, error)
 	StopTask(*StopTaskParams) (*StopTaskResult, error)
+	StopTasks(*StopTasksParams) (*StopTasksResult, error)
 	CleanupTask(*CleanupTaskParams) (*CleanupTaskResult, error)
+	ListTasks(*ListTasksParams) (*ListTasksResult, error)
 	GetTaskLogs(*GetTaskLogsParams) (*GetTaskLogsResult, error)
+	ExportTaskLogs(*ExportTaskLogsParams) (*ExportTaskLogsResult, error)
+	RenewLease(*RenewLeaseParams) (*RenewLeaseResult, error)
+	RunTask(*RunTaskParams, ...cwlogs.LogSink) (*RunTaskResult, error)
+}
+
+// LauncherOptions options used when creating a launcher
+type LauncherOptions struct {
+	// optional, updates published to the feed, such as task state change events, are passed to the launcher's poller
+	StatusFeed *launcher.StatusFeed
+	// optional, tune the poller shared by the tasks waiting on the launcher
+	Poller *launcher.PollerConfig
 }
 
 // DefineTaskParams parameters used to build a container execution environment for Codebuild
@@ -42,6 +82,36 @@ type DefineTaskParams struct {
 	Image       string            `json:"image,omitempty" jsonschema:"required"`
 	Environment map[string]string `json:"environment,omitempty"`
 	Tags        map[string]string `json:"tags,omitempty"`
+
+	// optional, log group naming, retention, encryption and tags
+	LogGroup *cwlogs.LogGroupConfig `json:"log_group,omitempty"`
+
+	// optional, defaults to the awslogs driver writing to the log group
+	LogDriver *LogDriverConfig `json:"log_driver,omitempty"`
+
+	// optional, FARGATE or EC2, defaults to FARGATE
+	LaunchType string `json:"launch_type,omitempty"`
+	// optional, FARGATE tasks only support awsvpc, EC2 tasks also support bridge, host and none, defaults to
+	// awsvpc for FARGATE and bridge for EC2
+	NetworkMode string `json:"network_mode,omitempty"`
+}
+
+// LogDriverConfig log driver used by the task container, logs can only be read by the launcher when using awslogs
+type LogDriverConfig struct {
+	Driver        string            `json:"driver,omitempty" jsonschema:"required"`
+	Options       map[string]string `json:"options,omitempty"`
+	SecretOptions map[string]string `json:"secret_options,omitempty"`
+
+	// required by the awsfirelens driver
+	FireLens *FireLensConfig `json:"firelens,omitempty"`
+}
+
+// FireLensConfig the log router container added to the task when using the awsfirelens driver,
+// the router writes its own logs to the log group using awslogs
+type FireLensConfig struct {
+	Image   string            `json:"image,omitempty" jsonschema:"required"`
+	Type    string            `json:"type,omitempty"` // defaults to fluentbit
+	Options map[string]string `json:"options,omitempty"`
 }
 
 // DefineTaskResult the results from create definition for Codebuild
@@ -51,21 +121,116 @@ type DefineTaskResult struct {
 	CloudwatchStreamPrefix string `json:"cloudwatch_stream_prefix,omitempty"`
 }
 
+// ListDefinitionsParams list the task definition families, or the revisions of a single family
+type ListDefinitionsParams struct {
+	// optional, when supplied the revisions of this family are listed rather than the families
+	Family       string `json:"family,omitempty"`
+	FamilyPrefix string `json:"family_prefix,omitempty"`
+
+	// optional, ACTIVE or INACTIVE, families can also be listed using ALL, defaults to ACTIVE
+	Status string `json:"status,omitempty"`
+
+	NextToken  *string `json:"next_token,omitempty"`
+	MaxResults int64   `json:"max_results,omitempty"`
+}
+
+// ListDefinitionsResult either the families or the revisions of a family
+type ListDefinitionsResult struct {
+	Families    []string            `json:"families,omitempty"`
+	Definitions []*ListedDefinition `json:"definitions,omitempty"`
+	NextToken   *string             `json:"next_token,omitempty"`
+}
+
+// ListedDefinition a revision of a task definition, the ID matches the one returned by define task
+type ListedDefinition struct {
+	ID       string `json:"id,omitempty"`
+	Arn      string `json:"arn,omitempty"`
+	Family   string `json:"family,omitempty"`
+	Revision int64  `json:"revision,omitempty"`
+}
+
+// DescribeDefinitionParams describe a task definition using the family, family:revision or ARN
+type DescribeDefinitionParams struct {
+	TaskDefinition string `json:"task_definition,omitempty" jsonschema:"required"`
+}
+
+// DescribeDefinitionResult the deployed task definition along with the parameters which would define it
+type DescribeDefinitionResult struct {
+	DefineTaskResult
+
+	Arn      string `json:"arn,omitempty"`
+	Revision int64  `json:"revision,omitempty"`
+	Status   string `json:"status,omitempty"`
+
+	Definition *DefineTaskParams `json:"definition,omitempty"`
+}
+
 // LaunchTaskParams used to launch Codebuild container based tasks
 type LaunchTaskParams struct {
-	ClusterName    string   `json:"cluster_name,omitempty" jsonschema:"required"`
-	ServiceName    string   `json:"service_name,omitempty" jsonschema:"required"`
-	ContainerName  string   `json:"container_name,omitempty" jsonschema:"required"`
-	TaskDefinition string   `json:"task_definition,omitempty" jsonschema:"required"`
-	CPU            int64    `json:"cpu,omitempty" jsonschema:"required"`
-	Memory         int64    `json:"memory,omitempty" jsonschema:"required"`
-	Subnets        []string `json:"subnets,omitempty" jsonschema:"required"`
+	ClusterName    string `json:"cluster_name,omitempty" jsonschema:"required"`
+	ServiceName    string `json:"service_name,omitempty" jsonschema:"required"`
+	ContainerName  string `json:"container_name,omitempty" jsonschema:"required"`
+	TaskDefinition string `json:"task_definition,omitempty" jsonschema:"required"`
+	CPU            int64  `json:"cpu,omitempty" jsonschema:"required"`
+	Memory         int64  `json:"memory,omitempty" jsonschema:"required"`
+
+	// required by FARGATE tasks and EC2 tasks whose definition uses the awsvpc network mode
+	Subnets []string `json:"subnets,omitempty"`
+	// optional, awsvpc only, the security groups of the task network interface
+	SecurityGroups []string `json:"security_groups,omitempty"`
+	// optional, FARGATE only, ENABLED or DISABLED, defaults to ENABLED
+	AssignPublicIP string `json:"assign_public_ip,omitempty"`
+
+	// optional, the number of tasks to launch, defaults to 1
+	Count int64 `json:"count,omitempty"`
 
 	Environment map[string]string `json:"environment,omitempty"`
 	Tags        map[string]string `json:"tags,omitempty"`
+
+	// optional, lease the launched tasks to an owner so they are stopped by the reaper if the owner goes away
+	Lease *launcher.LeaseConfig `json:"lease,omitempty"`
+
+	// optional, run the tasks using capacity providers in place of a launch type, either FARGATE and FARGATE_SPOT
+	// or the auto scaling group providers of an EC2 cluster
+	CapacityProviderStrategy []*CapacityProviderStrategyItem `json:"capacity_provider_strategy,omitempty"`
+
+	// optional, FARGATE or EC2, defaults to FARGATE, this must match the launch type of the task definition and
+	// can't be set along with a capacity provider strategy
+	LaunchType string `json:"launch_type,omitempty"`
+	// optional, EC2 only, rules restricting the container instances the tasks are placed on
+	PlacementConstraints []*PlacementConstraint `json:"placement_constraints,omitempty"`
+	// optional, EC2 only, how the tasks are spread over the container instances
+	PlacementStrategy []*PlacementStrategy `json:"placement_strategy,omitempty"`
 }
 
-// LaunchTaskResult summarsied result of the launched task in Codebuild
+// PlacementConstraint a rule restricting the container instances a task can be placed on
+type PlacementConstraint struct {
+	// distinctInstance or memberOf
+	Type string `json:"type,omitempty" jsonschema:"required"`
+	// required by memberOf, a cluster query language expression such as attribute:ecs.instance-type =~ r5.*
+	Expression string `json:"expression,omitempty"`
+}
+
+// PlacementStrategy how tasks are placed on the container instances
+type PlacementStrategy struct {
+	// random, spread or binpack
+	Type string `json:"type,omitempty" jsonschema:"required"`
+	// optional, the field the strategy is applied to such as memory for binpack or instanceId for spread
+	Field string `json:"field,omitempty"`
+}
+
+// CapacityProviderStrategyItem the share of tasks placed using a capacity provider
+type CapacityProviderStrategyItem struct {
+	CapacityProvider string `json:"capacity_provider,omitempty" jsonschema:"required"`
+
+	// optional, the relative share of tasks placed using this capacity provider
+	Weight int64 `json:"weight,omitempty"`
+	// optional, the number of tasks placed using this capacity provider before the weights are applied
+	Base int64 `json:"base,omitempty"`
+}
+
+// LaunchTaskResult summarsied result of the launched task in Codebuild, when more than one task
+// is launched the first task is summarised and all the tasks are listed
 type LaunchTaskResult struct {
 	TaskArn string `json:"task_arn,omitempty"`
 	TaskID  string `json:"task_id,omitempty"`
@@ -74,13 +239,34 @@ type LaunchTaskResult struct {
 	TaskStatus string     `json:"task_status,omitempty"`
 	StartTime  *time.Time `json:"start_time,omitempty"`
 	EndTime    *time.Time `json:"end_time,omitempty"`
+
+	// encoded launcher.TaskHandle of the first task, this can be passed to the other operations in place of the ID
+	Handle string `json:"handle,omitempty"`
+
+	Tasks    []*LaunchedTask         `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+
+	// the number of the requested tasks which weren't launched
+	NotLaunched int64 `json:"not_launched,omitempty"`
+}
+
+// LaunchedTask a task started by launch task
+type LaunchedTask struct {
+	TaskArn    string `json:"task_arn,omitempty"`
+	TaskID     string `json:"task_id,omitempty"`
+	ID         string `json:"id,omitempty"`
+	TaskStatus string `json:"task_status,omitempty"`
+	Handle     string `json:"handle,omitempty"`
 }
 
 // GetTaskStatusParams get status task parameters for Codebuild
 type GetTaskStatusParams struct {
-	ClusterName string `json:"cluster_name,omitempty" jsonschema:"required"`
+	ClusterName string `json:"cluster_name,omitempty"`
 
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the cluster name and ID
+	Handle string `json:"handle,omitempty"`
 }
 
 // GetTaskStatusResult get status task result for Codebuild
@@ -94,50 +280,223 @@ type GetTaskStatusResult struct {
 	TaskStatus string     `json:"task_status,omitempty"`
 	StartTime  *time.Time `json:"start_time,omitempty"`
 	EndTime    *time.Time `json:"end_time,omitempty"`
+	ExitCode   *int64     `json:"exit_code,omitempty"`
+}
+
+// GetTasksStatusParams get the status of many tasks in a cluster
+type GetTasksStatusParams struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+}
+
+// GetTasksStatusResult the status of each task in the order requested, along with the tasks which couldn't be described
+type GetTasksStatusResult struct {
+	Tasks    []*GetTaskStatusResult  `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
 }
 
 // WaitForTaskParams wait for task parameters for Codebuild
 type WaitForTaskParams struct {
-	ClusterName string `json:"cluster_name,omitempty" jsonschema:"required"`
+	ClusterName string `json:"cluster_name,omitempty"`
 
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the cluster name and ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, defaults to launcher.DefaultMaxWait, launcher.ErrWaitTimeout is returned if the task is still running
+	MaxWait time.Duration `json:"max_wait,omitempty"`
 }
 
 // WaitForTaskResult wait for task parameters for Codebuild
 type WaitForTaskResult struct {
+	ID         string `json:"id,omitempty"`
+	TaskStatus string `json:"task_status,omitempty"`
+}
+
+// WaitForTasksParams wait for many tasks in a cluster
+type WaitForTasksParams struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+
+	// optional, defaults to launcher.DefaultMaxWait, tasks still running after this are returned as failures
+	MaxWait time.Duration `json:"max_wait,omitempty"`
+}
+
+// WaitForTasksResult the tasks which completed in the order requested, along with those which couldn't be checked
+type WaitForTasksResult struct {
+	Tasks    []*WaitForTaskResult    `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+}
+
+// WatchTaskParams watch task parameters for ECS
+type WatchTaskParams struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+
 	ID string `json:"id,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the cluster name and ID
+	Handle string `json:"handle,omitempty"`
+}
+
+// WatchTaskResult the events channel is closed once the task reaches a terminal state
+type WatchTaskResult struct {
+	Events <-chan *launcher.StatusChange `json:"-"`
+
+	// stop watching before the task completes, this closes the events channel
+	Stop func() `json:"-"`
 }
 
 // StopTaskParams stop task params for Codebuild
 type StopTaskParams struct {
-	ClusterName string `json:"cluster_name,omitempty" jsonschema:"required"`
-	TaskARN     string `json:"task_arn,omitempty" jsonschema:"required"`
+	// optional, derived from the task ARN when it includes the cluster
+	ClusterName string `json:"cluster_name,omitempty"`
+	// the task ARN, this is the ID returned by launch task
+	ID string `json:"id,omitempty"`
+	// Deprecated: use ID
+	TaskARN string `json:"task_arn,omitempty"`
+	// optional, the handle returned by launch task which replaces the cluster name and ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, recorded as the stopped reason of the task
+	Reason string `json:"reason,omitempty"`
+	// optional, wait for the task to stop, if it doesn't stop within the timeout the stop is sent again
+	Wait bool `json:"wait,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	WaitTimeout time.Duration `json:"wait_timeout,omitempty"`
+	// optional, the container which provides the exit code, defaults to the first container with an exit code
+	ContainerName string `json:"container_name,omitempty"`
 }
 
 // StopTaskResult stop task result for Codebuild
 type StopTaskResult struct {
+	ID         string `json:"id,omitempty"`
 	LastStatus string `json:"last_status,omitempty"`
 	StopCode   string `json:"stop_reason,omitempty"`
 
-	TaskStatus string `json:"task_status,omitempty"`
+	TaskStatus    string     `json:"task_status,omitempty"`
+	StoppedReason string     `json:"stopped_reason,omitempty"`
+	ExitCode      *int64     `json:"exit_code,omitempty"`
+	EndTime       *time.Time `json:"end_time,omitempty"`
+
+	// set when the task didn't stop within the wait timeout
+	TimedOut bool `json:"timed_out,omitempty"`
 }
 
-// CleanupTaskParams cleanup definition params for Codebuild
+// StopTasksParams stop many tasks in a cluster
+type StopTasksParams struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	IDs []string `json:"ids,omitempty"`
+
+	// optional, handles returned by launch task which are resolved and added to the IDs
+	Handles []string `json:"handles,omitempty"`
+
+	// optional, recorded as the stopped reason of each task
+	Reason string `json:"reason,omitempty"`
+	// optional, wait for each task to stop, if it doesn't stop within the timeout the stop is sent again
+	Wait bool `json:"wait,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	WaitTimeout time.Duration `json:"wait_timeout,omitempty"`
+	// optional, the container which provides the exit code, defaults to the first container with an exit code
+	ContainerName string `json:"container_name,omitempty"`
+}
+
+// StopTasksResult the tasks which were stopped in the order requested, along with those which couldn't be stopped
+type StopTasksResult struct {
+	Tasks    []*StopTaskResult       `json:"tasks,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+}
+
+// CleanupTaskParams cleanup definition params for ECS, cleanup is refused while tasks from the family are running
 type CleanupTaskParams struct {
 	TaskDefinition string `json:"task_definition,omitempty" jsonschema:"required"`
+
+	// optional, deregister every active revision of the family rather than just the one supplied
+	AllRevisions bool `json:"all_revisions,omitempty"`
+
+	// optional, deregister all but the latest revisions of the family
+	KeepLatest int64 `json:"keep_latest,omitempty"`
+
+	// optional, delete the log group, this is skipped while any revisions are kept
+	DeleteLogGroup bool `json:"delete_log_group,omitempty"`
+
+	// optional, the cluster checked for running tasks, defaults to all clusters
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	// optional, cleanup even if tasks are running
+	Force bool `json:"force,omitempty"`
 }
 
-// CleanupTaskResult cleanup definition result for Codebuild
+// CleanupTaskResult the revisions and log group which were removed
 type CleanupTaskResult struct {
+	Deregistered    []string `json:"deregistered,omitempty"`
+	Kept            []string `json:"kept,omitempty"`
+	DeletedLogGroup string   `json:"deleted_log_group,omitempty"`
+}
+
+// ListTasksParams list the tasks in a cluster, optionally for a single task definition family
+type ListTasksParams struct {
+	ClusterName string `json:"cluster_name,omitempty" jsonschema:"required"`
+	Family      string `json:"family,omitempty"`
+
+	// optional, either RUNNING or STOPPED, defaults to RUNNING
+	DesiredStatus string `json:"desired_status,omitempty"`
+
+	launcher.TaskFilter
+
+	NextToken  *string `json:"next_token,omitempty"`
+	MaxResults int64   `json:"max_results,omitempty"`
+}
+
+// ListTasksResult the tasks in a page which match the filter, there may be more pages when next token is set
+type ListTasksResult struct {
+	Tasks     []*ListedTask `json:"tasks,omitempty"`
+	NextToken *string       `json:"next_token,omitempty"`
+}
+
+// ListedTask summary of a task returned by list tasks
+type ListedTask struct {
+	TaskArn    string `json:"task_arn,omitempty"`
+	TaskID     string `json:"task_id,omitempty"`
+	Definition string `json:"definition,omitempty"`
+	LastStatus string `json:"last_status,omitempty"`
+	StopCode   string `json:"stop_reason,omitempty"`
+
+	ID         string            `json:"id,omitempty"`
+	TaskStatus string            `json:"task_status,omitempty"`
+	StartTime  *time.Time        `json:"start_time,omitempty"`
+	EndTime    *time.Time        `json:"end_time,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
 }
 
 // GetTaskLogsParams get logs task params for Codebuild
 type GetTaskLogsParams struct {
-	DefinitionName string `json:"definition_name,omitempty" jsonschema:"required"`
-	TaskARN        string `json:"task_arn,omitempty" jsonschema:"required"`
+	// optional, the cluster of the task when the ID isn't an ARN
+	ClusterName string `json:"cluster_name,omitempty"`
 
-	TaskID    string  `json:"task_id,omitempty" jsonschema:"required"`
+	ID        string  `json:"id,omitempty"`
 	NextToken *string `json:"next_token,omitempty"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, the container to read the logs of, defaults to the first container using awslogs
+	ContainerName string `json:"container_name,omitempty"`
+
+	// optional, read from the start of the stream when no next token is supplied
+	StartFromHead bool `json:"start_from_head,omitempty"`
+
+	// optional, when supplied each log message is parsed as JSON using this field mapping
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
 }
 
 // GetTaskLogsResult get logs task result for Codebuild
@@ -145,3 +504,97 @@ type GetTaskLogsResult struct {
 	LogLines  []*cwlogs.LogLine `json:"log_lines,omitempty"`
 	NextToken *string           `json:"next_token,omitempty"`
 }
+
+// ExportTaskLogsParams export the complete logs of a task to a local file for ECS
+type ExportTaskLogsParams struct {
+	// optional, the cluster of the task when the ID isn't an ARN
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	ID   string `json:"id,omitempty"`
+	Path string `json:"path,omitempty" jsonschema:"required"`
+
+	// optional, the handle returned by launch task which replaces the ID
+	Handle string `json:"handle,omitempty"`
+
+	// optional, the container to export the logs of, defaults to the first container using awslogs
+	ContainerName string `json:"container_name,omitempty"`
+
+	// optional, one of text, jsonl or gzip, defaults to text
+	Format       string `json:"format,omitempty"`
+	TimeFormat   string `json:"time_format,omitempty"`
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
+}
+
+// ExportTaskLogsResult export task logs result for ECS
+type ExportTaskLogsResult struct {
+	Path         string `json:"path,omitempty"`
+	ManifestPath string `json:"manifest_path,omitempty"`
+
+	Manifest *cwlogs.ExportManifest `json:"manifest,omitempty"`
+}
+
+// RenewLeaseParams extend the lease on the tasks, the IDs must be task ARNs
+type RenewLeaseParams struct {
+	IDs   []string              `json:"ids,omitempty" jsonschema:"required"`
+	Lease *launcher.LeaseConfig `json:"lease,omitempty" jsonschema:"required"`
+
+	// optional, defaults to the lease duration from now
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// RenewLeaseResult the new expiry along with the tasks which couldn't be tagged
+type RenewLeaseResult struct {
+	Expires  time.Time               `json:"expires,omitempty"`
+	Failures []*launcher.TaskFailure `json:"failures,omitempty"`
+}
+
+// RunTaskParams launch a task, follow the logs and wait for it to complete
+type RunTaskParams struct {
+	LaunchTaskParams
+
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
+	PollInterval time.Duration        `json:"poll_interval,omitempty"`
+
+	// optional, how long the logs must be quiet once the task completes, defaults to cwlogs.DefaultDrainQuietPeriod
+	DrainQuietPeriod time.Duration `json:"drain_quiet_period,omitempty"`
+
+	// optional, stop the tasks when the process receives SIGINT or SIGTERM, the run then returns its result along with
+	// launcher.ErrInterrupted, a second signal terminates the process without waiting for the tasks to stop
+	StopOnInterrupt bool `json:"stop_on_interrupt,omitempty"`
+	// optional, defaults to launcher.DefaultStopReason
+	StopReason string `json:"stop_reason,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	StopTimeout time.Duration `json:"stop_timeout,omitempty"`
+
+	// optional, launch the task again when it fails for one of the retryable reasons
+	RetryPolicy *launcher.RetryPolicy `json:"retry_policy,omitempty"`
+}
+
+// RunTaskResult the final status of the task after it has completed
+type RunTaskResult struct {
+	TaskArn    string `json:"task_arn,omitempty"`
+	TaskID     string `json:"task_id,omitempty"`
+	LastStatus string `json:"last_status,omitempty"`
+	StopCode   string `json:"stop_reason,omitempty"`
+
+	ID           string     `json:"id,omitempty"`
+	TaskStatus   string     `json:"task_status,omitempty"`
+	StartTime    *time.Time `json:"start_time,omitempty"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	LogLineCount int64      `json:"log_line_count,omitempty"`
+
+	// the handle of the task and the log stream its logs were read from
+	Handle        string `json:"handle,omitempty"`
+	LogGroupName  string `json:"log_group_name,omitempty"`
+	LogStreamName string `json:"log_stream_name,omitempty"`
+
+	ExitCode *int64 `json:"exit_code,omitempty"`
+
+	// set when the task was stopped after the run was interrupted
+	Interrupted bool `json:"interrupted,omitempty"`
+
+	// every attempt at running the task, the other fields describe the last attempt which launched a task
+	Attempts []*launcher.RunAttempt `json:"attempts,omitempty"`
+}
@@ -2,10 +2,12 @@ package ecs
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
@@ -22,64 +24,97 @@ type Launcher struct {
 	ecsSvc       ecsiface.ECSAPI
 	cwlogsSvc    cloudwatchlogsiface.CloudWatchLogsAPI
 	cwlogsReader cwlogs.LogsReader
+
+	// log locations discovered for each task
+	logLocations sync.Map
+
+	// shared by all the tasks waiting on this launcher
+	pollerOnce   sync.Once
+	poller       *launcher.Poller
+	pollerConfig *launcher.PollerConfig
+	statusFeed   *launcher.StatusFeed
+
+	// handles launched in another region are refused, empty skips the check
+	region string
+
+	// optional, defaults to launcher.NotifyInterrupt, replaced in tests so a run can be interrupted without a signal
+	notifyInterrupt launcher.NotifyFunc
 }
 
 // NewLauncher create a new launcher
 func NewLauncher(cfgs ...*aws.Config) LauncherAPI {
+	return NewLauncherWithOptions(&LauncherOptions{}, cfgs...)
+}
+
+// NewLauncherWithOptions create a new launcher using the options
+func NewLauncherWithOptions(opts *LauncherOptions, cfgs ...*aws.Config) LauncherAPI {
 	sess := session.Must(session.NewSession(cfgs...))
 	return &Launcher{
 		ecsSvc:       ecs.New(sess),
 		cwlogsSvc:    cloudwatchlogs.New(sess),
-		cwlogsReader: cwlogs.NewCloudwatchLogsReader(cfgs...),
+		cwlogsReader: cwlogs.NewCloudwatchLogsReaderWithOptions(&cwlogs.ReaderOptions{RateLimiter: cwlogs.DefaultRateLimiter}, cfgs...),
+		pollerConfig: opts.Poller,
+		statusFeed:   opts.StatusFeed,
+		region:       aws.StringValue(sess.Config.Region),
 	}
 }
 
 // DefineTask create a container task definition
 func (lc *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error) {
 
-	logGroupName := fmt.Sprintf(ECSLogGroupFormat, dp.DefinitionName)
+	err := validateLogDriver(dp.LogDriver)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dp.LogGroup.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	launchType, networkMode, err := definitionLaunchType(dp)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := lc.cwlogsSvc.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
-		LogGroupName: aws.String(logGroupName),
-		Tags: map[string]*string{
-			"createdBy": aws.String("fargate-run-job"),
+	logGroupName := dp.LogGroup.LogGroupName(ECSLogGroupFormat, dp.DefinitionName)
+
+	containerDefinitions := []*ecs.ContainerDefinition{
+		{
+			Name:             aws.String(dp.ContainerName),
+			Image:            aws.String(dp.Image),
+			LogConfiguration: buildLogConfiguration(dp, logGroupName),
+			Environment:      convertMapToKeyValuePair(dp.Environment),
 		},
-	})
-	if err, ok := err.(awserr.Error); ok {
-		if err.Code() != "ResourceAlreadyExistsException" {
-			return nil, errors.Wrap(err, "create log group failed.")
-		}
+	}
+
+	if dp.LogDriver != nil && dp.LogDriver.FireLens != nil {
+		containerDefinitions = append(containerDefinitions, buildFireLensContainer(dp, logGroupName))
+	}
 
-		logrus.WithField("name", logGroupName).Info("cloudwatch log group exists")
+	// the log group is only needed when a container is using awslogs
+	if !usesAwslogs(containerDefinitions) {
+		logGroupName = ""
+	} else {
+		err = cwlogs.EnsureLogGroup(lc.cwlogsSvc, dp.LogGroup.EnsureLogGroupParams(logGroupName, launcher.OwnershipTags(nil)))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// register the task definition with default base memory, cpu and cwlogs groups
 	res, err := lc.ecsSvc.RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
 		RequiresCompatibilities: aws.StringSlice([]string{
-			"FARGATE",
+			launchType,
 		}),
-		Family:      aws.String(dp.DefinitionName),
-		TaskRoleArn: dp.TaskRoleARN,
-		NetworkMode: aws.String(ecs.NetworkModeAwsvpc),
-		Cpu:         aws.String(DefaultCPU),
-		Memory:      aws.String(DefaultMemory),
-		ContainerDefinitions: []*ecs.ContainerDefinition{
-			{
-				Name:  aws.String(dp.ContainerName),
-				Image: aws.String(dp.Image),
-				LogConfiguration: &ecs.LogConfiguration{
-					LogDriver: aws.String(ecs.LogDriverAwslogs),
-					Options: map[string]*string{
-						"awslogs-group":         aws.String(logGroupName),
-						"awslogs-region":        aws.String(dp.Region),
-						"awslogs-stream-prefix": aws.String(ECSStreamPrefix),
-					},
-				},
-				Environment: convertMapToKeyValuePair(dp.Environment),
-			},
-		},
-		ExecutionRoleArn: aws.String(dp.ExecutionRoleARN),
-		Tags:             convertMapToECSTags(dp.Tags),
+		Family:               aws.String(dp.DefinitionName),
+		TaskRoleArn:          dp.TaskRoleARN,
+		NetworkMode:          aws.String(networkMode),
+		Cpu:                  aws.String(DefaultCPU),
+		Memory:               aws.String(DefaultMemory),
+		ContainerDefinitions: containerDefinitions,
+		ExecutionRoleArn:     aws.String(dp.ExecutionRoleARN),
+		Tags:                 convertMapToECSTags(definitionTags(dp)),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to register task definition.")
@@ -87,11 +122,123 @@ func (lc *Launcher) DefineTask(dp *DefineTaskParams) (*DefineTaskResult, error)
 
 	logrus.WithField("result", res).Debug("Register Task Definition")
 
-	return &DefineTaskResult{
+	defRes := &DefineTaskResult{
 		ID:                     fmt.Sprintf("%s:%d", aws.StringValue(res.TaskDefinition.Family), aws.Int64Value(res.TaskDefinition.Revision)),
 		CloudwatchLogGroupName: logGroupName,
-		CloudwatchStreamPrefix: "ecs",
-	}, nil
+	}
+
+	if aws.StringValue(containerDefinitions[0].LogConfiguration.LogDriver) == ecs.LogDriverAwslogs {
+		defRes.CloudwatchStreamPrefix = ECSStreamPrefix
+	}
+
+	return defRes, nil
+}
+
+// ListDefinitions list the task definition families, or the revisions of a family when one is supplied
+func (lc *Launcher) ListDefinitions(ldp *ListDefinitionsParams) (*ListDefinitionsResult, error) {
+
+	status := ldp.Status
+	if status == "" {
+		status = ecs.TaskDefinitionStatusActive
+	}
+
+	var maxResults *int64
+	if ldp.MaxResults > 0 {
+		maxResults = aws.Int64(ldp.MaxResults)
+	}
+
+	if ldp.Family == "" {
+		listInput := &ecs.ListTaskDefinitionFamiliesInput{
+			Status:     aws.String(status),
+			NextToken:  ldp.NextToken,
+			MaxResults: maxResults,
+		}
+
+		if ldp.FamilyPrefix != "" {
+			listInput.FamilyPrefix = aws.String(ldp.FamilyPrefix)
+		}
+
+		listRes, err := lc.ecsSvc.ListTaskDefinitionFamilies(listInput)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list task definition families.")
+		}
+
+		return &ListDefinitionsResult{
+			Families:  aws.StringValueSlice(listRes.Families),
+			NextToken: listRes.NextToken,
+		}, nil
+	}
+
+	listRes, err := lc.ecsSvc.ListTaskDefinitions(&ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String(ldp.Family),
+		Status:       aws.String(status),
+		Sort:         aws.String(ecs.SortOrderDesc),
+		NextToken:    ldp.NextToken,
+		MaxResults:   maxResults,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list task definitions.")
+	}
+
+	res := &ListDefinitionsResult{NextToken: listRes.NextToken}
+
+	for _, arn := range listRes.TaskDefinitionArns {
+		family, revision := parseTaskDefinitionArn(aws.StringValue(arn))
+
+		res.Definitions = append(res.Definitions, &ListedDefinition{
+			ID:       fmt.Sprintf("%s:%d", family, revision),
+			Arn:      aws.StringValue(arn),
+			Family:   family,
+			Revision: revision,
+		})
+	}
+
+	return res, nil
+}
+
+// DescribeDefinition describe the task definition and convert it back into the parameters which would define it
+func (lc *Launcher) DescribeDefinition(ddp *DescribeDefinitionParams) (*DescribeDefinitionResult, error) {
+
+	descRes, err := lc.ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(ddp.TaskDefinition),
+		Include:        []*string{aws.String(ecs.TaskDefinitionFieldTags)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe task definition.")
+	}
+
+	td := descRes.TaskDefinition
+
+	dp, logGroupName, err := newDefineTaskParams(td, descRes.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if logGroupName != "" {
+		nameFormat := launcher.LogGroupFormat(convertECSTagsToMap(descRes.Tags))
+
+		dp.LogGroup, err = cwlogs.DescribeLogGroupConfig(lc.cwlogsSvc, logGroupName, nameFormat, launcher.OwnershipTags(nil))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &DescribeDefinitionResult{
+		DefineTaskResult: DefineTaskResult{
+			ID:                     fmt.Sprintf("%s:%d", aws.StringValue(td.Family), aws.Int64Value(td.Revision)),
+			CloudwatchLogGroupName: logGroupName,
+		},
+		Arn:        aws.StringValue(td.TaskDefinitionArn),
+		Revision:   aws.Int64Value(td.Revision),
+		Status:     aws.StringValue(td.Status),
+		Definition: dp,
+	}
+
+	if dp.LogDriver == nil || dp.LogDriver.Driver == ecs.LogDriverAwslogs {
+		res.CloudwatchStreamPrefix = ECSStreamPrefix
+	}
+
+	return res, nil
 }
 
 // LaunchTask run a container task
@@ -102,77 +249,169 @@ func (lc *Launcher) LaunchTask(lp *LaunchTaskParams) (*LaunchTaskResult, error)
 		"TaskDefinition": lp.TaskDefinition,
 	}).Info("Launch Task")
 
-	runRes, err := lc.ecsSvc.RunTask(&ecs.RunTaskInput{
-		Cluster:        aws.String(lp.ClusterName),
-		LaunchType:     aws.String(ecs.LaunchTypeFargate),
-		TaskDefinition: aws.String(lp.TaskDefinition),
-		Count:          aws.Int64(1),
-		Overrides: &ecs.TaskOverride{
-			ContainerOverrides: []*ecs.ContainerOverride{
-				{
-					Cpu:         aws.Int64(lp.CPU),
-					Memory:      aws.Int64(lp.Memory),
-					Name:        aws.String(lp.ContainerName),
-					Environment: convertMapToKeyValuePair(lp.Environment),
-				},
-			},
-		},
-		PlatformVersion: aws.String("LATEST"),
-		NetworkConfiguration: &ecs.NetworkConfiguration{
-			AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
-				AssignPublicIp: aws.String(ecs.AssignPublicIpEnabled),
-				Subnets:        aws.StringSlice(lp.Subnets),
-			},
-		},
-		Tags: convertMapToECSTags(lp.Tags),
-	})
+	mode, err := validateLaunchTaskParams(lp)
+	if err != nil {
+		return nil, err
+	}
+
+	networkConfig, err := lc.networkConfiguration(lp, mode)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create task.")
+		return nil, err
 	}
 
-	task := runRes.Tasks[0]
+	count := lp.Count
+	if count <= 0 {
+		count = 1
+	}
 
-	logrus.WithFields(logrus.Fields{
-		"TaskID": shortenTaskArn(task.TaskArn),
-	}).Info("Task Provisioned")
+	taskRes := &LaunchTaskResult{}
 
-	taskRes := &LaunchTaskResult{
-		ID:         aws.StringValue(task.TaskArn),
-		TaskStatus: launcher.TaskRunning,
-		TaskArn:    aws.StringValue(task.TaskArn),
-		TaskID:     shortenTaskArn(task.TaskArn),
+	// each call to run task can only start a limited number of tasks
+	for remaining := count; remaining > 0; {
+		n := remaining
+		if n > MaxRunTaskCount {
+			n = MaxRunTaskCount
+		}
+		remaining -= n
+
+		runRes, err := lc.ecsSvc.RunTask(runTaskInput(lp, mode, networkConfig, n))
+		if err != nil {
+			if len(taskRes.Tasks) == 0 && len(taskRes.Failures) == 0 {
+				return nil, errors.Wrap(err, "failed to create task.")
+			}
+
+			// the remaining batches aren't attempted, they are included in the not launched count
+			taskRes.Failures = append(taskRes.Failures, &launcher.TaskFailure{Reason: err.Error()})
+			break
+		}
+
+		for _, task := range runRes.Tasks {
+			logrus.WithFields(logrus.Fields{
+				"TaskID": shortenTaskArn(task.TaskArn),
+			}).Info("Task Provisioned")
+
+			taskRes.Tasks = append(taskRes.Tasks, &LaunchedTask{
+				ID:         aws.StringValue(task.TaskArn),
+				TaskStatus: launcher.TaskRunning,
+				TaskArn:    aws.StringValue(task.TaskArn),
+				TaskID:     shortenTaskArn(task.TaskArn),
+				Handle:     taskHandle(lp, task).Encode(),
+			})
+		}
+
+		for _, failure := range runRes.Failures {
+			taskRes.Failures = append(taskRes.Failures, &launcher.TaskFailure{
+				ID:     aws.StringValue(failure.Arn),
+				Reason: aws.StringValue(failure.Reason),
+			})
+		}
+	}
+
+	taskRes.NotLaunched = count - int64(len(taskRes.Tasks))
+
+	// the result is returned with the error so the failures of each batch aren't lost
+	if len(taskRes.Tasks) == 0 {
+		reason := "no tasks returned"
+		if len(taskRes.Failures) > 0 {
+			reason = taskRes.Failures[0].Reason
+		}
+		return taskRes, errors.Errorf("failed to place task: %s", reason)
 	}
 
+	task := taskRes.Tasks[0]
+
+	taskRes.ID = task.ID
+	taskRes.TaskStatus = task.TaskStatus
+	taskRes.TaskArn = task.TaskArn
+	taskRes.TaskID = task.TaskID
+	taskRes.Handle = task.Handle
+
 	return taskRes, nil
 }
 
-// WaitForTask wait for task to complete
+// WaitForTask wait for task to complete, the status of all waiting tasks is polled in batches
 func (lc *Launcher) WaitForTask(wft *WaitForTaskParams) (*WaitForTaskResult, error) {
 
-	descInput := &ecs.DescribeTasksInput{
-		Cluster: aws.String(wft.ClusterName),
-		Tasks:   []*string{aws.String(wft.ID)},
+	clusterName, id, err := lc.resolveHandle(wft.Handle, wft.ClusterName, wft.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWait := wft.MaxWait
+	if maxWait == 0 {
+		maxWait = launcher.DefaultMaxWait
+	}
+
+	su, err := lc.statusPoller().WaitTimeout(clusterName, id, maxWait)
+	if err == launcher.ErrWaitTimeout {
+		return nil, err
 	}
 
-	err := lc.ecsSvc.WaitUntilTasksStopped(descInput)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to check stopped task.")
 	}
 
-	return &WaitForTaskResult{ID: wft.ID}, nil
+	return &WaitForTaskResult{ID: id, TaskStatus: su.TaskStatus}, nil
+}
+
+// WaitForTasks wait for all the tasks to complete, tasks which can't be described are returned as failures
+func (lc *Launcher) WaitForTasks(wft *WaitForTasksParams) (*WaitForTasksResult, error) {
+
+	clusterName, ids, err := lc.resolveHandles(wft.Handles, wft.ClusterName, wft.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWait := wft.MaxWait
+	if maxWait == 0 {
+		maxWait = launcher.DefaultMaxWait
+	}
+
+	updates, failures := lc.statusPoller().WaitAll(clusterName, ids, maxWait)
+
+	res := &WaitForTasksResult{Failures: failures}
+
+	for _, su := range updates {
+		res.Tasks = append(res.Tasks, &WaitForTaskResult{ID: su.ID, TaskStatus: su.TaskStatus})
+	}
+
+	return res, nil
+}
+
+// WatchTask send each change in the status of the task to the events channel until it stops
+func (lc *Launcher) WatchTask(wtp *WatchTaskParams) (*WatchTaskResult, error) {
+
+	clusterName, id, err := lc.resolveHandle(wtp.Handle, wtp.ClusterName, wtp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, stop := launcher.Watch(lc.statusPoller(), clusterName, id)
+
+	return &WatchTaskResult{Events: events, Stop: stop}, nil
 }
 
 // GetTaskStatus get task status
 func (lc *Launcher) GetTaskStatus(gts *GetTaskStatusParams) (*GetTaskStatusResult, error) {
+
+	clusterName, id, err := lc.resolveHandle(gts.Handle, gts.ClusterName, gts.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	descInput := &ecs.DescribeTasksInput{
-		Cluster: aws.String(gts.ClusterName),
-		Tasks:   []*string{aws.String(gts.ID)},
+		Cluster: aws.String(clusterName),
+		Tasks:   []*string{aws.String(id)},
 	}
 	descRes, err := lc.ecsSvc.DescribeTasks(descInput)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to describe task.")
 	}
 
+	if len(descRes.Tasks) == 0 {
+		return nil, errors.Errorf("task not found: %s", id)
+	}
+
 	task := descRes.Tasks[0]
 
 	logrus.WithFields(logrus.Fields{
@@ -181,123 +420,1695 @@ func (lc *Launcher) GetTaskStatus(gts *GetTaskStatusParams) (*GetTaskStatusResul
 		"StoppedReason": aws.StringValue(task.StoppedReason),
 	}).Info("Describe completed Task")
 
-	taskRes := &GetTaskStatusResult{
-		ID:         aws.StringValue(task.TaskArn),
-		StartTime:  task.StartedAt,
-		EndTime:    task.StoppedAt,
-		TaskStatus: convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
-		TaskArn:    aws.StringValue(task.TaskArn),
-		TaskID:     shortenTaskArn(task.TaskArn),
-		LastStatus: aws.StringValue(task.LastStatus),
-		StopCode:   aws.StringValue(task.StopCode),
+	res := newGetTaskStatusResult(task)
+
+	if res.TaskStatus != launcher.TaskRunning {
+		lc.forgetLogLocations(res.TaskArn)
 	}
 
-	return taskRes, nil
+	return res, nil
 }
 
-// StopTask clean up ecs task definition
-func (lc *Launcher) StopTask(stp *StopTaskParams) (*StopTaskResult, error) {
-	res, err := lc.ecsSvc.StopTask(&ecs.StopTaskInput{
-		Cluster: aws.String(stp.ClusterName),
-		Reason:  aws.String("request stop task"),
-		Task:    aws.String(stp.TaskARN),
-	})
+// GetTasksStatus get the status of many tasks in batches, tasks which can't be described are returned as failures
+func (lc *Launcher) GetTasksStatus(gts *GetTasksStatusParams) (*GetTasksStatusResult, error) {
+
+	clusterName, ids, err := lc.resolveHandles(gts.Handles, gts.ClusterName, gts.IDs)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to de-register definition.")
+		return nil, err
 	}
 
-	task := res.Task
+	found := map[string]*ecs.Task{}
+	failed := map[string]string{}
 
-	return &StopTaskResult{
-		LastStatus: aws.StringValue(task.LastStatus),
-		StopCode:   aws.StringValue(task.StopCode),
-		TaskStatus: convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
-	}, nil
+	for _, chunk := range launcher.ChunkIDs(ids, launcher.MaxStatusBatchSize) {
+		descRes, err := lc.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(clusterName),
+			Tasks:   aws.StringSlice(chunk),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe tasks.")
+		}
+
+		// tasks can be requested using either the ARN or the task id
+		for _, task := range descRes.Tasks {
+			found[aws.StringValue(task.TaskArn)] = task
+			found[shortenTaskArn(task.TaskArn)] = task
+		}
+
+		for _, failure := range descRes.Failures {
+			failed[aws.StringValue(failure.Arn)] = aws.StringValue(failure.Reason)
+		}
+	}
+
+	res := &GetTasksStatusResult{}
+
+	for _, id := range ids {
+		if task, ok := found[id]; ok {
+			statusRes := newGetTaskStatusResult(task)
+
+			if statusRes.TaskStatus != launcher.TaskRunning {
+				lc.forgetLogLocations(statusRes.TaskArn)
+			}
+
+			res.Tasks = append(res.Tasks, statusRes)
+			continue
+		}
+
+		reason, ok := failed[id]
+		if !ok {
+			reason = "MISSING"
+		}
+
+		res.Failures = append(res.Failures, &launcher.TaskFailure{ID: id, Reason: reason})
+	}
+
+	return res, nil
 }
 
-// CleanupTask clean up ecs task definition
-func (lc *Launcher) CleanupTask(ctp *CleanupTaskParams) (*CleanupTaskResult, error) {
-	_, err := lc.ecsSvc.DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{
-		TaskDefinition: aws.String(ctp.TaskDefinition),
-	})
+// StopTask stop the task with the reason, optionally waiting for it to stop
+func (lc *Launcher) StopTask(stp *StopTaskParams) (*StopTaskResult, error) {
+
+	id := stp.ID
+	if id == "" {
+		id = stp.TaskARN
+	}
+
+	clusterName, id, err := lc.resolveHandle(stp.Handle, stp.ClusterName, id)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to de-register definition.")
+		return nil, err
 	}
 
-	return &CleanupTaskResult{}, nil
-}
+	if id == "" {
+		return nil, errors.New("id is required.")
+	}
 
-// GetTaskLogs get task logs
-func (lc *Launcher) GetTaskLogs(gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
-	taskID := shortenTaskArn(aws.String(gtlp.TaskARN))
-	logGroupName := fmt.Sprintf(ECSLogGroupFormat, gtlp.DefinitionName)
-	streamName := fmt.Sprintf("%s/%s/%s", ECSStreamPrefix, gtlp.DefinitionName, taskID)
+	if clusterName == "" {
+		clusterName = aws.StringValue(clusterFromTaskArn(id))
+	}
 
-	logrus.WithFields(logrus.Fields{
-		"group":  logGroupName,
-		"stream": streamName,
-	}).Info("ReadLogs")
+	reason := stp.Reason
+	if reason == "" {
+		reason = DefaultStopTaskReason
+	}
 
-	res, err := lc.cwlogsReader.ReadLogs(&cwlogs.ReadLogsParams{
-		GroupName:  logGroupName,
-		StreamName: streamName,
-		NextToken:  gtlp.NextToken,
+	stopInput := &ecs.StopTaskInput{
+		Cluster: aws.String(clusterName),
+		Reason:  aws.String(reason),
+		Task:    aws.String(id),
+	}
+
+	res, err := lc.ecsSvc.StopTask(stopInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stop task.")
+	}
+
+	if !stp.Wait {
+		return newStopTaskResult(res.Task, stp.ContainerName), nil
+	}
+
+	waitTimeout := stp.WaitTimeout
+	if waitTimeout <= 0 {
+		waitTimeout = launcher.DefaultStopTimeout
+	}
+
+	_, err = lc.statusPoller().WaitTimeout(clusterName, id, waitTimeout)
+
+	timedOut := err == launcher.ErrWaitTimeout
+
+	if err != nil && !timedOut {
+		return nil, errors.Wrap(err, "failed to check stopped task.")
+	}
+
+	// the stop is sent again in case the first request was lost, ECS kills containers which ignore SIGTERM
+	if timedOut {
+		logrus.WithField("ID", id).Warn("task didn't stop within the timeout, sending stop again")
+
+		_, err = lc.ecsSvc.StopTask(stopInput)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stop task.")
+		}
+	}
+
+	descRes, err := lc.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   []*string{aws.String(id)},
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve logs for task.")
+		return nil, errors.Wrap(err, "failed to describe task.")
 	}
 
-	return &GetTaskLogsResult{
-		LogLines:  res.LogLines,
-		NextToken: res.NextToken,
-	}, nil
+	if len(descRes.Tasks) == 0 {
+		return nil, errors.Errorf("task not found: %s", id)
+	}
+
+	stopRes := newStopTaskResult(descRes.Tasks[0], stp.ContainerName)
+	stopRes.TimedOut = timedOut
+
+	return stopRes, nil
 }
 
-func shortenTaskArn(taskArn *string) string {
-	tokens := strings.Split(aws.StringValue(taskArn), "/")
-	if len(tokens) == 3 {
-		return tokens[2]
+// StopTasks stop each of the tasks, tasks which can't be stopped are returned as failures
+func (lc *Launcher) StopTasks(stp *StopTasksParams) (*StopTasksResult, error) {
+
+	clusterName, ids, err := lc.resolveHandles(stp.Handles, stp.ClusterName, stp.IDs)
+	if err != nil {
+		return nil, err
 	}
 
-	return "unknown"
+	res := &StopTasksResult{}
+
+	for _, id := range ids {
+		stopRes, err := lc.StopTask(&StopTaskParams{
+			ClusterName:   clusterName,
+			ID:            id,
+			Reason:        stp.Reason,
+			Wait:          stp.Wait,
+			WaitTimeout:   stp.WaitTimeout,
+			ContainerName: stp.ContainerName,
+		})
+		if err != nil {
+			res.Failures = append(res.Failures, &launcher.TaskFailure{ID: id, Reason: errors.Cause(err).Error()})
+			continue
+		}
+
+		res.Tasks = append(res.Tasks, stopRes)
+	}
+
+	return res, nil
 }
 
-func convertMapToKeyValuePair(env map[string]string) []*ecs.KeyValuePair {
+// CleanupTask clean up ecs task definition
+func (lc *Launcher) CleanupTask(ctp *CleanupTaskParams) (*CleanupTaskResult, error) {
 
-	ecsEnv := []*ecs.KeyValuePair{}
+	family, _ := parseTaskDefinitionArn(ctp.TaskDefinition)
 
-	// empty map is valid
-	if env == nil {
-		return nil
+	if !ctp.Force {
+		running, err := lc.countRunningTasks(family, ctp.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+
+		if running > 0 {
+			return nil, errors.Wrapf(launcher.ErrTasksRunning, "%d tasks running in family %s", running, family)
+		}
 	}
 
-	for k, v := range env {
-		ecsEnv = append(ecsEnv, &ecs.KeyValuePair{Name: aws.String(k), Value: aws.String(v)})
+	logGroupName := ""
+
+	if ctp.DeleteLogGroup {
+		descRes, err := lc.ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(ctp.TaskDefinition),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe task definition.")
+		}
+
+		_, logGroupName, err = newDefineTaskParams(descRes.TaskDefinition, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return ecsEnv
-}
+	res := &CleanupTaskResult{}
 
-func convertMapToECSTags(tags map[string]string) []*ecs.Tag {
+	revisions := []string{ctp.TaskDefinition}
 
-	ecsTags := []*ecs.Tag{}
+	if ctp.AllRevisions || ctp.KeepLatest > 0 {
+		var err error
 
-	// empty map is valid
-	if tags == nil {
-		return nil
-	}
+		revisions, err = lc.listActiveRevisions(family)
+		if err != nil {
+			return nil, err
+		}
 
-	for k, v := range tags {
-		ecsTags = append(ecsTags, &ecs.Tag{Key: aws.String(k), Value: aws.String(v)})
+		// revisions are listed newest first
+		if ctp.KeepLatest > 0 {
+			keep := int(ctp.KeepLatest)
+			if keep > len(revisions) {
+				keep = len(revisions)
+			}
+
+			res.Kept = revisions[:keep]
+			revisions = revisions[keep:]
+		}
+	}
+
+	for _, revision := range revisions {
+		_, err := lc.ecsSvc.DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{
+			TaskDefinition: aws.String(revision),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to de-register definition.")
+		}
+
+		res.Deregistered = append(res.Deregistered, revision)
+	}
+
+	if logGroupName == "" {
+		return res, nil
+	}
+
+	// the log group is shared by every revision in the family, so it is kept while any of them remain active
+	remaining, err := lc.listActiveRevisions(family)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(remaining) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"name":      logGroupName,
+			"revisions": len(remaining),
+		}).Info("log group kept as active revisions remain")
+		return res, nil
+	}
+
+	deleted, err := cwlogs.DeleteLogGroup(lc.cwlogsSvc, logGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		res.DeletedLogGroup = logGroupName
+	}
+
+	return res, nil
+}
+
+// countRunningTasks count the running tasks from the family in the cluster, or in all clusters if none is supplied
+func (lc *Launcher) countRunningTasks(family, clusterName string) (int, error) {
+
+	clusters := []*string{aws.String(clusterName)}
+
+	if clusterName == "" {
+		clusters = []*string{}
+
+		var nextToken *string
+
+		for {
+			listRes, err := lc.ecsSvc.ListClusters(&ecs.ListClustersInput{NextToken: nextToken})
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to list clusters.")
+			}
+
+			clusters = append(clusters, listRes.ClusterArns...)
+
+			nextToken = listRes.NextToken
+			if nextToken == nil {
+				break
+			}
+		}
+	}
+
+	running := 0
+
+	for _, cluster := range clusters {
+		listRes, err := lc.ecsSvc.ListTasks(&ecs.ListTasksInput{
+			Cluster:       cluster,
+			Family:        aws.String(family),
+			DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to list tasks.")
+		}
+
+		running += len(listRes.TaskArns)
+	}
+
+	return running, nil
+}
+
+// listActiveRevisions list the IDs of the active revisions of the family, newest first
+func (lc *Launcher) listActiveRevisions(family string) ([]string, error) {
+
+	revisions := []string{}
+
+	var nextToken *string
+
+	for {
+		listRes, err := lc.ecsSvc.ListTaskDefinitions(&ecs.ListTaskDefinitionsInput{
+			FamilyPrefix: aws.String(family),
+			Status:       aws.String(ecs.TaskDefinitionStatusActive),
+			Sort:         aws.String(ecs.SortOrderDesc),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list task definitions.")
+		}
+
+		for _, arn := range listRes.TaskDefinitionArns {
+			family, revision := parseTaskDefinitionArn(aws.StringValue(arn))
+			revisions = append(revisions, fmt.Sprintf("%s:%d", family, revision))
+		}
+
+		nextToken = listRes.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return revisions, nil
+}
+
+// ListTasks list a page of tasks in the cluster which match the filter
+func (lc *Launcher) ListTasks(ltp *ListTasksParams) (*ListTasksResult, error) {
+
+	desiredStatus := ltp.DesiredStatus
+	if desiredStatus == "" {
+		desiredStatus = ecs.DesiredStatusRunning
+	}
+
+	maxResults := ltp.MaxResults
+	if maxResults <= 0 || maxResults > launcher.MaxStatusBatchSize {
+		maxResults = launcher.MaxStatusBatchSize
+	}
+
+	listInput := &ecs.ListTasksInput{
+		Cluster:       aws.String(ltp.ClusterName),
+		DesiredStatus: aws.String(desiredStatus),
+		NextToken:     ltp.NextToken,
+		MaxResults:    aws.Int64(maxResults),
+	}
+
+	if ltp.Family != "" {
+		listInput.Family = aws.String(ltp.Family)
+	}
+
+	listRes, err := lc.ecsSvc.ListTasks(listInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tasks.")
+	}
+
+	res := &ListTasksResult{NextToken: listRes.NextToken}
+
+	if len(listRes.TaskArns) == 0 {
+		return res, nil
+	}
+
+	descRes, err := lc.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(ltp.ClusterName),
+		Tasks:   listRes.TaskArns,
+		Include: []*string{aws.String(ecs.TaskFieldTags)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe tasks.")
+	}
+
+	for _, task := range descRes.Tasks {
+		lt := newListedTask(task)
+
+		if !ltp.MatchStatus(lt.TaskStatus) || !ltp.MatchStartTime(lt.StartTime) || !ltp.MatchTags(lt.Tags) {
+			continue
+		}
+
+		res.Tasks = append(res.Tasks, lt)
+	}
+
+	return res, nil
+}
+
+// GetTaskLogs get task logs, the log group and stream are discovered from the task and its definition
+func (lc *Launcher) GetTaskLogs(gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	clusterName, id, err := lc.resolveHandle(gtlp.Handle, gtlp.ClusterName, gtlp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := lc.taskLogLocation(clusterName, id, gtlp.ContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return lc.readTaskLogs(loc, gtlp)
+}
+
+func (lc *Launcher) readTaskLogs(loc *logLocation, gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	logrus.WithFields(logrus.Fields{
+		"group":  loc.groupName,
+		"stream": loc.streamName,
+	}).Info("ReadLogs")
+
+	res, err := lc.cwlogsReader.ReadLogs(&cwlogs.ReadLogsParams{
+		GroupName:     loc.groupName,
+		StreamName:    loc.streamName,
+		TaskID:        loc.taskID,
+		NextToken:     gtlp.NextToken,
+		StartFromHead: gtlp.StartFromHead,
+		FieldMapping:  gtlp.FieldMapping,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve logs for task.")
+	}
+
+	return &GetTaskLogsResult{
+		LogLines:  res.LogLines,
+		NextToken: res.NextToken,
+	}, nil
+}
+
+// ExportTaskLogs export the logs of the task from the start of the stream to a local file along with a manifest
+func (lc *Launcher) ExportTaskLogs(etlp *ExportTaskLogsParams) (*ExportTaskLogsResult, error) {
+
+	clusterName, id, err := lc.resolveHandle(etlp.Handle, etlp.ClusterName, etlp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := lc.taskLogLocation(clusterName, id, etlp.ContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cwlogs.Export(&taskLogsReader{lc: lc, cluster: clusterName, id: id, loc: loc}, &cwlogs.ExportParams{
+		GroupName:    loc.groupName,
+		StreamName:   loc.streamName,
+		Path:         etlp.Path,
+		Format:       etlp.Format,
+		TimeFormat:   etlp.TimeFormat,
+		ManifestPath: etlp.ManifestPath,
+		TaskID:       loc.taskID,
+		Definition:   loc.definition,
+		FieldMapping: etlp.FieldMapping,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export logs for task.")
+	}
+
+	return &ExportTaskLogsResult{
+		Path:         res.Path,
+		ManifestPath: res.ManifestPath,
+		Manifest:     res.Manifest,
+	}, nil
+}
+
+// RenewLease extend the lease on the tasks by updating the lease expiry tag
+func (lc *Launcher) RenewLease(rlp *RenewLeaseParams) (*RenewLeaseResult, error) {
+
+	if rlp.Lease == nil {
+		return nil, errors.New("lease is required.")
+	}
+
+	res := &RenewLeaseResult{Expires: rlp.Lease.Expires(time.Now())}
+	if rlp.Expires != nil {
+		res.Expires = rlp.Expires.UTC()
+	}
+
+	for _, id := range rlp.IDs {
+		_, err := lc.ecsSvc.TagResource(&ecs.TagResourceInput{
+			ResourceArn: aws.String(id),
+			Tags: []*ecs.Tag{
+				{Key: aws.String(launcher.LeaseExpiresTagKey), Value: aws.String(launcher.FormatLeaseTime(res.Expires))},
+			},
+		})
+		if err != nil {
+			res.Failures = append(res.Failures, &launcher.TaskFailure{ID: id, Reason: err.Error()})
+		}
+	}
+
+	return res, nil
+}
+
+// RunTask launch a task, write the logs to the sinks as they arrive and wait for it to complete, retrying as the policy allows
+func (lc *Launcher) RunTask(rtp *RunTaskParams, sinks ...cwlogs.LogSink) (*RunTaskResult, error) {
+
+	// invalid parameters are returned straight away rather than being retried as launch failures
+	_, err := validateLaunchTaskParams(&rtp.LaunchTaskParams)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &taskRun{lc: lc, rtp: rtp}
+
+	attempts, err := launcher.RunTask(lc.statusPoller(), tr, &launcher.RunParams{
+		BatchKey:         rtp.ClusterName,
+		FieldMapping:     rtp.FieldMapping,
+		PollInterval:     rtp.PollInterval,
+		DrainQuietPeriod: rtp.DrainQuietPeriod,
+		Lease:            rtp.Lease,
+		StopOnInterrupt:  rtp.StopOnInterrupt,
+		StopTimeout:      rtp.StopTimeout,
+		NotifyInterrupt:  lc.notifyInterrupt,
+		RetryPolicy:      rtp.RetryPolicy,
+	}, sinks...)
+
+	if tr.res == nil {
+		// the attempts are still returned when the last of the retries couldn't be launched
+		if rtp.RetryPolicy == nil || len(attempts) == 0 {
+			return nil, err
+		}
+
+		return &RunTaskResult{TaskStatus: launcher.TaskFailed, Attempts: attempts}, err
+	}
+
+	if rtp.RetryPolicy != nil {
+		tr.res.Attempts = attempts
+	}
+
+	return tr.res, err
+}
+
+// taskRun the backend of a run, this keeps the tasks launched by the current attempt
+type taskRun struct {
+	lc  *Launcher
+	rtp *RunTaskParams
+
+	launchRes *LaunchTaskResult
+	reader    *taskLogsReader
+	res       *RunTaskResult
+}
+
+func (tr *taskRun) Launch() (*launcher.RunLaunch, error) {
+	tr.res = nil
+
+	launchRes, err := tr.lc.LaunchTask(&tr.rtp.LaunchTaskParams)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.launchRes = launchRes
+	tr.reader = &taskLogsReader{lc: tr.lc, cluster: tr.rtp.ClusterName, id: launchRes.ID, container: tr.rtp.ContainerName}
+
+	return &launcher.RunLaunch{
+		TaskID: launchRes.TaskID,
+		IDs:    taskArns(launchRes),
+		Reader: tr.reader,
+	}, nil
+}
+
+func (tr *taskRun) RenewLease(expires time.Time) error {
+	renewRes, err := tr.lc.RenewLease(&RenewLeaseParams{IDs: taskArns(tr.launchRes), Lease: tr.rtp.Lease, Expires: &expires})
+	if err != nil {
+		return err
+	}
+
+	if len(renewRes.Failures) > 0 {
+		return errors.Errorf("failed to renew lease on task %s: %s", renewRes.Failures[0].ID, renewRes.Failures[0].Reason)
+	}
+
+	return nil
+}
+
+func (tr *taskRun) Stop() error {
+	return tr.lc.stopInterrupted(tr.rtp, tr.launchRes)
+}
+
+func (tr *taskRun) Complete(ftr *launcher.FollowTaskResult, err error) (*launcher.RunAttempt, error) {
+	// the logs have been drained so the location is no longer needed
+	tr.lc.forgetLogLocations(tr.launchRes.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	statusRes, err := tr.lc.GetTaskStatus(&GetTaskStatusParams{ClusterName: tr.rtp.ClusterName, ID: tr.launchRes.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RunTaskResult{
+		ID:           statusRes.ID,
+		TaskStatus:   statusRes.TaskStatus,
+		StartTime:    statusRes.StartTime,
+		EndTime:      statusRes.EndTime,
+		TaskArn:      statusRes.TaskArn,
+		TaskID:       statusRes.TaskID,
+		LastStatus:   statusRes.LastStatus,
+		StopCode:     statusRes.StopCode,
+		ExitCode:     statusRes.ExitCode,
+		LogLineCount: ftr.LineCount,
+		Handle:       tr.launchRes.Handle,
+		Interrupted:  ftr.Stopped,
+	}
+
+	if tr.reader.loc != nil {
+		res.LogGroupName = tr.reader.loc.groupName
+		res.LogStreamName = tr.reader.loc.streamName
+	}
+
+	tr.res = res
+
+	return &launcher.RunAttempt{
+		ID:            res.ID,
+		TaskStatus:    res.TaskStatus,
+		StartTime:     res.StartTime,
+		EndTime:       res.EndTime,
+		Status:        res.LastStatus,
+		StopCode:      res.StopCode,
+		ExitCode:      res.ExitCode,
+		Handle:        res.Handle,
+		LogGroupName:  res.LogGroupName,
+		LogStreamName: res.LogStreamName,
+		LogLineCount:  res.LogLineCount,
+	}, nil
+}
+
+// taskArns the ARNs of all the tasks started by the launch
+func taskArns(launchRes *LaunchTaskResult) []string {
+	arns := []string{}
+	for _, task := range launchRes.Tasks {
+		arns = append(arns, task.TaskArn)
+	}
+
+	return arns
+}
+
+// stopInterrupted stop all the tasks started by the run
+func (lc *Launcher) stopInterrupted(rtp *RunTaskParams, launchRes *LaunchTaskResult) error {
+
+	reason := rtp.StopReason
+	if reason == "" {
+		reason = launcher.DefaultStopReason
+	}
+
+	for _, task := range launchRes.Tasks {
+		logrus.WithField("TaskID", task.TaskID).Warn("stopping interrupted task")
+
+		_, err := lc.StopTask(&StopTaskParams{ClusterName: rtp.ClusterName, ID: task.TaskArn, Reason: reason})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// taskLogsReader reads the logs of a single task, the log location is discovered on the first read and kept
+// for the reads which follow
+type taskLogsReader struct {
+	lc        *Launcher
+	cluster   string
+	id        string
+	container string
+	loc       *logLocation
+}
+
+func (tlr *taskLogsReader) ReadLogs(rlp *cwlogs.ReadLogsParams) (*cwlogs.ReadLogsResult, error) {
+	gtlp := &GetTaskLogsParams{
+		ID:            tlr.id,
+		ContainerName: tlr.container,
+		NextToken:     rlp.NextToken,
+		StartFromHead: rlp.StartFromHead,
+		FieldMapping:  rlp.FieldMapping,
+	}
+
+	if tlr.loc == nil {
+		loc, err := tlr.lc.taskLogLocation(tlr.cluster, tlr.id, tlr.container)
+		if err != nil {
+			return nil, err
+		}
+
+		tlr.loc = loc
+	}
+
+	res, err := tlr.lc.readTaskLogs(tlr.loc, gtlp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cwlogs.ReadLogsResult{LogLines: res.LogLines, NextToken: res.NextToken}, nil
+}
+
+type logLocation struct {
+	groupName  string
+	streamName string
+	taskID     string
+	definition string
+}
+
+// taskLogLocation discover the log group and stream of the container using the awslogs options of the task definition,
+// the first container using awslogs is used when none is named, locations are only cached until the task stops.
+// The cluster is only used when the ARN doesn't include one, such as the older short format ARNs
+func (lc *Launcher) taskLogLocation(clusterName, taskARN, containerName string) (*logLocation, error) {
+
+	key := logLocationKey(taskARN, containerName)
+
+	if v, ok := lc.logLocations.Load(key); ok {
+		return v.(*logLocation), nil
+	}
+
+	cluster := clusterFromTaskArn(taskARN)
+	if cluster == nil && clusterName != "" {
+		cluster = aws.String(clusterName)
+	}
+
+	descRes, err := lc.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: cluster,
+		Tasks:   []*string{aws.String(taskARN)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe task.")
+	}
+
+	if len(descRes.Tasks) == 0 {
+		return nil, errors.Errorf("task not found: %s", taskARN)
+	}
+
+	task := descRes.Tasks[0]
+
+	defRes, err := lc.ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: task.TaskDefinitionArn,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe task definition.")
+	}
+
+	taskID := shortenTaskArn(task.TaskArn)
+
+	for _, cd := range defRes.TaskDefinition.ContainerDefinitions {
+		if containerName != "" && aws.StringValue(cd.Name) != containerName {
+			continue
+		}
+
+		// skip the firelens log router as it only contains the logs of the router
+		if containerName == "" && cd.FirelensConfiguration != nil {
+			continue
+		}
+
+		if cd.LogConfiguration == nil || aws.StringValue(cd.LogConfiguration.LogDriver) != ecs.LogDriverAwslogs {
+			continue
+		}
+
+		opts := aws.StringValueMap(cd.LogConfiguration.Options)
+
+		if opts["awslogs-group"] == "" || opts["awslogs-stream-prefix"] == "" {
+			continue
+		}
+
+		loc := &logLocation{
+			groupName:  opts["awslogs-group"],
+			streamName: fmt.Sprintf("%s/%s/%s", opts["awslogs-stream-prefix"], aws.StringValue(cd.Name), taskID),
+			taskID:     taskID,
+			definition: aws.StringValue(task.TaskDefinitionArn),
+		}
+
+		if aws.StringValue(task.LastStatus) != ecs.DesiredStatusStopped {
+			lc.logLocations.Store(key, loc)
+		}
+
+		return loc, nil
+	}
+
+	return nil, launcher.ErrLogsNotAvailable
+}
+
+func logLocationKey(taskARN, containerName string) string {
+	return taskARN + "#" + containerName
+}
+
+// forgetLogLocations drop the cached log locations of a task once it has stopped
+func (lc *Launcher) forgetLogLocations(taskARN string) {
+	prefix := logLocationKey(taskARN, "")
+
+	lc.logLocations.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), prefix) {
+			lc.logLocations.Delete(k)
+		}
+		return true
+	})
+}
+
+// statusPoller the poller shared by all the tasks waiting on this launcher, it is attached to the status feed
+// if one was configured
+func (lc *Launcher) statusPoller() *launcher.Poller {
+	lc.pollerOnce.Do(func() {
+		if lc.poller == nil {
+			lc.poller = launcher.NewPoller(launcher.WithDoneHook(NewStatusFetcher(lc.ecsSvc), lc.forgetLogLocations), lc.pollerConfig)
+		}
+
+		if lc.statusFeed != nil {
+			lc.statusFeed.Attach(lc.poller)
+		}
+	})
+
+	return lc.poller
+}
+
+// StatusFetcher describes batches of tasks in a cluster for the poller
+type StatusFetcher struct {
+	ecsSvc ecsiface.ECSAPI
+}
+
+// NewStatusFetcher create a status fetcher using the ECS service
+func NewStatusFetcher(ecsSvc ecsiface.ECSAPI) *StatusFetcher {
+	return &StatusFetcher{ecsSvc: ecsSvc}
+}
+
+// FetchStatuses describe the tasks in the cluster, tasks which can't be described are returned with an error, each
+// update carries the id as it was requested as subscribers may use the task id or either form of the task ARN
+func (sf *StatusFetcher) FetchStatuses(clusterName string, ids []string) ([]*launcher.StatusUpdate, error) {
+	descRes, err := sf.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   aws.StringSlice(ids),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe tasks.")
+	}
+
+	// the same task may be requested more than once using different forms of the id
+	requested := map[string][]string{}
+	for _, id := range ids {
+		requested[taskIDFromArn(id)] = append(requested[taskIDFromArn(id)], id)
+	}
+
+	updates := make([]*launcher.StatusUpdate, 0, len(ids))
+	found := map[string]bool{}
+
+	for _, task := range descRes.Tasks {
+		taskID := taskIDFromArn(aws.StringValue(task.TaskArn))
+
+		for _, id := range requested[taskID] {
+			su := TaskStatusUpdate(task)
+			su.ID = id
+
+			updates = append(updates, su)
+		}
+
+		found[taskID] = true
+	}
+
+	for _, failure := range descRes.Failures {
+		taskID := taskIDFromArn(aws.StringValue(failure.Arn))
+
+		for _, id := range requested[taskID] {
+			updates = append(updates, &launcher.StatusUpdate{
+				ID:  id,
+				Err: errors.Errorf("failed to describe task %s: %s", id, aws.StringValue(failure.Reason)),
+			})
+		}
+
+		found[taskID] = true
+	}
+
+	// tasks which were neither described nor reported as failures
+	for taskID, reqIDs := range requested {
+		if found[taskID] {
+			continue
+		}
+
+		for _, id := range reqIDs {
+			updates = append(updates, &launcher.StatusUpdate{
+				ID:  id,
+				Err: errors.Errorf("failed to describe task %s: %s", id, "MISSING"),
+			})
+		}
+	}
+
+	return updates, nil
+}
+
+// taskIDFromArn the task id is the last part of both the long and short task ARN formats, task ids are returned as is
+func taskIDFromArn(taskArn string) string {
+	return taskArn[strings.LastIndex(taskArn, "/")+1:]
+}
+
+// TaskStatusUpdate convert an ECS task into a status update
+func TaskStatusUpdate(task *ecs.Task) *launcher.StatusUpdate {
+	return &launcher.StatusUpdate{
+		ID:         aws.StringValue(task.TaskArn),
+		TaskStatus: convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
+		LastStatus: aws.StringValue(task.LastStatus),
+		StopCode:   aws.StringValue(task.StopCode),
+		StartTime:  task.StartedAt,
+		EndTime:    task.StoppedAt,
+		Timestamp:  launcher.LatestTime(task.CreatedAt, task.PullStartedAt, task.PullStoppedAt, task.StartedAt, task.StoppingAt, task.StoppedAt),
+		Details:    taskDetails(task),
+	}
+}
+
+// taskDetails the state of each container along with the reason the task stopped
+func taskDetails(task *ecs.Task) map[string]string {
+	details := map[string]string{}
+
+	if task.StoppedReason != nil {
+		details["stopped_reason"] = aws.StringValue(task.StoppedReason)
+	}
+
+	for _, container := range task.Containers {
+		name := aws.StringValue(container.Name)
+
+		details[fmt.Sprintf("container/%s", name)] = aws.StringValue(container.LastStatus)
+
+		if container.ExitCode != nil {
+			details[fmt.Sprintf("container/%s/exit_code", name)] = strconv.FormatInt(aws.Int64Value(container.ExitCode), 10)
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	return details
+}
+
+// taskHandle the handle used to find the task again
+func taskHandle(lp *LaunchTaskParams, task *ecs.Task) *launcher.TaskHandle {
+	definition := aws.StringValue(task.TaskDefinitionArn)
+	if definition == "" {
+		definition = lp.TaskDefinition
+	}
+
+	return &launcher.TaskHandle{
+		Backend:    launcher.BackendECS,
+		Region:     launcher.RegionFromArn(aws.StringValue(task.TaskArn)),
+		Cluster:    lp.ClusterName,
+		Definition: definition,
+		ID:         aws.StringValue(task.TaskArn),
+	}
+}
+
+// resolveHandle the cluster and task ARN in the handle, the supplied values are returned when there is no handle
+func (lc *Launcher) resolveHandle(handle, clusterName, id string) (string, string, error) {
+	if handle == "" {
+		return clusterName, id, nil
+	}
+
+	th, err := launcher.DecodeBackendHandle(handle, launcher.BackendECS)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = th.CheckRegion(lc.region)
+	if err != nil {
+		return "", "", err
+	}
+
+	return th.Cluster, th.ID, nil
+}
+
+// resolveHandles the task ARNs in the handles are added to the IDs, all the tasks must be in the same cluster
+// as batch requests are made to a single cluster
+func (lc *Launcher) resolveHandles(handles []string, clusterName string, ids []string) (string, []string, error) {
+	resolved := append([]string{}, ids...)
+
+	for _, handle := range handles {
+		handleCluster, id, err := lc.resolveHandle(handle, "", "")
+		if err != nil {
+			return "", nil, err
+		}
+
+		if clusterName == "" {
+			clusterName = handleCluster
+		}
+
+		if handleCluster != clusterName {
+			return "", nil, errors.Wrapf(launcher.ErrInvalidTaskHandle, "handle is for cluster %s not %s", handleCluster, clusterName)
+		}
+
+		resolved = append(resolved, id)
+	}
+
+	return clusterName, resolved, nil
+}
+
+func newStopTaskResult(task *ecs.Task, containerName string) *StopTaskResult {
+	return &StopTaskResult{
+		ID:            aws.StringValue(task.TaskArn),
+		LastStatus:    aws.StringValue(task.LastStatus),
+		StopCode:      aws.StringValue(task.StopCode),
+		TaskStatus:    convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
+		StoppedReason: aws.StringValue(task.StoppedReason),
+		ExitCode:      containerExitCode(task, containerName),
+		EndTime:       task.StoppedAt,
+	}
+}
+
+// containerExitCode the exit code of the named container, otherwise the first container other than the log router
+// which has exited
+func containerExitCode(task *ecs.Task, containerName string) *int64 {
+	for _, container := range task.Containers {
+		name := aws.StringValue(container.Name)
+
+		if containerName != "" && name != containerName {
+			continue
+		}
+
+		if containerName == "" && name == FireLensContainerName {
+			continue
+		}
+
+		if container.ExitCode != nil {
+			return container.ExitCode
+		}
+	}
+
+	return nil
+}
+
+func newGetTaskStatusResult(task *ecs.Task) *GetTaskStatusResult {
+	return &GetTaskStatusResult{
+		ID:         aws.StringValue(task.TaskArn),
+		StartTime:  task.StartedAt,
+		EndTime:    task.StoppedAt,
+		TaskStatus: convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
+		TaskArn:    aws.StringValue(task.TaskArn),
+		TaskID:     shortenTaskArn(task.TaskArn),
+		LastStatus: aws.StringValue(task.LastStatus),
+		StopCode:   aws.StringValue(task.StopCode),
+		ExitCode:   containerExitCode(task, ""),
+	}
+}
+
+func newListedTask(task *ecs.Task) *ListedTask {
+	tags := map[string]string{}
+	for _, tag := range task.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return &ListedTask{
+		ID:         aws.StringValue(task.TaskArn),
+		TaskArn:    aws.StringValue(task.TaskArn),
+		TaskID:     shortenTaskArn(task.TaskArn),
+		Definition: aws.StringValue(task.TaskDefinitionArn),
+		LastStatus: aws.StringValue(task.LastStatus),
+		StopCode:   aws.StringValue(task.StopCode),
+		TaskStatus: convertTaskStatus(aws.StringValue(task.LastStatus), aws.StringValue(task.StopCode)),
+		StartTime:  task.StartedAt,
+		EndTime:    task.StoppedAt,
+		Tags:       tags,
+	}
+}
+
+func runTaskInput(lp *LaunchTaskParams, mode string, networkConfig *ecs.NetworkConfiguration, count int64) *ecs.RunTaskInput {
+	input := &ecs.RunTaskInput{
+		Cluster:              aws.String(lp.ClusterName),
+		TaskDefinition:       aws.String(lp.TaskDefinition),
+		Count:                aws.Int64(count),
+		NetworkConfiguration: networkConfig,
+		Overrides: &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{
+				{
+					Cpu:         aws.Int64(lp.CPU),
+					Memory:      aws.Int64(lp.Memory),
+					Name:        aws.String(lp.ContainerName),
+					Environment: convertMapToKeyValuePair(lp.Environment),
+				},
+			},
+		},
+		Tags: convertMapToECSTags(taskTags(lp)),
+	}
+
+	// the launch type can't be supplied along with a capacity provider strategy
+	if len(lp.CapacityProviderStrategy) > 0 {
+		input.CapacityProviderStrategy = convertCapacityProviderStrategy(lp.CapacityProviderStrategy)
+	} else {
+		input.LaunchType = aws.String(mode)
+	}
+
+	if mode == ecs.LaunchTypeEc2 {
+		input.PlacementConstraints = convertPlacementConstraints(lp.PlacementConstraints)
+		input.PlacementStrategy = convertPlacementStrategy(lp.PlacementStrategy)
+
+		return input
+	}
+
+	input.PlatformVersion = aws.String("LATEST")
+
+	return input
+}
+
+// networkConfiguration fargate tasks always use awsvpc, while EC2 tasks only take a network configuration when
+// their definition uses awsvpc, so the definition is described to check its network mode when subnets or
+// security groups are supplied for an EC2 task
+func (lc *Launcher) networkConfiguration(lp *LaunchTaskParams, mode string) (*ecs.NetworkConfiguration, error) {
+
+	awsvpcConfig := &ecs.AwsVpcConfiguration{
+		Subnets: aws.StringSlice(lp.Subnets),
+	}
+
+	if len(lp.SecurityGroups) > 0 {
+		awsvpcConfig.SecurityGroups = aws.StringSlice(lp.SecurityGroups)
+	}
+
+	if mode == ecs.LaunchTypeFargate {
+		assignPublicIP := lp.AssignPublicIP
+		if assignPublicIP == "" {
+			assignPublicIP = ecs.AssignPublicIpEnabled
+		}
+
+		awsvpcConfig.AssignPublicIp = aws.String(assignPublicIP)
+
+		return &ecs.NetworkConfiguration{AwsvpcConfiguration: awsvpcConfig}, nil
+	}
+
+	if len(lp.Subnets) == 0 && len(lp.SecurityGroups) == 0 {
+		return nil, nil
+	}
+
+	descRes, err := lc.ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(lp.TaskDefinition),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe task definition.")
+	}
+
+	// definitions registered without a network mode use bridge
+	networkMode := aws.StringValue(descRes.TaskDefinition.NetworkMode)
+	if networkMode == "" {
+		networkMode = ecs.NetworkModeBridge
+	}
+
+	if networkMode != ecs.NetworkModeAwsvpc {
+		return nil, errors.Errorf("subnets and security groups require the awsvpc network mode, the definition uses %s.", networkMode)
+	}
+
+	if len(lp.Subnets) == 0 {
+		return nil, errors.New("subnets are required by the awsvpc network mode.")
+	}
+
+	return &ecs.NetworkConfiguration{AwsvpcConfiguration: awsvpcConfig}, nil
+}
+
+func convertPlacementConstraints(constraints []*PlacementConstraint) []*ecs.PlacementConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	ecsConstraints := make([]*ecs.PlacementConstraint, 0, len(constraints))
+
+	for _, pc := range constraints {
+		ecsConstraint := &ecs.PlacementConstraint{Type: aws.String(pc.Type)}
+		if pc.Expression != "" {
+			ecsConstraint.Expression = aws.String(pc.Expression)
+		}
+
+		ecsConstraints = append(ecsConstraints, ecsConstraint)
+	}
+
+	return ecsConstraints
+}
+
+func convertPlacementStrategy(strategy []*PlacementStrategy) []*ecs.PlacementStrategy {
+	if len(strategy) == 0 {
+		return nil
+	}
+
+	ecsStrategy := make([]*ecs.PlacementStrategy, 0, len(strategy))
+
+	for _, ps := range strategy {
+		ecsPlacement := &ecs.PlacementStrategy{Type: aws.String(ps.Type)}
+		if ps.Field != "" {
+			ecsPlacement.Field = aws.String(ps.Field)
+		}
+
+		ecsStrategy = append(ecsStrategy, ecsPlacement)
+	}
+
+	return ecsStrategy
+}
+
+func convertCapacityProviderStrategy(items []*CapacityProviderStrategyItem) []*ecs.CapacityProviderStrategyItem {
+	strategy := make([]*ecs.CapacityProviderStrategyItem, 0, len(items))
+
+	for _, item := range items {
+		strategy = append(strategy, &ecs.CapacityProviderStrategyItem{
+			CapacityProvider: aws.String(item.CapacityProvider),
+			Weight:           aws.Int64(item.Weight),
+			Base:             aws.Int64(item.Base),
+		})
+	}
+
+	return strategy
+}
+
+// taskTags tasks are tagged with their owner, along with the lease when one is configured
+func taskTags(lp *LaunchTaskParams) map[string]string {
+	tags := launcher.OwnershipTags(lp.Tags)
+
+	if lp.Lease != nil {
+		for k, v := range lp.Lease.Tags(lp.Lease.Expires(time.Now())) {
+			tags[k] = v
+		}
+	}
+
+	return tags
+}
+
+// definitionTags task definitions are tagged with the time they were registered so the reaper can work out their age,
+// along with the log group name format so it can be described
+func definitionTags(dp *DefineTaskParams) map[string]string {
+	tags := launcher.OwnershipTags(dp.Tags)
+	tags[launcher.DefinedAtTagKey] = time.Now().UTC().Format(time.RFC3339)
+
+	if dp.LogGroup != nil && dp.LogGroup.NameFormat != "" {
+		tags[launcher.LogGroupFormatTagKey] = launcher.LogGroupFormatTag(dp.LogGroup.NameFormat)
+	}
+
+	return tags
+}
+
+func shortenTaskArn(taskArn *string) string {
+	// the older short format ARNs don't include the cluster, "task/<id>" rather than "task/<cluster>/<id>"
+	tokens := strings.Split(aws.StringValue(taskArn), "/")
+	if len(tokens) == 2 || len(tokens) == 3 {
+		return tokens[len(tokens)-1]
+	}
+
+	return "unknown"
+}
+
+// newDefineTaskParams reverse the conversion done by define task, returning the log group used by the task
+func newDefineTaskParams(td *ecs.TaskDefinition, tags []*ecs.Tag) (*DefineTaskParams, string, error) {
+
+	var container, firelens *ecs.ContainerDefinition
+
+	for _, cd := range td.ContainerDefinitions {
+		if cd.FirelensConfiguration != nil {
+			firelens = cd
+			continue
+		}
+
+		if container == nil {
+			container = cd
+		}
+	}
+
+	if container == nil {
+		return nil, "", errors.Errorf("task definition has no task container: %s", aws.StringValue(td.TaskDefinitionArn))
+	}
+
+	dp := &DefineTaskParams{
+		ExecutionRoleARN: aws.StringValue(td.ExecutionRoleArn),
+		DefinitionName:   aws.StringValue(td.Family),
+		ContainerName:    aws.StringValue(container.Name),
+		Region:           launcher.RegionFromArn(aws.StringValue(td.TaskDefinitionArn)),
+		TaskRoleARN:      td.TaskRoleArn,
+		Image:            aws.StringValue(container.Image),
+		Environment:      convertKeyValuePairToMap(container.Environment),
+		Tags:             launcher.StripOwnershipTags(convertECSTagsToMap(tags)),
+	}
+
+	// the launch type and network mode are only kept when they differ from the defaults
+	if compatibilities := aws.StringValueSlice(td.RequiresCompatibilities); len(compatibilities) == 1 && compatibilities[0] == ecs.CompatibilityEc2 {
+		dp.LaunchType = ecs.LaunchTypeEc2
+
+		if networkMode := aws.StringValue(td.NetworkMode); networkMode != ecs.NetworkModeBridge {
+			dp.NetworkMode = networkMode
+		}
+	}
+
+	if container.LogConfiguration == nil {
+		return dp, "", nil
+	}
+
+	driver := aws.StringValue(container.LogConfiguration.LogDriver)
+	options := convertOptionsToMap(container.LogConfiguration.Options)
+	secretOptions := convertSecretsToMap(container.LogConfiguration.SecretOptions)
+
+	if driver != ecs.LogDriverAwslogs {
+		dp.LogDriver = &LogDriverConfig{
+			Driver:        driver,
+			Options:       options,
+			SecretOptions: secretOptions,
+		}
+
+		if firelens == nil {
+			return dp, "", nil
+		}
+
+		dp.LogDriver.FireLens = &FireLensConfig{
+			Image:   aws.StringValue(firelens.Image),
+			Options: convertOptionsToMap(firelens.FirelensConfiguration.Options),
+		}
+
+		if firelensType := aws.StringValue(firelens.FirelensConfiguration.Type); firelensType != ecs.FirelensConfigurationTypeFluentbit {
+			dp.LogDriver.FireLens.Type = firelensType
+		}
+
+		if firelens.LogConfiguration == nil {
+			return dp, "", nil
+		}
+
+		return dp, aws.StringValue(firelens.LogConfiguration.Options["awslogs-group"]), nil
+	}
+
+	logGroupName := options["awslogs-group"]
+
+	// only options which differ from the defaults set by define task are kept
+	delete(options, "awslogs-group")
+
+	if options["awslogs-region"] == dp.Region {
+		delete(options, "awslogs-region")
+	}
+
+	if options["awslogs-stream-prefix"] == ECSStreamPrefix {
+		delete(options, "awslogs-stream-prefix")
+	}
+
+	if len(options) > 0 || len(secretOptions) > 0 {
+		dp.LogDriver = &LogDriverConfig{
+			Driver:        ecs.LogDriverAwslogs,
+			Options:       options,
+			SecretOptions: secretOptions,
+		}
+	}
+
+	return dp, logGroupName, nil
+}
+
+// parseTaskDefinitionArn split a task definition ARN, "arn:aws:ecs:<region>:<account>:task-definition/<family>:<revision>"
+func parseTaskDefinitionArn(arn string) (string, int64) {
+	tokens := strings.Split(arn[strings.LastIndex(arn, "/")+1:], ":")
+	if len(tokens) != 2 {
+		return tokens[0], 0
+	}
+
+	revision, _ := strconv.ParseInt(tokens[1], 10, 64)
+
+	return tokens[0], revision
+}
+
+// definitionLaunchType the launch type and network mode of the task definition, fargate tasks must use awsvpc
+func definitionLaunchType(dp *DefineTaskParams) (string, string, error) {
+	switch dp.LaunchType {
+	case "", ecs.LaunchTypeFargate:
+		if dp.NetworkMode != "" && dp.NetworkMode != ecs.NetworkModeAwsvpc {
+			return "", "", errors.Errorf("network mode %s isn't supported by the FARGATE launch type.", dp.NetworkMode)
+		}
+
+		return ecs.LaunchTypeFargate, ecs.NetworkModeAwsvpc, nil
+	case ecs.LaunchTypeEc2:
+		switch dp.NetworkMode {
+		case "":
+			return ecs.LaunchTypeEc2, ecs.NetworkModeBridge, nil
+		case ecs.NetworkModeBridge, ecs.NetworkModeHost, ecs.NetworkModeAwsvpc, ecs.NetworkModeNone:
+			return ecs.LaunchTypeEc2, dp.NetworkMode, nil
+		}
+
+		return "", "", errors.Errorf("unsupported network mode: %s", dp.NetworkMode)
+	}
+
+	return "", "", errors.Errorf("unsupported launch type: %s", dp.LaunchType)
+}
+
+// validateLaunchTaskParams the tasks are launched on FARGATE or EC2 capacity, which is returned, placement is only
+// available to EC2 tasks while a public address can only be assigned to FARGATE tasks
+func validateLaunchTaskParams(lp *LaunchTaskParams) (string, error) {
+	err := validateCapacityProviderStrategy(lp.CapacityProviderStrategy)
+	if err != nil {
+		return "", err
+	}
+
+	mode, err := launchMode(lp)
+	if err != nil {
+		return "", err
+	}
+
+	switch mode {
+	case ecs.LaunchTypeFargate:
+		if len(lp.PlacementConstraints) > 0 || len(lp.PlacementStrategy) > 0 {
+			return "", errors.New("placement constraints and strategies require EC2 capacity.")
+		}
+
+		switch lp.AssignPublicIP {
+		case "", ecs.AssignPublicIpEnabled, ecs.AssignPublicIpDisabled:
+		default:
+			return "", errors.Errorf("unsupported assign public ip: %s", lp.AssignPublicIP)
+		}
+	case ecs.LaunchTypeEc2:
+		if lp.AssignPublicIP != "" {
+			return "", errors.New("a public ip can only be assigned to FARGATE tasks.")
+		}
+	}
+
+	for _, pc := range lp.PlacementConstraints {
+		if pc.Type == "" {
+			return "", errors.New("placement constraint type is required.")
+		}
+
+		if pc.Type == ecs.PlacementConstraintTypeMemberOf && pc.Expression == "" {
+			return "", errors.New("placement constraint expression is required by memberOf.")
+		}
+	}
+
+	for _, ps := range lp.PlacementStrategy {
+		if ps.Type == "" {
+			return "", errors.New("placement strategy type is required.")
+		}
+	}
+
+	return mode, nil
+}
+
+// launchMode FARGATE or EC2, taken from the launch type or when a capacity provider strategy is used in place of
+// the launch type from its providers, which are either FARGATE and FARGATE_SPOT or EC2 auto scaling group providers
+func launchMode(lp *LaunchTaskParams) (string, error) {
+	if len(lp.CapacityProviderStrategy) == 0 {
+		switch lp.LaunchType {
+		case "", ecs.LaunchTypeFargate:
+			return ecs.LaunchTypeFargate, nil
+		case ecs.LaunchTypeEc2:
+			return ecs.LaunchTypeEc2, nil
+		}
+
+		return "", errors.Errorf("unsupported launch type: %s", lp.LaunchType)
+	}
+
+	if lp.LaunchType != "" {
+		return "", errors.New("launch type can't be set along with a capacity provider strategy.")
+	}
+
+	fargate := 0
+
+	for _, item := range lp.CapacityProviderStrategy {
+		if item.CapacityProvider == CapacityProviderFargate || item.CapacityProvider == CapacityProviderFargateSpot {
+			fargate++
+		}
+	}
+
+	switch fargate {
+	case 0:
+		return ecs.LaunchTypeEc2, nil
+	case len(lp.CapacityProviderStrategy):
+		return ecs.LaunchTypeFargate, nil
+	}
+
+	return "", errors.New("fargate and auto scaling group capacity providers can't be mixed in a strategy.")
+}
+
+func validateCapacityProviderStrategy(items []*CapacityProviderStrategyItem) error {
+	for _, item := range items {
+		if item.CapacityProvider == "" {
+			return errors.New("capacity provider name is required.")
+		}
+
+		if item.Weight < 0 || item.Base < 0 {
+			return errors.Errorf("capacity provider weight and base must not be negative: %s", item.CapacityProvider)
+		}
+	}
+
+	return nil
+}
+
+func validateLogDriver(ldc *LogDriverConfig) error {
+	if ldc == nil {
+		return nil
+	}
+
+	if ldc.Driver == "" {
+		return errors.New("log driver name is required.")
+	}
+
+	if ldc.Driver == ecs.LogDriverAwsfirelens && (ldc.FireLens == nil || ldc.FireLens.Image == "") {
+		return errors.New("firelens log router image is required by the awsfirelens log driver.")
+	}
+
+	if ldc.Driver != ecs.LogDriverAwsfirelens && ldc.FireLens != nil {
+		return errors.Errorf("firelens log router can only be used with the awsfirelens log driver: %s", ldc.Driver)
+	}
+
+	return nil
+}
+
+func buildLogConfiguration(dp *DefineTaskParams, logGroupName string) *ecs.LogConfiguration {
+	if dp.LogDriver == nil || dp.LogDriver.Driver == ecs.LogDriverAwslogs {
+		options := map[string]*string{
+			"awslogs-group":         aws.String(logGroupName),
+			"awslogs-region":        aws.String(dp.Region),
+			"awslogs-stream-prefix": aws.String(ECSStreamPrefix),
+		}
+
+		// allow the defaults to be overridden
+		if dp.LogDriver != nil {
+			for k, v := range dp.LogDriver.Options {
+				options[k] = aws.String(v)
+			}
+		}
+
+		return &ecs.LogConfiguration{
+			LogDriver:     aws.String(ecs.LogDriverAwslogs),
+			Options:       options,
+			SecretOptions: convertMapToSecrets(logDriverSecretOptions(dp.LogDriver)),
+		}
+	}
+
+	return &ecs.LogConfiguration{
+		LogDriver:     aws.String(dp.LogDriver.Driver),
+		Options:       aws.StringMap(dp.LogDriver.Options),
+		SecretOptions: convertMapToSecrets(dp.LogDriver.SecretOptions),
+	}
+}
+
+func buildFireLensContainer(dp *DefineTaskParams, logGroupName string) *ecs.ContainerDefinition {
+
+	firelensType := dp.LogDriver.FireLens.Type
+	if firelensType == "" {
+		firelensType = ecs.FirelensConfigurationTypeFluentbit
+	}
+
+	return &ecs.ContainerDefinition{
+		Name:      aws.String(FireLensContainerName),
+		Image:     aws.String(dp.LogDriver.FireLens.Image),
+		Essential: aws.Bool(true),
+		FirelensConfiguration: &ecs.FirelensConfiguration{
+			Type:    aws.String(firelensType),
+			Options: aws.StringMap(dp.LogDriver.FireLens.Options),
+		},
+		LogConfiguration: &ecs.LogConfiguration{
+			LogDriver: aws.String(ecs.LogDriverAwslogs),
+			Options: map[string]*string{
+				"awslogs-group":         aws.String(logGroupName),
+				"awslogs-region":        aws.String(dp.Region),
+				"awslogs-stream-prefix": aws.String(FireLensStreamPrefix),
+			},
+		},
+	}
+}
+
+func logDriverSecretOptions(ldc *LogDriverConfig) map[string]string {
+	if ldc == nil {
+		return nil
+	}
+
+	return ldc.SecretOptions
+}
+
+func usesAwslogs(containerDefinitions []*ecs.ContainerDefinition) bool {
+	for _, cd := range containerDefinitions {
+		if aws.StringValue(cd.LogConfiguration.LogDriver) == ecs.LogDriverAwslogs {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clusterFromTaskArn the cluster name is included in task ARNs using the long ARN format,
+// when it is missing the default cluster is used
+func clusterFromTaskArn(taskArn string) *string {
+	tokens := strings.Split(taskArn, "/")
+	if len(tokens) == 3 {
+		return aws.String(tokens[1])
+	}
+
+	return nil
+}
+
+func convertMapToKeyValuePair(env map[string]string) []*ecs.KeyValuePair {
+
+	ecsEnv := []*ecs.KeyValuePair{}
+
+	// empty map is valid
+	if env == nil {
+		return nil
+	}
+
+	for k, v := range env {
+		ecsEnv = append(ecsEnv, &ecs.KeyValuePair{Name: aws.String(k), Value: aws.String(v)})
+	}
+
+	return ecsEnv
+}
+
+func convertMapToSecrets(secrets map[string]string) []*ecs.Secret {
+
+	ecsSecrets := []*ecs.Secret{}
+
+	// empty map is valid
+	if secrets == nil {
+		return nil
+	}
+
+	for k, v := range secrets {
+		ecsSecrets = append(ecsSecrets, &ecs.Secret{Name: aws.String(k), ValueFrom: aws.String(v)})
+	}
+
+	return ecsSecrets
+}
+
+func convertMapToECSTags(tags map[string]string) []*ecs.Tag {
+
+	ecsTags := []*ecs.Tag{}
+
+	// empty map is valid
+	if tags == nil {
+		return nil
+	}
+
+	for k, v := range tags {
+		ecsTags = append(ecsTags, &ecs.Tag{Key: aws.String(k), Value: aws.String(v)})
 	}
 
 	return ecsTags
 }
 
+func convertKeyValuePairToMap(ecsEnv []*ecs.KeyValuePair) map[string]string {
+	if len(ecsEnv) == 0 {
+		return nil
+	}
+
+	env := map[string]string{}
+
+	for _, kv := range ecsEnv {
+		env[aws.StringValue(kv.Name)] = aws.StringValue(kv.Value)
+	}
+
+	return env
+}
+
+func convertOptionsToMap(options map[string]*string) map[string]string {
+	if len(options) == 0 {
+		return nil
+	}
+
+	return aws.StringValueMap(options)
+}
+
+func convertSecretsToMap(ecsSecrets []*ecs.Secret) map[string]string {
+	if len(ecsSecrets) == 0 {
+		return nil
+	}
+
+	secrets := map[string]string{}
+
+	for _, secret := range ecsSecrets {
+		secrets[aws.StringValue(secret.Name)] = aws.StringValue(secret.ValueFrom)
+	}
+
+	return secrets
+}
+
+func convertECSTagsToMap(ecsTags []*ecs.Tag) map[string]string {
+	if len(ecsTags) == 0 {
+		return nil
+	}
+
+	tags := map[string]string{}
+
+	for _, tag := range ecsTags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tags
+}
+
 func convertTaskStatus(lastStatus, stopCode string) string {
 	if lastStatus == ecs.DesiredStatusStopped {
-		if stopCode == ecs.TaskStopCodeEssentialContainerExited {
+		switch stopCode {
+		case ecs.TaskStopCodeEssentialContainerExited:
 			return launcher.TaskSucceeded
+		case TaskStopCodeSpotInterruption:
+			return launcher.TaskSpotInterrupted
 		}
 		return launcher.TaskFailed
 	}
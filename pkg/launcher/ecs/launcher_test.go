@@ -1,11 +1,18 @@
 package ecs
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/wolfeidau/aws-launch/awsmocks"
@@ -39,165 +46,1892 @@ func TestLauncher_LaunchTask(t *testing.T) {
 		TaskDefinition: "test-command:12",
 	}
 
+	handle := (&launcher.TaskHandle{
+		Backend:    launcher.BackendECS,
+		Region:     "ap-southeast-2",
+		Cluster:    "abc123",
+		Definition: "test-command:12",
+		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+	}).Encode()
+
 	want := &LaunchTaskResult{
 		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
 		TaskStatus: launcher.TaskRunning,
 		TaskArn:    "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
 		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+		Handle:     handle,
+		Tasks: []*LaunchedTask{
+			{
+				ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+				TaskStatus: launcher.TaskRunning,
+				TaskArn:    "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+				TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+				Handle:     handle,
+			},
+		},
+	}
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+	got, err := cbl.LaunchTask(rt)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+
+}
+
+func TestLauncher_LaunchTask_Count(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.Int64Value(in.Count) == 10
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1")},
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2")},
+		},
+		Failures: []*ecs.Failure{
+			{Reason: aws.String("RESOURCE:MEMORY")},
+		},
+	}, nil).Once()
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.Int64Value(in.Count) == 2
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc3")},
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{ClusterName: "test", TaskDefinition: "test-command:12", Count: 12})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 3)
+	require.Equal(t, "abc1", got.TaskID)
+	require.Equal(t, []*launcher.TaskFailure{{Reason: "RESOURCE:MEMORY"}}, got.Failures)
+	require.Equal(t, int64(9), got.NotLaunched)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_LaunchTask_Count_Error(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.Int64Value(in.Count) == 10
+	})).Return(&ecs.RunTaskOutput{
+		Failures: []*ecs.Failure{
+			{Reason: aws.String("RESOURCE:MEMORY")},
+		},
+	}, nil).Once()
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.Int64Value(in.Count) == 10
+	})).Return(nil, errors.New("throttled")).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	// the failures of the first batch are kept when the next batch fails
+	got, err := cbl.LaunchTask(&LaunchTaskParams{ClusterName: "test", TaskDefinition: "test-command:12", Count: 25})
+	require.EqualError(t, err, "failed to place task: RESOURCE:MEMORY")
+	require.Equal(t, []*launcher.TaskFailure{{Reason: "RESOURCE:MEMORY"}, {Reason: "throttled"}}, got.Failures)
+	require.Equal(t, int64(25), got.NotLaunched)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_LaunchTask_NotPlaced(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Failures: []*ecs.Failure{
+			{Reason: aws.String("RESOURCE:CPU")},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	_, err := cbl.LaunchTask(&LaunchTaskParams{ClusterName: "test", TaskDefinition: "test-command:12"})
+	require.EqualError(t, err, "failed to place task: RESOURCE:CPU")
+}
+
+func TestLauncher_LaunchTask_CapacityProviderStrategy(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return in.LaunchType == nil && len(in.CapacityProviderStrategy) == 2 &&
+			aws.StringValue(in.CapacityProviderStrategy[0].CapacityProvider) == "FARGATE_SPOT" &&
+			aws.Int64Value(in.CapacityProviderStrategy[0].Weight) == 3 &&
+			aws.Int64Value(in.CapacityProviderStrategy[1].Base) == 1
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1")},
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:    "test",
+		TaskDefinition: "test-command:12",
+		CapacityProviderStrategy: []*CapacityProviderStrategyItem{
+			{CapacityProvider: "FARGATE_SPOT", Weight: 3},
+			{CapacityProvider: "FARGATE", Weight: 1, Base: 1},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "abc1", got.TaskID)
+	ecsSvcMock.AssertExpectations(t)
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:              "test",
+		TaskDefinition:           "test-command:12",
+		CapacityProviderStrategy: []*CapacityProviderStrategyItem{{Weight: 1}},
+	})
+	require.EqualError(t, err, "capacity provider name is required.")
+}
+
+func TestLauncher_LaunchTask_EC2(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.StringValue(in.LaunchType) == ecs.LaunchTypeEc2 &&
+			in.PlatformVersion == nil && in.NetworkConfiguration == nil &&
+			len(in.PlacementConstraints) == 1 &&
+			aws.StringValue(in.PlacementConstraints[0].Expression) == "attribute:ecs.instance-type =~ r5.*" &&
+			len(in.PlacementStrategy) == 1 &&
+			aws.StringValue(in.PlacementStrategy[0].Field) == "memory"
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1")},
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:    "test",
+		TaskDefinition: "test-command:12",
+		LaunchType:     ecs.LaunchTypeEc2,
+		PlacementConstraints: []*PlacementConstraint{
+			{Type: ecs.PlacementConstraintTypeMemberOf, Expression: "attribute:ecs.instance-type =~ r5.*"},
+		},
+		PlacementStrategy: []*PlacementStrategy{
+			{Type: ecs.PlacementStrategyTypeBinpack, Field: "memory"},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "abc1", got.TaskID)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_LaunchTask_EC2_CapacityProviderStrategy(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return in.LaunchType == nil && in.PlatformVersion == nil && in.NetworkConfiguration == nil &&
+			len(in.CapacityProviderStrategy) == 1 &&
+			aws.StringValue(in.CapacityProviderStrategy[0].CapacityProvider) == "memory-asg" &&
+			len(in.PlacementStrategy) == 1
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1")},
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:              "test",
+		TaskDefinition:           "test-command:12",
+		CapacityProviderStrategy: []*CapacityProviderStrategyItem{{CapacityProvider: "memory-asg", Weight: 1}},
+		PlacementStrategy: []*PlacementStrategy{
+			{Type: ecs.PlacementStrategyTypeBinpack, Field: "memory"},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "abc1", got.TaskID)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_LaunchTask_EC2_NetworkMode(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command:12"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{NetworkMode: aws.String(ecs.NetworkModeAwsvpc)},
+	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command:13"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{NetworkMode: aws.String(ecs.NetworkModeBridge)},
+	}, nil)
+
+	// EC2 tasks using awsvpc can't be assigned a public address
+	ecsSvcMock.On("RunTask", mock.MatchedBy(func(in *ecs.RunTaskInput) bool {
+		return aws.StringValue(in.LaunchType) == ecs.LaunchTypeEc2 &&
+			aws.StringValueSlice(in.NetworkConfiguration.AwsvpcConfiguration.Subnets)[0] == "subnet-1" &&
+			aws.StringValueSlice(in.NetworkConfiguration.AwsvpcConfiguration.SecurityGroups)[0] == "sg-1" &&
+			in.NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp == nil
+	})).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1")},
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:    "test",
+		TaskDefinition: "test-command:12",
+		LaunchType:     ecs.LaunchTypeEc2,
+		Subnets:        []string{"subnet-1"},
+		SecurityGroups: []string{"sg-1"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "abc1", got.TaskID)
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:    "test",
+		TaskDefinition: "test-command:12",
+		LaunchType:     ecs.LaunchTypeEc2,
+		SecurityGroups: []string{"sg-1"},
+	})
+	require.EqualError(t, err, "subnets are required by the awsvpc network mode.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		ClusterName:    "test",
+		TaskDefinition: "test-command:13",
+		LaunchType:     ecs.LaunchTypeEc2,
+		Subnets:        []string{"subnet-1"},
+	})
+	require.EqualError(t, err, "subnets and security groups require the awsvpc network mode, the definition uses bridge.")
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_LaunchTask_Validate(t *testing.T) {
+
+	cbl := &Launcher{}
+
+	_, err := cbl.LaunchTask(&LaunchTaskParams{
+		PlacementStrategy: []*PlacementStrategy{{Type: ecs.PlacementStrategyTypeSpread, Field: "instanceId"}},
+	})
+	require.EqualError(t, err, "placement constraints and strategies require EC2 capacity.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		LaunchType:               ecs.LaunchTypeEc2,
+		CapacityProviderStrategy: []*CapacityProviderStrategyItem{{CapacityProvider: "FARGATE_SPOT"}},
+	})
+	require.EqualError(t, err, "launch type can't be set along with a capacity provider strategy.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		CapacityProviderStrategy: []*CapacityProviderStrategyItem{{CapacityProvider: "FARGATE_SPOT"}, {CapacityProvider: "memory-asg"}},
+	})
+	require.EqualError(t, err, "fargate and auto scaling group capacity providers can't be mixed in a strategy.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		LaunchType:     ecs.LaunchTypeEc2,
+		AssignPublicIP: ecs.AssignPublicIpEnabled,
+	})
+	require.EqualError(t, err, "a public ip can only be assigned to FARGATE tasks.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{AssignPublicIP: "YES"})
+	require.EqualError(t, err, "unsupported assign public ip: YES")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{
+		LaunchType:           ecs.LaunchTypeEc2,
+		PlacementConstraints: []*PlacementConstraint{{Type: ecs.PlacementConstraintTypeMemberOf}},
+	})
+	require.EqualError(t, err, "placement constraint expression is required by memberOf.")
+
+	_, err = cbl.LaunchTask(&LaunchTaskParams{LaunchType: "EXTERNAL"})
+	require.EqualError(t, err, "unsupported launch type: EXTERNAL")
+}
+
+func TestLauncher_GetTasksStatus(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{"abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2", "abc3"}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+				LastStatus: aws.String(ecs.DesiredStatusRunning),
+			},
+			{
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+			},
+		},
+		Failures: []*ecs.Failure{
+			{Arn: aws.String("abc3"), Reason: aws.String("MISSING")},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.GetTasksStatus(&GetTasksStatusParams{
+		ClusterName: "test",
+		IDs:         []string{"abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2", "abc3"},
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 2)
+	require.Equal(t, "abc1", got.Tasks[0].TaskID)
+	require.Equal(t, launcher.TaskSucceeded, got.Tasks[0].TaskStatus)
+	require.Equal(t, "abc2", got.Tasks[1].TaskID)
+	require.Equal(t, launcher.TaskRunning, got.Tasks[1].TaskStatus)
+	require.Equal(t, []*launcher.TaskFailure{{ID: "abc3", Reason: "MISSING"}}, got.Failures)
+}
+
+func TestLauncher_ListTasks(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		Family:        aws.String("testing"),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+		MaxResults:    aws.Int64(100),
+	}).Return(&ecs.ListTasksOutput{
+		TaskArns:  aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+		NextToken: aws.String("next"),
+	}, nil)
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+		Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/testing:1"),
+				LastStatus:        aws.String(ecs.DesiredStatusRunning),
+				Tags:              []*ecs.Tag{{Key: aws.String("env"), Value: aws.String("dev")}},
+			},
+			{
+				TaskArn:           aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/testing:1"),
+				LastStatus:        aws.String(ecs.DesiredStatusRunning),
+				Tags:              []*ecs.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.ListTasks(&ListTasksParams{
+		ClusterName: "test",
+		Family:      "testing",
+		TaskFilter:  launcher.TaskFilter{Tags: map[string]string{"env": "dev"}},
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 1)
+	require.Equal(t, "abc1", got.Tasks[0].TaskID)
+	require.Equal(t, launcher.TaskRunning, got.Tasks[0].TaskStatus)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task-definition/testing:1", got.Tasks[0].Definition)
+	require.Equal(t, aws.String("next"), got.NextToken)
+}
+
+func TestLauncher_WaitForTasks(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+			},
+		},
+		Failures: []*ecs.Failure{
+			{Arn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"), Reason: aws.String("MISSING")},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.WaitForTasks(&WaitForTasksParams{
+		ClusterName: "test",
+		IDs:         []string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []*WaitForTaskResult{{ID: "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", TaskStatus: launcher.TaskSucceeded}}, got.Tasks)
+	require.Len(t, got.Failures, 1)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2", got.Failures[0].ID)
+}
+
+func TestLauncher_WaitForTasks_MaxWait(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				LastStatus: aws.String(ecs.DesiredStatusRunning),
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.WaitForTasks(&WaitForTasksParams{
+		ClusterName: "test",
+		IDs:         []string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"},
+		MaxWait:     10 * time.Millisecond,
+	})
+	require.Nil(t, err)
+	require.Empty(t, got.Tasks)
+	require.Equal(t, []*launcher.TaskFailure{
+		{ID: "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", Reason: launcher.ErrWaitTimeout.Error()},
+	}, got.Failures)
+}
+
+func TestLauncher_DefineTask_With_Update(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(123),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ContainerName:    "test-command",
+		DefinitionName:   "test-command",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+	}
+	want := &DefineTaskResult{
+		ID:                     "test-command:123",
+		CloudwatchLogGroupName: "/aws/fargate/test-command",
+		CloudwatchStreamPrefix: "ecs",
 	}
+
 	cbl := &Launcher{
 		ecsSvc:    ecsSvcMock,
 		cwlogsSvc: cwlogsSvcMock,
 	}
-	got, err := cbl.LaunchTask(rt)
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestLauncher_ListDefinitions(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("ListTaskDefinitions", &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("test-command"),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+	}).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2",
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1",
+		}),
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.ListDefinitions(&ListDefinitionsParams{Family: "test-command"})
+	require.Nil(t, err)
+	require.Len(t, got.Definitions, 2)
+	require.Equal(t, &ListedDefinition{
+		ID:       "test-command:2",
+		Arn:      "arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2",
+		Family:   "test-command",
+		Revision: 2,
+	}, got.Definitions[0])
+}
+
+func TestLauncher_DescribeDefinition(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	dp := &DefineTaskParams{
+		ContainerName:    "test-command",
+		DefinitionName:   "test-command",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		TaskRoleARN:      aws.String("arn:aws:iam::123456789012:role/testTaskRole"),
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+		Environment:      map[string]string{"TEST": "true"},
+		Tags:             map[string]string{"team": "build"},
+		LogDriver: &LogDriverConfig{
+			Driver:  ecs.LogDriverAwsfirelens,
+			Options: map[string]string{"Name": "datadog"},
+			FireLens: &FireLensConfig{
+				Image: "amazon/aws-for-fluent-bit:latest",
+			},
+		},
+	}
+
+	var registered *ecs.RegisterTaskDefinitionInput
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvcMock.On("DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/aws/fargate/test-command"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		registered = args.Get(0).(*ecs.RegisterTaskDefinitionInput)
+	}).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(3),
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	_, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+
+	// the live task definition is built from what was registered
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command:3"),
+		Include:        aws.StringSlice([]string{ecs.TaskDefinitionFieldTags}),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			TaskDefinitionArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3"),
+			Family:               registered.Family,
+			Revision:             aws.Int64(3),
+			Status:               aws.String(ecs.TaskDefinitionStatusActive),
+			ExecutionRoleArn:     registered.ExecutionRoleArn,
+			TaskRoleArn:          registered.TaskRoleArn,
+			ContainerDefinitions: registered.ContainerDefinitions,
+		},
+		Tags: registered.Tags,
+	}, nil)
+
+	got, err := cbl.DescribeDefinition(&DescribeDefinitionParams{TaskDefinition: "test-command:3"})
+	require.Nil(t, err)
+	require.Equal(t, "test-command:3", got.ID)
+	require.Equal(t, "/aws/fargate/test-command", got.CloudwatchLogGroupName)
+	require.Equal(t, dp, got.Definition)
+}
+
+func TestLauncher_DescribeDefinition_LogGroupFormat(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	// the definition name also appears in the fixed part of the format
+	dp := &DefineTaskParams{
+		ContainerName:    "ci",
+		DefinitionName:   "ci",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+		LogGroup:         &cwlogs.LogGroupConfig{NameFormat: "/ci/%s"},
+	}
+
+	var registered *ecs.RegisterTaskDefinitionInput
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvcMock.On("DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/ci/ci"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		registered = args.Get(0).(*ecs.RegisterTaskDefinitionInput)
+	}).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("ci"),
+			Revision: aws.Int64(1),
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	_, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			TaskDefinitionArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/ci:1"),
+			Family:               registered.Family,
+			Revision:             aws.Int64(1),
+			Status:               aws.String(ecs.TaskDefinitionStatusActive),
+			ExecutionRoleArn:     registered.ExecutionRoleArn,
+			ContainerDefinitions: registered.ContainerDefinitions,
+		},
+		Tags: registered.Tags,
+	}, nil)
+
+	got, err := cbl.DescribeDefinition(&DescribeDefinitionParams{TaskDefinition: "ci:1"})
+	require.Nil(t, err)
+	require.Equal(t, "/ci/ci", got.CloudwatchLogGroupName)
+	require.Equal(t, &cwlogs.LogGroupConfig{NameFormat: "/ci/%s"}, got.Definition.LogGroup)
+	require.Nil(t, got.Definition.Tags)
+}
+
+func TestLauncher_DefineTask_With_LogGroup(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	cwlogsSvcMock.On("CreateLogGroup", &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String("/ci/test-command"),
+		Tags: map[string]*string{
+			"createdBy": aws.String("fargate-run-job"),
+			"team":      aws.String("build"),
+		},
+	}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	cwlogsSvcMock.On("PutRetentionPolicy", &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/ci/test-command"),
+		RetentionInDays: aws.Int64(14),
+	}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(123),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ContainerName:    "test-command",
+		DefinitionName:   "test-command",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+		LogGroup: &cwlogs.LogGroupConfig{
+			NameFormat:      "/ci/%s",
+			RetentionInDays: aws.Int64(14),
+			Tags:            map[string]string{"team": "build"},
+		},
+	}
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, "/ci/test-command", got.CloudwatchLogGroupName)
+	cwlogsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_DefineTask_With_FireLens(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.MatchedBy(func(in *ecs.RegisterTaskDefinitionInput) bool {
+		if len(in.ContainerDefinitions) != 2 {
+			return false
+		}
+		task, router := in.ContainerDefinitions[0], in.ContainerDefinitions[1]
+		return aws.StringValue(task.LogConfiguration.LogDriver) == ecs.LogDriverAwsfirelens &&
+			aws.StringValue(task.LogConfiguration.Options["Name"]) == "splunk" &&
+			aws.StringValue(router.Name) == FireLensContainerName &&
+			aws.StringValue(router.FirelensConfiguration.Type) == ecs.FirelensConfigurationTypeFluentbit &&
+			aws.StringValue(router.LogConfiguration.Options["awslogs-stream-prefix"]) == FireLensStreamPrefix
+	})).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(123),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ContainerName:    "test-command",
+		DefinitionName:   "test-command",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+		LogDriver: &LogDriverConfig{
+			Driver:  ecs.LogDriverAwsfirelens,
+			Options: map[string]string{"Name": "splunk"},
+			FireLens: &FireLensConfig{
+				Image: "amazon/aws-for-fluent-bit:latest",
+			},
+		},
+	}
+	want := &DefineTaskResult{
+		ID:                     "test-command:123",
+		CloudwatchLogGroupName: "/aws/fargate/test-command",
+	}
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_DefineTask_With_Splunk(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(123),
+		},
+	}, nil)
+
+	dp := &DefineTaskParams{
+		ContainerName:  "test-command",
+		DefinitionName: "test-command",
+		LogDriver: &LogDriverConfig{
+			Driver:        ecs.LogDriverSplunk,
+			Options:       map[string]string{"splunk-url": "https://splunk.example.com:8088"},
+			SecretOptions: map[string]string{"splunk-token": "arn:aws:ssm:ap-southeast-2:123456789012:parameter/splunk-token"},
+		},
+	}
+
+	// no cloudwatch log group is created as it isn't used
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: &awsmocks.CloudWatchLogsAPI{},
+	}
+
+	got, err := cbl.DefineTask(dp)
+	require.Nil(t, err)
+	require.Equal(t, &DefineTaskResult{ID: "test-command:123"}, got)
+}
+
+func TestLauncher_DefineTask_EC2(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+	ecsSvcMock.On("RegisterTaskDefinition", mock.MatchedBy(func(in *ecs.RegisterTaskDefinitionInput) bool {
+		return aws.StringValueSlice(in.RequiresCompatibilities)[0] == ecs.CompatibilityEc2 &&
+			aws.StringValue(in.NetworkMode) == ecs.NetworkModeBridge
+	})).Return(&ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Family:   aws.String("test-command"),
+			Revision: aws.Int64(123),
+		},
+	}, nil).Once()
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	got, err := cbl.DefineTask(&DefineTaskParams{
+		ContainerName:    "test-command",
+		DefinitionName:   "test-command",
+		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		Image:            "wolfeidau/test-command:latest",
+		Region:           "ap-southeast-2",
+		LaunchType:       ecs.LaunchTypeEc2,
+	})
+	require.Nil(t, err)
+	require.Equal(t, "test-command:123", got.ID)
+	ecsSvcMock.AssertExpectations(t)
+
+	_, err = cbl.DefineTask(&DefineTaskParams{DefinitionName: "test-command", NetworkMode: ecs.NetworkModeHost})
+	require.EqualError(t, err, "network mode host isn't supported by the FARGATE launch type.")
+}
+
+func TestLauncher_DefineTask_FireLens_Required(t *testing.T) {
+
+	cbl := &Launcher{}
+
+	_, err := cbl.DefineTask(&DefineTaskParams{
+		LogDriver: &LogDriverConfig{Driver: ecs.LogDriverAwsfirelens},
+	})
+	require.Error(t, err)
+
+	// the log router is only added for the awsfirelens driver
+	_, err = cbl.DefineTask(&DefineTaskParams{
+		LogDriver: &LogDriverConfig{
+			Driver:   ecs.LogDriverSplunk,
+			FireLens: &FireLensConfig{Image: "amazon/aws-for-fluent-bit:latest"},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestLauncher_GetTaskStatus(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String("EssentialContainerExited"),
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"),
+			},
+		},
+	}, nil)
+
+	gt := &GetTaskStatusParams{
+		ClusterName: "testing-1",
+	}
+	want := &GetTaskStatusResult{
+		TaskArn:    "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskStatus: "SUCCEEDED",
+		LastStatus: "STOPPED",
+		StopCode:   "EssentialContainerExited",
+	}
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.GetTaskStatus(gt)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestLauncher_StopTask(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("StopTask", mock.AnythingOfType("*ecs.StopTaskInput")).Return(&ecs.StopTaskOutput{
+		Task: &ecs.Task{
+			LastStatus: aws.String(ecs.DesiredStatusStopped),
+			StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+		},
+	}, nil)
+
+	ct := &StopTaskParams{
+		ClusterName: "abc123",
+		TaskARN:     "test-command:12",
+	}
+	want := &StopTaskResult{
+		TaskStatus: "SUCCEEDED",
+		LastStatus: "STOPPED",
+		StopCode:   "EssentialContainerExited",
+	}
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.StopTask(ct)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestLauncher_StopTasks(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("StopTask", &ecs.StopTaskInput{
+		Cluster: aws.String("test"),
+		Reason:  aws.String("cancelled by user"),
+		Task:    aws.String("abc1"),
+	}).Return(&ecs.StopTaskOutput{
+		Task: &ecs.Task{
+			LastStatus: aws.String(ecs.DesiredStatusStopped),
+			StopCode:   aws.String(ecs.TaskStopCodeUserInitiated),
+		},
+	}, nil)
+	ecsSvcMock.On("StopTask", &ecs.StopTaskInput{
+		Cluster: aws.String("test"),
+		Reason:  aws.String("cancelled by user"),
+		Task:    aws.String("abc2"),
+	}).Return(nil, errors.New("task not found"))
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.StopTasks(&StopTasksParams{ClusterName: "test", IDs: []string{"abc1", "abc2"}, Reason: "cancelled by user"})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 1)
+	require.Equal(t, "STOPPED", got.Tasks[0].LastStatus)
+	require.Equal(t, []*launcher.TaskFailure{{ID: "abc2", Reason: "task not found"}}, got.Failures)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_GetTaskStatus_Handle(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{taskArn}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String(taskArn), LastStatus: aws.String(ecs.DesiredStatusRunning)}},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+		region: "ap-southeast-2",
+	}
+
+	got, err := cbl.GetTaskStatus(&GetTaskStatusParams{
+		Handle: (&launcher.TaskHandle{Backend: launcher.BackendECS, Region: "ap-southeast-2", Cluster: "test", ID: taskArn}).Encode(),
+	})
+	require.Nil(t, err)
+	require.Equal(t, taskArn, got.ID)
+
+	_, err = cbl.GetTaskStatus(&GetTaskStatusParams{
+		Handle: (&launcher.TaskHandle{Backend: launcher.BackendCodebuild, ID: "testing-1:abc1"}).Encode(),
+	})
+	require.Equal(t, launcher.ErrInvalidTaskHandle, errors.Cause(err))
+
+	_, err = cbl.GetTaskStatus(&GetTaskStatusParams{
+		Handle: (&launcher.TaskHandle{Backend: launcher.BackendECS, Region: "us-east-1", Cluster: "test", ID: taskArn}).Encode(),
+	})
+	require.Equal(t, launcher.ErrInvalidTaskHandle, errors.Cause(err))
+}
+
+func TestLauncher_GetTasksStatus_Handles(t *testing.T) {
+
+	taskArn1 := "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"
+	taskArn2 := "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{taskArn1, taskArn2}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{TaskArn: aws.String(taskArn1), LastStatus: aws.String(ecs.DesiredStatusRunning)},
+			{TaskArn: aws.String(taskArn2), LastStatus: aws.String(ecs.DesiredStatusRunning)},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.GetTasksStatus(&GetTasksStatusParams{
+		IDs:     []string{taskArn1},
+		Handles: []string{(&launcher.TaskHandle{Backend: launcher.BackendECS, Cluster: "test", ID: taskArn2}).Encode()},
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Tasks, 2)
+	require.Equal(t, taskArn2, got.Tasks[1].ID)
+
+	// batch requests are made to a single cluster
+	_, err = cbl.GetTasksStatus(&GetTasksStatusParams{
+		ClusterName: "other",
+		Handles:     []string{(&launcher.TaskHandle{Backend: launcher.BackendECS, Cluster: "test", ID: taskArn2}).Encode()},
+	})
+	require.Equal(t, launcher.ErrInvalidTaskHandle, errors.Cause(err))
+}
+
+func TestLauncher_StopTask_Wait(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("StopTask", &ecs.StopTaskInput{
+		Cluster: aws.String("wolfeidau-ecs-dev-Cluster-1234567890123"),
+		Task:    aws.String(taskArn),
+		Reason:  aws.String("cancelled by build"),
+	}).Return(&ecs.StopTaskOutput{
+		Task: &ecs.Task{TaskArn: aws.String(taskArn), LastStatus: aws.String(ecs.DesiredStatusRunning)},
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:       aws.String(taskArn),
+				LastStatus:    aws.String(ecs.DesiredStatusStopped),
+				StopCode:      aws.String(ecs.TaskStopCodeUserInitiated),
+				StoppedReason: aws.String("cancelled by build"),
+				Containers: []*ecs.Container{
+					{Name: aws.String(FireLensContainerName), ExitCode: aws.Int64(0)},
+					{Name: aws.String("test-command"), ExitCode: aws.Int64(143)},
+				},
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.StopTask(&StopTaskParams{
+		ID:     taskArn,
+		Reason: "cancelled by build",
+		Wait:   true,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &StopTaskResult{
+		ID:            taskArn,
+		LastStatus:    ecs.DesiredStatusStopped,
+		StopCode:      ecs.TaskStopCodeUserInitiated,
+		TaskStatus:    launcher.TaskFailed,
+		StoppedReason: "cancelled by build",
+		ExitCode:      aws.Int64(143),
+	}, got)
+}
+
+func TestLauncher_RenewLease(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	expires := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ecsSvcMock.On("TagResource", &ecs.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+		Tags:        []*ecs.Tag{{Key: aws.String(launcher.LeaseExpiresTagKey), Value: aws.String("2020-01-02T03:04:05Z")}},
+	}).Return(&ecs.TagResourceOutput{}, nil)
+	ecsSvcMock.On("TagResource", &ecs.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+		Tags:        []*ecs.Tag{{Key: aws.String(launcher.LeaseExpiresTagKey), Value: aws.String("2020-01-02T03:04:05Z")}},
+	}).Return(nil, errors.New("task not found"))
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.RenewLease(&RenewLeaseParams{
+		IDs:     []string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"},
+		Lease:   &launcher.LeaseConfig{OwnerID: "worker-1"},
+		Expires: &expires,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &RenewLeaseResult{
+		Expires: expires,
+		Failures: []*launcher.TaskFailure{
+			{ID: "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2", Reason: "task not found"},
+		},
+	}, got)
+}
+
+func Test_taskTags(t *testing.T) {
+	tags := taskTags(&LaunchTaskParams{
+		Tags:  map[string]string{"team": "build"},
+		Lease: &launcher.LeaseConfig{OwnerID: "worker-1"},
+	})
+
+	owner, expires := launcher.LeaseFromTags(tags)
+	require.Equal(t, "worker-1", owner)
+	require.NotNil(t, expires)
+	require.True(t, launcher.IsOwned(tags))
+	require.Equal(t, "build", tags["team"])
+}
+
+func TestLauncher_CleanupTask(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		Family:        aws.String("test-command"),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}).Return(&ecs.ListTasksOutput{}, nil)
+	ecsSvcMock.On("DeregisterTaskDefinition", mock.AnythingOfType("*ecs.DeregisterTaskDefinitionInput")).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+
+	ct := &CleanupTaskParams{
+		TaskDefinition: "test-command:12",
+		ClusterName:    "test",
+	}
+	want := &CleanupTaskResult{
+		Deregistered: []string{"test-command:12"},
+	}
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	got, err := cbl.CleanupTask(ct)
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestLauncher_CleanupTask_Running(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("ListClusters", &ecs.ListClustersInput{}).Return(&ecs.ListClustersOutput{
+		ClusterArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:cluster/test"}),
+	}, nil)
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("arn:aws:ecs:ap-southeast-2:123456789012:cluster/test"),
+		Family:        aws.String("test-command"),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"}),
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	_, err := cbl.CleanupTask(&CleanupTaskParams{TaskDefinition: "test-command:12"})
+	require.Equal(t, launcher.ErrTasksRunning, errors.Cause(err))
+	ecsSvcMock.AssertNotCalled(t, "DeregisterTaskDefinition", mock.Anything)
+}
+
+func TestLauncher_CleanupTask_KeepLatest(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3"),
+			Family:            aws.String("test-command"),
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("test-command"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwslogs),
+						Options:   aws.StringMap(map[string]string{"awslogs-group": "/aws/fargate/test-command"}),
+					},
+				},
+			},
+		},
+	}, nil)
+	listInput := &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("test-command"),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+	}
+	ecsSvcMock.On("ListTaskDefinitions", listInput).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3",
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2",
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1",
+		}),
+	}, nil).Once()
+	ecsSvcMock.On("ListTaskDefinitions", listInput).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3",
+		}),
+	}, nil).Twice()
+	ecsSvcMock.On("ListTaskDefinitions", listInput).Return(&ecs.ListTaskDefinitionsOutput{}, nil).Once()
+	ecsSvcMock.On("DeregisterTaskDefinition", mock.AnythingOfType("*ecs.DeregisterTaskDefinitionInput")).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	got, err := cbl.CleanupTask(&CleanupTaskParams{
+		TaskDefinition: "test-command",
+		KeepLatest:     1,
+		DeleteLogGroup: true,
+		Force:          true,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &CleanupTaskResult{
+		Deregistered: []string{"test-command:2", "test-command:1"},
+		Kept:         []string{"test-command:3"},
+	}, got)
+
+	// the log group is still used by the kept revision
+	cwlogsSvcMock.AssertNotCalled(t, "DeleteLogGroup", mock.Anything)
+
+	cwlogsSvcMock.On("DeleteLogGroup", &cloudwatchlogs.DeleteLogGroupInput{
+		LogGroupName: aws.String("/aws/fargate/test-command"),
+	}).Return(&cloudwatchlogs.DeleteLogGroupOutput{}, nil)
+
+	got, err = cbl.CleanupTask(&CleanupTaskParams{
+		TaskDefinition: "test-command",
+		AllRevisions:   true,
+		DeleteLogGroup: true,
+		Force:          true,
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Deregistered, 1)
+	require.Equal(t, "/aws/fargate/test-command", got.DeletedLogGroup)
+}
+
+func TestLauncher_CleanupTask_SharedLogGroup(t *testing.T) {
+
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command:2"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"),
+			Family:            aws.String("test-command"),
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("test-command"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwslogs),
+						Options:   aws.StringMap(map[string]string{"awslogs-group": "/aws/fargate/test-command"}),
+					},
+				},
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DeregisterTaskDefinition", &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: aws.String("test-command:2"),
+	}).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+	ecsSvcMock.On("ListTaskDefinitions", &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("test-command"),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+	}).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{
+			"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3",
+		}),
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc:    ecsSvcMock,
+		cwlogsSvc: cwlogsSvcMock,
+	}
+
+	got, err := cbl.CleanupTask(&CleanupTaskParams{
+		TaskDefinition: "test-command:2",
+		DeleteLogGroup: true,
+		Force:          true,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &CleanupTaskResult{
+		Deregistered: []string{"test-command:2"},
+	}, got)
+
+	// revision 3 is still active and writes to the same log group
+	cwlogsSvcMock.AssertNotCalled(t, "DeleteLogGroup", mock.Anything)
+}
+
+func TestLauncher_GetTaskLogs(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("wolfeidau-ecs-dev-Cluster-1234567890123"),
+		Tasks:   []*string{aws.String(taskArn)},
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String(taskArn),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+			},
+		},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil).Once()
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/test-command",
+		StreamName: "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whaterer"}},
+		NextToken: aws.String("f/123456789"),
+	}, nil)
+
+	gt := &GetTaskLogsParams{
+		ID: taskArn,
+	}
+
+	want := &GetTaskLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whaterer"}},
+		NextToken: aws.String("f/123456789"),
+	}
+
+	cbl := &Launcher{
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
+	}
+
+	got, err := cbl.GetTaskLogs(gt)
 	require.Nil(t, err)
 	require.Equal(t, want, got)
 
+	// the discovered location is cached
+	_, err = cbl.GetTaskLogs(gt)
+	require.Nil(t, err)
+	ecsSvcMock.AssertExpectations(t)
 }
 
-func TestLauncher_DefineTask_With_Update(t *testing.T) {
+func TestLauncher_GetTaskLogs_Handle(t *testing.T) {
+
+	// the older short format ARNs don't include the cluster
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/dece5e631c854b0d9edd5d93e91d5b8c"
 
-	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
 	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
 
-	cwlogsSvcMock.On("CreateLogGroup", mock.AnythingOfType("*cloudwatchlogs.CreateLogGroupInput")).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
-	ecsSvcMock.On("RegisterTaskDefinition", mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Return(&ecs.RegisterTaskDefinitionOutput{
-		TaskDefinition: &ecs.TaskDefinition{
-			Family:   aws.String("test-command"),
-			Revision: aws.Int64(123),
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("batch"),
+		Tasks:   []*string{aws.String(taskArn)},
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String(taskArn),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+			},
 		},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil).Once()
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/test-command",
+		StreamName: "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines: []*cwlogs.LogLine{{Message: "whaterer"}},
 	}, nil)
 
-	dp := &DefineTaskParams{
-		ContainerName:    "test-command",
-		DefinitionName:   "test-command",
-		ExecutionRoleARN: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
-		Image:            "wolfeidau/test-command:latest",
-		Region:           "ap-southeast-2",
-	}
-	want := &DefineTaskResult{
-		ID:                     "test-command:123",
-		CloudwatchLogGroupName: "/aws/fargate/test-command",
-		CloudwatchStreamPrefix: "ecs",
+	cbl := &Launcher{
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
 	}
 
+	got, err := cbl.GetTaskLogs(&GetTaskLogsParams{
+		Handle: (&launcher.TaskHandle{Backend: launcher.BackendECS, Cluster: "batch", ID: taskArn}).Encode(),
+	})
+	require.Nil(t, err)
+	require.Len(t, got.LogLines, 1)
+	ecsSvcMock.AssertExpectations(t)
+}
+
+func TestLauncher_GetTaskLogs_ClusterName(t *testing.T) {
+
+	taskID := "dece5e631c854b0d9edd5d93e91d5b8c"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("batch"),
+		Tasks:   []*string{aws.String(taskID)},
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/batch/" + taskID),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+			},
+		},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil).Once()
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/test-command",
+		StreamName: "custom/web/" + taskID,
+		TaskID:     taskID,
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines: []*cwlogs.LogLine{{Message: "whaterer"}},
+	}, nil)
+
 	cbl := &Launcher{
-		ecsSvc:    ecsSvcMock,
-		cwlogsSvc: cwlogsSvcMock,
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
 	}
 
-	got, err := cbl.DefineTask(dp)
+	got, err := cbl.GetTaskLogs(&GetTaskLogsParams{
+		ClusterName: "batch",
+		ID:          taskID,
+	})
 	require.Nil(t, err)
-	require.Equal(t, want, got)
+	require.Len(t, got.LogLines, 1)
+	ecsSvcMock.AssertExpectations(t)
 }
 
-func TestLauncher_GetTaskStatus(t *testing.T) {
+func TestLauncher_GetTaskLogs_NotAvailable(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc123")}},
+	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("web"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverSplunk),
+					},
+				},
+			},
+		},
+	}, nil)
+
+	cbl := &Launcher{
+		ecsSvc: ecsSvcMock,
+	}
+
+	_, err := cbl.GetTaskLogs(&GetTaskLogsParams{ID: "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc123"})
+	require.Equal(t, launcher.ErrLogsNotAvailable, err)
+}
+
+func TestLauncher_GetTaskLogs_Container(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
 
 	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	// a sidecar using awslogs is defined before the requested container
+	def := testTaskDefinitionOutput()
+	def.TaskDefinition.ContainerDefinitions = append([]*ecs.ContainerDefinition{
+		{
+			Name: aws.String("sidecar"),
+			LogConfiguration: &ecs.LogConfiguration{
+				LogDriver: aws.String(ecs.LogDriverAwslogs),
+				Options: map[string]*string{
+					"awslogs-group":         aws.String("/custom/sidecar"),
+					"awslogs-stream-prefix": aws.String("sidecar"),
+				},
+			},
+		},
+	}, def.TaskDefinition.ContainerDefinitions...)
 
 	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
 		Tasks: []*ecs.Task{
 			{
-				LastStatus: aws.String(ecs.DesiredStatusStopped),
-				StopCode:   aws.String("EssentialContainerExited"),
-				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"),
+				TaskArn:           aws.String(taskArn),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+				LastStatus:        aws.String(ecs.DesiredStatusRunning),
 			},
 		},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(def, nil)
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/test-command",
+		StreamName: "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines: []*cwlogs.LogLine{{Message: "whaterer"}},
 	}, nil)
 
-	gt := &GetTaskStatusParams{
-		ClusterName: "testing-1",
+	cbl := &Launcher{
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
 	}
-	want := &GetTaskStatusResult{
-		TaskArn:    "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
+
+	_, err := cbl.GetTaskLogs(&GetTaskLogsParams{ID: taskArn, ContainerName: "web"})
+	require.Nil(t, err)
+	cwlogsReader.AssertExpectations(t)
+
+	// once the task has stopped the location is dropped from the cache
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String(taskArn),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+				LastStatus:        aws.String(ecs.DesiredStatusStopped),
+				StopCode:          aws.String(ecs.TaskStopCodeEssentialContainerExited),
+			},
+		},
+	}, nil)
+
+	_, err = cbl.GetTaskStatus(&GetTaskStatusParams{ID: taskArn, ClusterName: "wolfeidau-ecs-dev-Cluster-1234567890123"})
+	require.Nil(t, err)
+
+	_, ok := cbl.logLocations.Load(logLocationKey(taskArn, "web"))
+	require.False(t, ok)
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:  "/custom/sidecar",
+		StreamName: "sidecar/sidecar/dece5e631c854b0d9edd5d93e91d5b8c",
 		TaskID:     "dece5e631c854b0d9edd5d93e91d5b8c",
-		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c",
-		TaskStatus: "SUCCEEDED",
-		LastStatus: "STOPPED",
-		StopCode:   "EssentialContainerExited",
+	}).Return(&cwlogs.ReadLogsResult{}, nil)
+
+	_, err = cbl.GetTaskLogs(&GetTaskLogsParams{ID: taskArn, ContainerName: "sidecar"})
+	require.Nil(t, err)
+
+	_, ok = cbl.logLocations.Load(logLocationKey(taskArn, "sidecar"))
+	require.False(t, ok)
+}
+
+func testTaskDefinitionOutput() *ecs.DescribeTaskDefinitionOutput {
+	return &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("web"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwslogs),
+						Options: map[string]*string{
+							"awslogs-group":         aws.String("/custom/test-command"),
+							"awslogs-region":        aws.String("ap-southeast-2"),
+							"awslogs-stream-prefix": aws.String("custom"),
+						},
+					},
+				},
+			},
+		},
 	}
+}
+
+func TestLauncher_ExportTaskLogs(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String(taskArn),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12"),
+			},
+		},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil).Once()
+
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:     "/custom/test-command",
+		StreamName:    "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:        "dece5e631c854b0d9edd5d93e91d5b8c",
+		StartFromHead: true,
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whaterer"}},
+		NextToken: aws.String("f/123456789"),
+	}, nil)
+	cwlogsReader.On("ReadLogs", mock.MatchedBy(func(in *cwlogs.ReadLogsParams) bool {
+		return aws.StringValue(in.NextToken) == "f/123456789"
+	})).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/123456789"),
+	}, nil)
+
+	dir, err := ioutil.TempDir("", "export")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
 
 	cbl := &Launcher{
-		ecsSvc: ecsSvcMock,
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
 	}
 
-	got, err := cbl.GetTaskStatus(gt)
+	got, err := cbl.ExportTaskLogs(&ExportTaskLogsParams{
+		ID:   taskArn,
+		Path: filepath.Join(dir, "task.log"),
+	})
 	require.Nil(t, err)
-	require.Equal(t, want, got)
+	require.Equal(t, int64(1), got.Manifest.LineCount)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12", got.Manifest.Definition)
+
+	data, err := ioutil.ReadFile(got.Path)
+	require.Nil(t, err)
+	require.Contains(t, string(data), "whaterer")
+	require.FileExists(t, got.ManifestPath)
 }
 
-func TestLauncher_StopTask(t *testing.T) {
+func TestLauncher_RunTask(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
 
 	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
 
-	ecsSvcMock.On("StopTask", mock.AnythingOfType("*ecs.StopTaskInput")).Return(&ecs.StopTaskOutput{
-		Task: &ecs.Task{
-			LastStatus: aws.String(ecs.DesiredStatusStopped),
-			StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String(taskArn)}},
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+				TaskArn:    aws.String(taskArn),
+			},
 		},
 	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil)
 
-	ct := &StopTaskParams{
-		ClusterName: "abc123",
-		TaskARN:     "test-command:12",
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:     "/custom/test-command",
+		StreamName:    "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
+		TaskID:        "dece5e631c854b0d9edd5d93e91d5b8c",
+		StartFromHead: true,
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "whatever"}},
+		NextToken: aws.String("f/1"),
+	}, nil)
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/1"),
+	}, nil)
+
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ClusterName:    "abc123",
+			ContainerName:  "web",
+			TaskDefinition: "test-command:12",
+		},
 	}
-	want := &StopTaskResult{
-		TaskStatus: "SUCCEEDED",
-		LastStatus: "STOPPED",
-		StopCode:   "EssentialContainerExited",
+
+	want := &RunTaskResult{
+		ID:           taskArn,
+		TaskStatus:   launcher.TaskSucceeded,
+		TaskArn:      taskArn,
+		TaskID:       "dece5e631c854b0d9edd5d93e91d5b8c",
+		LastStatus:   ecs.DesiredStatusStopped,
+		StopCode:     ecs.TaskStopCodeEssentialContainerExited,
+		LogLineCount: 1,
+		Handle: (&launcher.TaskHandle{
+			Backend:    launcher.BackendECS,
+			Region:     "ap-southeast-2",
+			Cluster:    "abc123",
+			Definition: "test-command:12",
+			ID:         taskArn,
+		}).Encode(),
+		LogGroupName:  "/custom/test-command",
+		LogStreamName: "custom/web/dece5e631c854b0d9edd5d93e91d5b8c",
 	}
 
 	cbl := &Launcher{
-		ecsSvc: ecsSvcMock,
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
 	}
 
-	got, err := cbl.StopTask(ct)
+	buf := new(bytes.Buffer)
+
+	got, err := cbl.RunTask(rt, cwlogs.NewTextSink(buf, ""))
 	require.Nil(t, err)
 	require.Equal(t, want, got)
+	require.Equal(t, "whatever\n", buf.String())
 }
-func TestLauncher_CleanupTask(t *testing.T) {
+
+func TestLauncher_RunTask_Retry(t *testing.T) {
+
+	failedArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"
 
 	ecsSvcMock := &awsmocks.ECSAPI{}
+	cwlogsReader := &mocks.LogsReader{}
 
-	ecsSvcMock.On("DeregisterTaskDefinition", mock.AnythingOfType("*ecs.DeregisterTaskDefinitionInput")).Return(&ecs.DeregisterTaskDefinitionOutput{}, nil)
+	// the first attempt can't be placed as there is no spot capacity
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Failures: []*ecs.Failure{{Reason: aws.String("Capacity is unavailable at this time.")}},
+	}, nil).Once()
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String(failedArn)}},
+	}, nil).Once()
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String(taskArn)}},
+	}, nil).Once()
+	ecsSvcMock.On("DescribeTasks", mock.MatchedBy(func(in *ecs.DescribeTasksInput) bool {
+		return aws.StringValue(in.Tasks[0]) == failedArn
+	})).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String(ecs.TaskStopCodeTaskFailedToStart),
+				TaskArn:    aws.String(failedArn),
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.MatchedBy(func(in *ecs.DescribeTasksInput) bool {
+		return aws.StringValue(in.Tasks[0]) == taskArn
+	})).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusStopped),
+				StopCode:   aws.String(ecs.TaskStopCodeEssentialContainerExited),
+				TaskArn:    aws.String(taskArn),
+				Containers: []*ecs.Container{{Name: aws.String("test-command"), ExitCode: aws.Int64(0)}},
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil)
 
-	ct := &CleanupTaskParams{
-		TaskDefinition: "test-command:12",
+	cwlogsReader.On("ReadLogs", &cwlogs.ReadLogsParams{
+		GroupName:     "/custom/test-command",
+		StreamName:    "custom/web/abc2",
+		TaskID:        "abc2",
+		StartFromHead: true,
+	}).Return(&cwlogs.ReadLogsResult{
+		LogLines:  []*cwlogs.LogLine{{Message: "passed", TaskID: "abc2"}},
+		NextToken: aws.String("f/1"),
+	}, nil)
+	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
+		NextToken: aws.String("f/1"),
+	}, nil)
+
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ClusterName:    "test",
+			ContainerName:  "web",
+			TaskDefinition: "test-command:12",
+		},
+		RetryPolicy: &launcher.RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+			StopCodes:   []string{ecs.TaskStopCodeTaskFailedToStart},
+			Statuses:    []string{launcher.StatusLaunchFailed},
+		},
 	}
-	want := &CleanupTaskResult{}
 
 	cbl := &Launcher{
-		ecsSvc: ecsSvcMock,
+		ecsSvc:       ecsSvcMock,
+		cwlogsReader: cwlogsReader,
+		poller:       launcher.NewPoller(NewStatusFetcher(ecsSvcMock), &launcher.PollerConfig{MinInterval: time.Millisecond}),
 	}
 
-	got, err := cbl.CleanupTask(ct)
+	buf := new(bytes.Buffer)
+
+	got, err := cbl.RunTask(rt, cwlogs.NewJSONLinesSink(buf))
 	require.Nil(t, err)
-	require.Equal(t, want, got)
+	require.Equal(t, taskArn, got.ID)
+	require.Equal(t, launcher.TaskSucceeded, got.TaskStatus)
+	require.Equal(t, aws.Int64(0), got.ExitCode)
+	require.Len(t, got.Attempts, 3)
+	require.Equal(t, launcher.StatusLaunchFailed, got.Attempts[0].Status)
+	require.Equal(t, "failed to place task: Capacity is unavailable at this time.", got.Attempts[0].Reason)
+	require.Equal(t, failedArn, got.Attempts[1].ID)
+	require.Equal(t, ecs.TaskStopCodeTaskFailedToStart, got.Attempts[1].StopCode)
+	require.Equal(t, "custom/web/abc1", got.Attempts[1].LogStreamName)
+	require.Equal(t, 3, got.Attempts[2].Attempt)
+	require.Equal(t, "custom/web/abc2", got.Attempts[2].LogStreamName)
+
+	// a boundary is written before the logs of each retry and every line is labelled with its attempt
+	dec := json.NewDecoder(buf)
+
+	for _, want := range []*cwlogs.LogLine{
+		{Message: "--- attempt 2: abc1", TaskID: "abc1", Attempt: 2},
+		{Message: "--- attempt 3: abc2", TaskID: "abc2", Attempt: 3},
+		{Message: "passed", TaskID: "abc2", Attempt: 3},
+	} {
+		line := &cwlogs.LogLine{}
+		require.Nil(t, dec.Decode(line))
+		require.Equal(t, want.Message, line.Message)
+		require.Equal(t, want.TaskID, line.TaskID)
+		require.Equal(t, want.Attempt, line.Attempt)
+	}
 }
 
-func TestLauncher_GetTaskLogs(t *testing.T) {
+func TestLauncher_RunTask_Interrupted(t *testing.T) {
+
+	taskArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/dece5e631c854b0d9edd5d93e91d5b8c"
+	otherArn := "arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/0a3e2b1c854b0d9edd5d93e91d5b8c12"
 
+	ecsSvcMock := &awsmocks.ECSAPI{}
 	cwlogsReader := &mocks.LogsReader{}
 
+	ecsSvcMock.On("RunTask", mock.AnythingOfType("*ecs.RunTaskInput")).Return(&ecs.RunTaskOutput{
+		Tasks: []*ecs.Task{{TaskArn: aws.String(taskArn)}, {TaskArn: aws.String(otherArn)}},
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusRunning),
+				TaskArn:    aws.String(taskArn),
+			},
+			{
+				LastStatus: aws.String(ecs.DesiredStatusRunning),
+				TaskArn:    aws.String(otherArn),
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", mock.AnythingOfType("*ecs.DescribeTaskDefinitionInput")).Return(testTaskDefinitionOutput(), nil)
+	for _, arn := range []string{taskArn, otherArn} {
+		ecsSvcMock.On("StopTask", &ecs.StopTaskInput{
+			Cluster: aws.String("abc123"),
+			Task:    aws.String(arn),
+			Reason:  aws.String(launcher.DefaultStopReason),
+		}).Return(&ecs.StopTaskOutput{
+			Task: &ecs.Task{TaskArn: aws.String(arn), LastStatus: aws.String(ecs.DesiredStatusRunning)},
+		}, nil)
+	}
+
 	cwlogsReader.On("ReadLogs", mock.AnythingOfType("*cwlogs.ReadLogsParams")).Return(&cwlogs.ReadLogsResult{
-		LogLines:  []*cwlogs.LogLine{{Message: "whaterer"}},
-		NextToken: aws.String("f/123456789"),
+		NextToken: aws.String("f/1"),
 	}, nil)
 
-	gt := &GetTaskLogsParams{}
+	interrupted := make(chan struct{})
+	close(interrupted)
 
-	want := &GetTaskLogsResult{
-		LogLines:  []*cwlogs.LogLine{{Message: "whaterer"}},
-		NextToken: aws.String("f/123456789"),
+	rt := &RunTaskParams{
+		DrainQuietPeriod: time.Millisecond,
+		LaunchTaskParams: LaunchTaskParams{
+			ClusterName:    "abc123",
+			ContainerName:  "web",
+			TaskDefinition: "test-command:12",
+			Count:          2,
+		},
+		StopOnInterrupt: true,
+		// the tasks never stop in this test
+		StopTimeout: 10 * time.Millisecond,
 	}
 
 	cbl := &Launcher{
+		ecsSvc:       ecsSvcMock,
 		cwlogsReader: cwlogsReader,
+		notifyInterrupt: func() (<-chan struct{}, func()) {
+			return interrupted, func() {}
+		},
 	}
 
-	got, err := cbl.GetTaskLogs(gt)
-	require.Nil(t, err)
-	require.Equal(t, want, got)
+	got, err := cbl.RunTask(rt)
+	require.Equal(t, launcher.ErrInterrupted, err)
+	require.True(t, got.Interrupted)
+	require.Equal(t, launcher.TaskRunning, got.TaskStatus)
+
+	// every task started by the run is stopped
+	ecsSvcMock.AssertNumberOfCalls(t, "StopTask", 2)
+}
+
+func Test_parseTaskDefinitionArn(t *testing.T) {
+	family, revision := parseTaskDefinitionArn("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:12")
+	require.Equal(t, "test-command", family)
+	require.Equal(t, int64(12), revision)
+}
+
+func Test_clusterFromTaskArn(t *testing.T) {
+	require.Equal(t, "wolfeidau-ecs-dev-Cluster-1234567890123", aws.StringValue(clusterFromTaskArn("arn:aws:ecs:ap-southeast-2:123456789012:task/wolfeidau-ecs-dev-Cluster-1234567890123/abcefg1234567890abcefg1234567890")))
+	require.Nil(t, clusterFromTaskArn("arn:aws:ecs:ap-southeast-2:123456789012:task/abcefg1234567890abcefg1234567890"))
 }
 
 func Test_convertTaskStatus(t *testing.T) {
@@ -233,6 +1967,14 @@ func Test_convertTaskStatus(t *testing.T) {
 			},
 			want: launcher.TaskFailed,
 		},
+		{
+			name: "stopped by spot interruption should return spot interrupted",
+			args: args{
+				lastStatus: ecs.DesiredStatusStopped,
+				stopCode:   TaskStopCodeSpotInterruption,
+			},
+			want: launcher.TaskSpotInterrupted,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,3 +1984,40 @@ func Test_convertTaskStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusFetcher_FetchStatuses(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				LastStatus: aws.String(ecs.DesiredStatusRunning),
+				TaskArn:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/testing-1/abc1"),
+			},
+		},
+		Failures: []*ecs.Failure{
+			{Arn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/testing-1/abc2"), Reason: aws.String("MISSING")},
+		},
+	}, nil)
+
+	// subscribers used the task id, the short ARN and the long ARN
+	got, err := NewStatusFetcher(ecsSvcMock).FetchStatuses("testing-1", []string{
+		"abc1",
+		"arn:aws:ecs:ap-southeast-2:123456789012:task/abc1",
+		"abc2",
+		"abc3",
+	})
+	require.Nil(t, err)
+	require.Len(t, got, 4)
+
+	ids := map[string]error{}
+	for _, su := range got {
+		ids[su.ID] = su.Err
+	}
+
+	require.Nil(t, ids["abc1"])
+	require.Nil(t, ids["arn:aws:ecs:ap-southeast-2:123456789012:task/abc1"])
+	require.Error(t, ids["abc2"])
+	require.Error(t, ids["abc3"])
+}
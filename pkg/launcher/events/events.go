@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+)
+
+const (
+	// ECSTaskStateChange the detail type of ECS task state change events
+	ECSTaskStateChange = "ECS Task State Change"
+
+	// CodeBuildBuildStateChange the detail type of CodeBuild build state change events
+	CodeBuildBuildStateChange = "CodeBuild Build State Change"
+
+	// DefaultRuleName the name of the EventBridge rule created by setup
+	DefaultRuleName = "aws-launch-task-state"
+
+	// DefaultQueueName the name of the SQS queue created by setup
+	DefaultQueueName = "aws-launch-task-state"
+
+	// DefaultWaitTime the long poll duration of each receive
+	DefaultWaitTime = 20 * time.Second
+
+	// MaxWaitTime the longest long poll duration supported by SQS
+	MaxWaitTime = 20 * time.Second
+
+	// DefaultUnmatchedVisibilityTimeout how long an unmatched event is hidden before it is received again
+	DefaultUnmatchedVisibilityTimeout = 5 * time.Second
+
+	// DefaultUnmatchedRetention how long an unmatched event is kept on the queue before it is deleted
+	DefaultUnmatchedRetention = 10 * time.Minute
+)
+
+// SourceAPI route task state change events to a queue, then consume them as status updates
+type SourceAPI interface {
+	Setup(*SetupParams) (*SetupResult, error)
+	Teardown(*TeardownParams) (*TeardownResult, error)
+	Run(context.Context, *RunParams, ...Publisher) (*RunResult, error)
+}
+
+// Publisher receives the status updates built from events, returning true if the update matched a subscription,
+// this is implemented by launcher.StatusFeed and launcher.Poller
+type Publisher interface {
+	Publish(*launcher.StatusUpdate) bool
+}
+
+// fallbackPublisher polls less often while events are being received
+type fallbackPublisher interface {
+	SetFallback(bool)
+}
+
+// SetupParams create the EventBridge rule and the queue it forwards to
+type SetupParams struct {
+	// optional, an existing queue to forward events to, otherwise a queue is created
+	QueueURL  string `json:"queue_url,omitempty"`
+	QueueName string `json:"queue_name,omitempty"`
+
+	// optional, defaults to DefaultRuleName
+	RuleName string `json:"rule_name,omitempty"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// SetupResult the queue and rule receiving the events
+type SetupResult struct {
+	QueueURL string `json:"queue_url,omitempty"`
+	QueueArn string `json:"queue_arn,omitempty"`
+	RuleArn  string `json:"rule_arn,omitempty"`
+}
+
+// TeardownParams remove the rule and optionally the queue
+type TeardownParams struct {
+	RuleName string `json:"rule_name,omitempty"`
+
+	// optional, the queue is only deleted when supplied
+	QueueURL string `json:"queue_url,omitempty"`
+}
+
+// TeardownResult teardown result
+type TeardownResult struct {
+}
+
+// RunParams consume events from the queue
+type RunParams struct {
+	QueueURL string `json:"queue_url,omitempty" jsonschema:"required"`
+
+	// optional, defaults to DefaultWaitTime, limited to MaxWaitTime
+	WaitTime time.Duration `json:"wait_time,omitempty"`
+
+	// optional, defaults to DefaultUnmatchedVisibilityTimeout
+	UnmatchedVisibilityTimeout time.Duration `json:"unmatched_visibility_timeout,omitempty"`
+
+	// optional, defaults to DefaultUnmatchedRetention
+	UnmatchedRetention time.Duration `json:"unmatched_retention,omitempty"`
+}
+
+// RunResult counts of the messages consumed before the context was cancelled, unmatched messages are left on
+// the queue for other consumers until they are older than the unmatched retention
+type RunResult struct {
+	Received  int64 `json:"received,omitempty"`
+	Published int64 `json:"published,omitempty"`
+	Unmatched int64 `json:"unmatched,omitempty"`
+	Expired   int64 `json:"expired,omitempty"`
+	Ignored   int64 `json:"ignored,omitempty"`
+}
@@ -0,0 +1,466 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+	codebuildlauncher "github.com/wolfeidau/aws-launch/pkg/launcher/codebuild"
+	ecslauncher "github.com/wolfeidau/aws-launch/pkg/launcher/ecs"
+)
+
+const (
+	targetID = "aws-launch-queue"
+
+	// the format of the time fields in codebuild events
+	codebuildEventTimeFormat = "Jan 2, 2006 3:04:05 PM"
+)
+
+// Source creates the event rule and consumes the events forwarded to the queue
+type Source struct {
+	sqsSvc    sqsiface.SQSAPI
+	eventsSvc eventbridgeiface.EventBridgeAPI
+}
+
+// NewSource create a new event source
+func NewSource(cfgs ...*aws.Config) SourceAPI {
+	return NewSourceWithSQSEndpoint("", cfgs...)
+}
+
+// NewSourceWithSQSEndpoint create a new event source which uses the supplied SQS endpoint, this
+// enables use of a local SQS compatible service
+func NewSourceWithSQSEndpoint(endpoint string, cfgs ...*aws.Config) SourceAPI {
+	sess := session.Must(session.NewSession(cfgs...))
+
+	sqsCfg := &aws.Config{}
+	if endpoint != "" {
+		sqsCfg.Endpoint = aws.String(endpoint)
+	}
+
+	return &Source{
+		sqsSvc:    sqs.New(sess, sqsCfg),
+		eventsSvc: eventbridge.New(sess),
+	}
+}
+
+// Setup create the queue if required, then a rule which forwards task state change events to it
+func (s *Source) Setup(sp *SetupParams) (*SetupResult, error) {
+
+	ruleName := sp.RuleName
+	if ruleName == "" {
+		ruleName = DefaultRuleName
+	}
+
+	queueURL := sp.QueueURL
+
+	if queueURL == "" {
+		queueName := sp.QueueName
+		if queueName == "" {
+			queueName = DefaultQueueName
+		}
+
+		createRes, err := s.sqsSvc.CreateQueue(&sqs.CreateQueueInput{
+			QueueName: aws.String(queueName),
+			Tags:      aws.StringMap(launcher.OwnershipTags(sp.Tags)),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create queue.")
+		}
+
+		queueURL = aws.StringValue(createRes.QueueUrl)
+	}
+
+	attrRes, err := s.sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameQueueArn)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get queue attributes.")
+	}
+
+	queueArn := aws.StringValue(attrRes.Attributes[sqs.QueueAttributeNameQueueArn])
+
+	ruleRes, err := s.eventsSvc.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(ruleName),
+		Description:  aws.String("Forward task state changes to aws-launch"),
+		EventPattern: aws.String(eventPattern()),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+		Tags:         convertMapToEventBridgeTags(launcher.OwnershipTags(sp.Tags)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to put rule.")
+	}
+
+	ruleArn := aws.StringValue(ruleRes.RuleArn)
+
+	_, err = s.sqsSvc.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		Attributes: map[string]*string{
+			sqs.QueueAttributeNamePolicy: aws.String(queuePolicy(queueArn, ruleArn)),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set queue policy.")
+	}
+
+	targetsRes, err := s.eventsSvc.PutTargets(&eventbridge.PutTargetsInput{
+		Rule: aws.String(ruleName),
+		Targets: []*eventbridge.Target{
+			{
+				Id:  aws.String(targetID),
+				Arn: aws.String(queueArn),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to put rule targets.")
+	}
+
+	if aws.Int64Value(targetsRes.FailedEntryCount) > 0 {
+		return nil, errors.Errorf("failed to put rule target: %s", aws.StringValue(targetsRes.FailedEntries[0].ErrorMessage))
+	}
+
+	return &SetupResult{
+		QueueURL: queueURL,
+		QueueArn: queueArn,
+		RuleArn:  ruleArn,
+	}, nil
+}
+
+// Teardown remove the rule, then the queue if it was supplied
+func (s *Source) Teardown(tp *TeardownParams) (*TeardownResult, error) {
+
+	ruleName := tp.RuleName
+	if ruleName == "" {
+		ruleName = DefaultRuleName
+	}
+
+	_, err := s.eventsSvc.RemoveTargets(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String(ruleName),
+		Ids:  []*string{aws.String(targetID)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove rule targets.")
+	}
+
+	_, err = s.eventsSvc.DeleteRule(&eventbridge.DeleteRuleInput{
+		Name: aws.String(ruleName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete rule.")
+	}
+
+	if tp.QueueURL != "" {
+		_, err = s.sqsSvc.DeleteQueue(&sqs.DeleteQueueInput{
+			QueueUrl: aws.String(tp.QueueURL),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete queue.")
+		}
+	}
+
+	return &TeardownResult{}, nil
+}
+
+// Run receive events from the queue and publish them as status updates until the context is cancelled, while
+// running any pollers being published to fall back to a longer poll interval. Events which matched a
+// subscription are deleted, the rest become visible again for other consumers of the queue after the unmatched
+// visibility timeout, and are deleted once they are older than the unmatched retention
+func (s *Source) Run(ctx context.Context, rp *RunParams, publishers ...Publisher) (*RunResult, error) {
+
+	waitTime := rp.WaitTime
+	if waitTime == 0 {
+		waitTime = DefaultWaitTime
+	}
+
+	if waitTime > MaxWaitTime {
+		waitTime = MaxWaitTime
+	}
+
+	visibilityTimeout := rp.UnmatchedVisibilityTimeout
+	if visibilityTimeout <= 0 {
+		visibilityTimeout = DefaultUnmatchedVisibilityTimeout
+	}
+
+	retention := rp.UnmatchedRetention
+	if retention <= 0 {
+		retention = DefaultUnmatchedRetention
+	}
+
+	for _, pub := range publishers {
+		if fp, ok := pub.(fallbackPublisher); ok {
+			fp.SetFallback(true)
+			defer fp.SetFallback(false)
+		}
+	}
+
+	res := &RunResult{}
+
+	for ctx.Err() == nil {
+		recvRes, err := s.sqsSvc.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(rp.QueueURL),
+			MaxNumberOfMessages: aws.Int64(10),
+			WaitTimeSeconds:     aws.Int64(int64(waitTime / time.Second)),
+			AttributeNames:      []*string{aws.String(sqs.MessageSystemAttributeNameSentTimestamp)},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return res, errors.Wrap(err, "failed to receive events.")
+		}
+
+		if len(recvRes.Messages) == 0 {
+			continue
+		}
+
+		entries := []*sqs.DeleteMessageBatchRequestEntry{}
+		hidden := []*sqs.ChangeMessageVisibilityBatchRequestEntry{}
+
+		for n, msg := range recvRes.Messages {
+			res.Received++
+
+			entry := &sqs.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(n)),
+				ReceiptHandle: msg.ReceiptHandle,
+			}
+
+			su, err := ParseEvent([]byte(aws.StringValue(msg.Body)))
+			if err != nil {
+				// messages which can't be parsed can never match a subscription so they are deleted
+				logrus.WithError(err).WithField("MessageId", aws.StringValue(msg.MessageId)).Warn("failed to parse event")
+				res.Ignored++
+				entries = append(entries, entry)
+				continue
+			}
+
+			if su == nil {
+				res.Ignored++
+				entries = append(entries, entry)
+				continue
+			}
+
+			matched := false
+
+			for _, pub := range publishers {
+				if pub.Publish(su) {
+					matched = true
+				}
+			}
+
+			if !matched {
+				res.Unmatched++
+
+				// nobody has subscribed to this task for the whole retention so it is unlikely anyone will
+				if time.Since(sentTime(msg)) > retention {
+					res.Expired++
+					entries = append(entries, entry)
+					continue
+				}
+
+				// received again soon, rather than after the queue visibility timeout, in case a subscriber arrives
+				hidden = append(hidden, &sqs.ChangeMessageVisibilityBatchRequestEntry{
+					Id:                entry.Id,
+					ReceiptHandle:     msg.ReceiptHandle,
+					VisibilityTimeout: aws.Int64(int64(visibilityTimeout / time.Second)),
+				})
+				continue
+			}
+
+			res.Published++
+			entries = append(entries, entry)
+		}
+
+		if len(hidden) > 0 {
+			_, err = s.sqsSvc.ChangeMessageVisibilityBatch(&sqs.ChangeMessageVisibilityBatchInput{
+				QueueUrl: aws.String(rp.QueueURL),
+				Entries:  hidden,
+			})
+			if err != nil {
+				return res, errors.Wrap(err, "failed to change event visibility.")
+			}
+		}
+
+		if len(entries) == 0 {
+			continue
+		}
+
+		_, err = s.sqsSvc.DeleteMessageBatch(&sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(rp.QueueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			return res, errors.Wrap(err, "failed to delete events.")
+		}
+	}
+
+	return res, nil
+}
+
+// sentTime when the message was sent to the queue, messages without the attribute are treated as just sent
+func sentTime(msg *sqs.Message) time.Time {
+	millis, err := strconv.ParseInt(aws.StringValue(msg.Attributes[sqs.MessageSystemAttributeNameSentTimestamp]), 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+type eventEnvelope struct {
+	Source     string          `json:"source"`
+	DetailType string          `json:"detail-type"`
+	Time       *time.Time      `json:"time"`
+	Detail     json.RawMessage `json:"detail"`
+}
+
+type ecsTaskDetail struct {
+	TaskArn       string     `json:"taskArn"`
+	LastStatus    string     `json:"lastStatus"`
+	StopCode      string     `json:"stopCode"`
+	StoppedReason *string    `json:"stoppedReason"`
+	StartedAt     *time.Time `json:"startedAt"`
+	StoppedAt     *time.Time `json:"stoppedAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+	Containers    []struct {
+		Name       string `json:"name"`
+		LastStatus string `json:"lastStatus"`
+		ExitCode   *int64 `json:"exitCode"`
+	} `json:"containers"`
+}
+
+type codebuildDetail struct {
+	BuildID               string `json:"build-id"`
+	BuildStatus           string `json:"build-status"`
+	CurrentPhase          string `json:"current-phase"`
+	AdditionalInformation struct {
+		BuildComplete bool   `json:"build-complete"`
+		StartTime     string `json:"start-time"`
+		EndTime       string `json:"end-time"`
+	} `json:"additional-information"`
+}
+
+// ParseEvent convert a task state change event into a status update using the converter from each launcher,
+// events of other types return nil
+func ParseEvent(body []byte) (*launcher.StatusUpdate, error) {
+	env := new(eventEnvelope)
+
+	err := json.Unmarshal(body, env)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode event.")
+	}
+
+	switch env.DetailType {
+	case ECSTaskStateChange:
+		detail := new(ecsTaskDetail)
+
+		err := json.Unmarshal(env.Detail, detail)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode ecs event detail.")
+		}
+
+		task := &ecs.Task{
+			TaskArn:       aws.String(detail.TaskArn),
+			LastStatus:    aws.String(detail.LastStatus),
+			StopCode:      aws.String(detail.StopCode),
+			StoppedReason: detail.StoppedReason,
+			StartedAt:     detail.StartedAt,
+			StoppedAt:     detail.StoppedAt,
+		}
+
+		for _, c := range detail.Containers {
+			task.Containers = append(task.Containers, &ecs.Container{
+				Name:       aws.String(c.Name),
+				LastStatus: aws.String(c.LastStatus),
+				ExitCode:   c.ExitCode,
+			})
+		}
+
+		su := ecslauncher.TaskStatusUpdate(task)
+
+		// the event records when the task was updated
+		su.Timestamp = launcher.LatestTime(detail.UpdatedAt, env.Time)
+
+		return su, nil
+	case CodeBuildBuildStateChange:
+		detail := new(codebuildDetail)
+
+		err := json.Unmarshal(env.Detail, detail)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode codebuild event detail.")
+		}
+
+		su := codebuildlauncher.BuildStatusUpdate(&codebuild.Build{
+			Id:            aws.String(buildIDFromArn(detail.BuildID)),
+			BuildStatus:   aws.String(detail.BuildStatus),
+			BuildComplete: aws.Bool(detail.AdditionalInformation.BuildComplete),
+			CurrentPhase:  aws.String(detail.CurrentPhase),
+			StartTime:     parseCodebuildTime(detail.AdditionalInformation.StartTime),
+			EndTime:       parseCodebuildTime(detail.AdditionalInformation.EndTime),
+		})
+
+		if env.Time != nil {
+			su.Timestamp = env.Time
+		}
+
+		return su, nil
+	}
+
+	return nil, nil
+}
+
+func eventPattern() string {
+	data, _ := json.Marshal(map[string][]string{
+		"source":      {"aws.ecs", "aws.codebuild"},
+		"detail-type": {ECSTaskStateChange, CodeBuildBuildStateChange},
+	})
+
+	return string(data)
+}
+
+func queuePolicy(queueArn, ruleArn string) string {
+	return fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"events.amazonaws.com"},"Action":"sqs:SendMessage","Resource":%q,"Condition":{"ArnEquals":{"aws:SourceArn":%q}}}]}`, queueArn, ruleArn)
+}
+
+// buildIDFromArn the build events contain the build ARN rather than the id used by the launcher
+func buildIDFromArn(buildArn string) string {
+	tokens := strings.SplitN(buildArn, ":build/", 2)
+	return tokens[len(tokens)-1]
+}
+
+func parseCodebuildTime(v string) *time.Time {
+	if v == "" {
+		return nil
+	}
+
+	t, err := time.Parse(codebuildEventTimeFormat, v)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func convertMapToEventBridgeTags(tags map[string]string) []*eventbridge.Tag {
+	eventBridgeTags := []*eventbridge.Tag{}
+
+	for k, v := range tags {
+		eventBridgeTags = append(eventBridgeTags, &eventbridge.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	return eventBridgeTags
+}
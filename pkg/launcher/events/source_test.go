@@ -0,0 +1,218 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+)
+
+const ecsStoppedEvent = `{
+  "version": "0",
+  "detail-type": "ECS Task State Change",
+  "source": "aws.ecs",
+  "time": "2019-01-01T00:01:05Z",
+  "detail": {
+    "clusterArn": "arn:aws:ecs:ap-southeast-2:123456789012:cluster/test",
+    "taskArn": "arn:aws:ecs:ap-southeast-2:123456789012:task/test/dece5e631c854b0d9edd5d93e91d5b8c",
+    "lastStatus": "STOPPED",
+    "desiredStatus": "STOPPED",
+    "stopCode": "EssentialContainerExited",
+    "startedAt": "2019-01-01T00:00:00.000Z",
+    "stoppedAt": "2019-01-01T00:01:00.000Z",
+    "updatedAt": "2019-01-01T00:01:01.000Z",
+    "containers": [{"name": "web", "lastStatus": "STOPPED", "exitCode": 0}]
+  }
+}`
+
+const codebuildSucceededEvent = `{
+  "version": "0",
+  "detail-type": "CodeBuild Build State Change",
+  "source": "aws.codebuild",
+  "time": "2019-01-01T00:01:02Z",
+  "detail": {
+    "build-status": "SUCCEEDED",
+    "current-phase": "COMPLETED",
+    "project-name": "testing-1",
+    "build-id": "arn:aws:codebuild:ap-southeast-2:123456789012:build/testing-1:b17dddde-97c6-4592-b7be-216524f8422b",
+    "additional-information": {
+      "build-complete": true,
+      "start-time": "Jan 1, 2019 12:00:00 AM",
+      "end-time": "Jan 1, 2019 12:01:00 AM"
+    }
+  }
+}`
+
+type recordingPublisher struct {
+	subscribed map[string]bool
+	updates    []*launcher.StatusUpdate
+	fallback   []bool
+}
+
+func (rp *recordingPublisher) Publish(su *launcher.StatusUpdate) bool {
+	rp.updates = append(rp.updates, su)
+	return rp.subscribed[su.ID]
+}
+
+func (rp *recordingPublisher) SetFallback(enabled bool) {
+	rp.fallback = append(rp.fallback, enabled)
+}
+
+func TestParseEvent_ECS(t *testing.T) {
+
+	su, err := ParseEvent([]byte(ecsStoppedEvent))
+	require.Nil(t, err)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task/test/dece5e631c854b0d9edd5d93e91d5b8c", su.ID)
+	require.Equal(t, launcher.TaskSucceeded, su.TaskStatus)
+	require.Equal(t, "STOPPED", su.LastStatus)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC), *su.EndTime)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 1, 5, 0, time.UTC), *su.Timestamp)
+	require.Equal(t, map[string]string{"container/web": "STOPPED", "container/web/exit_code": "0"}, su.Details)
+	require.True(t, su.Done())
+}
+
+func TestParseEvent_CodeBuild(t *testing.T) {
+
+	su, err := ParseEvent([]byte(codebuildSucceededEvent))
+	require.Nil(t, err)
+	require.Equal(t, "testing-1:b17dddde-97c6-4592-b7be-216524f8422b", su.ID)
+	require.Equal(t, launcher.TaskSucceeded, su.TaskStatus)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), *su.StartTime)
+	require.Equal(t, time.Date(2019, 1, 1, 0, 1, 2, 0, time.UTC), *su.Timestamp)
+	require.Equal(t, "COMPLETED", su.Details["phase"])
+}
+
+func TestParseEvent_Ignored(t *testing.T) {
+
+	su, err := ParseEvent([]byte(`{"detail-type": "EC2 Instance State-change Notification", "detail": {}}`))
+	require.Nil(t, err)
+	require.Nil(t, su)
+
+	_, err = ParseEvent([]byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestSource_Setup(t *testing.T) {
+
+	sqsSvcMock := &awsmocks.SQSAPI{}
+	eventsSvcMock := &awsmocks.EventBridgeAPI{}
+
+	queueURL := "http://localhost:9324/queue/aws-launch-task-state"
+	queueArn := "arn:aws:sqs:ap-southeast-2:123456789012:aws-launch-task-state"
+	ruleArn := "arn:aws:events:ap-southeast-2:123456789012:rule/aws-launch-task-state"
+
+	sqsSvcMock.On("CreateQueue", mock.MatchedBy(func(in *sqs.CreateQueueInput) bool {
+		return aws.StringValue(in.QueueName) == DefaultQueueName
+	})).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String(queueURL)}, nil)
+	sqsSvcMock.On("GetQueueAttributes", mock.AnythingOfType("*sqs.GetQueueAttributesInput")).Return(&sqs.GetQueueAttributesOutput{
+		Attributes: map[string]*string{sqs.QueueAttributeNameQueueArn: aws.String(queueArn)},
+	}, nil)
+	sqsSvcMock.On("SetQueueAttributes", mock.MatchedBy(func(in *sqs.SetQueueAttributesInput) bool {
+		return aws.StringValue(in.QueueUrl) == queueURL && in.Attributes[sqs.QueueAttributeNamePolicy] != nil
+	})).Return(&sqs.SetQueueAttributesOutput{}, nil)
+	eventsSvcMock.On("PutRule", mock.MatchedBy(func(in *eventbridge.PutRuleInput) bool {
+		return aws.StringValue(in.Name) == DefaultRuleName &&
+			aws.StringValue(in.EventPattern) == `{"detail-type":["ECS Task State Change","CodeBuild Build State Change"],"source":["aws.ecs","aws.codebuild"]}`
+	})).Return(&eventbridge.PutRuleOutput{RuleArn: aws.String(ruleArn)}, nil)
+	eventsSvcMock.On("PutTargets", mock.MatchedBy(func(in *eventbridge.PutTargetsInput) bool {
+		return aws.StringValue(in.Targets[0].Arn) == queueArn
+	})).Return(&eventbridge.PutTargetsOutput{FailedEntryCount: aws.Int64(0)}, nil)
+
+	src := &Source{sqsSvc: sqsSvcMock, eventsSvc: eventsSvcMock}
+
+	got, err := src.Setup(&SetupParams{})
+	require.Nil(t, err)
+	require.Equal(t, &SetupResult{QueueURL: queueURL, QueueArn: queueArn, RuleArn: ruleArn}, got)
+	sqsSvcMock.AssertExpectations(t)
+	eventsSvcMock.AssertExpectations(t)
+}
+
+func TestSource_Run(t *testing.T) {
+
+	sqsSvcMock := &awsmocks.SQSAPI{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sqsSvcMock.On("ReceiveMessageWithContext", ctx, mock.MatchedBy(func(in *sqs.ReceiveMessageInput) bool {
+		return aws.Int64Value(in.WaitTimeSeconds) == 20
+	})).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{
+			{Body: aws.String(ecsStoppedEvent), ReceiptHandle: aws.String("r1")},
+			{Body: aws.String(codebuildSucceededEvent), ReceiptHandle: aws.String("r2")},
+			{Body: aws.String(`{"detail-type": "Other"}`), ReceiptHandle: aws.String("r3")},
+		},
+	}, nil).Once()
+	sqsSvcMock.On("ReceiveMessageWithContext", ctx, mock.AnythingOfType("*sqs.ReceiveMessageInput")).Run(func(args mock.Arguments) {
+		cancel()
+	}).Return(nil, errors.New("request cancelled"))
+	// the codebuild event has no subscriber so it is left for other consumers
+	sqsSvcMock.On("ChangeMessageVisibilityBatch", mock.MatchedBy(func(in *sqs.ChangeMessageVisibilityBatchInput) bool {
+		return len(in.Entries) == 1 &&
+			aws.StringValue(in.Entries[0].ReceiptHandle) == "r2" &&
+			aws.Int64Value(in.Entries[0].VisibilityTimeout) == 5
+	})).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil)
+	sqsSvcMock.On("DeleteMessageBatch", mock.MatchedBy(func(in *sqs.DeleteMessageBatchInput) bool {
+		return len(in.Entries) == 2 &&
+			aws.StringValue(in.Entries[0].ReceiptHandle) == "r1" &&
+			aws.StringValue(in.Entries[1].ReceiptHandle) == "r3"
+	})).Return(&sqs.DeleteMessageBatchOutput{}, nil)
+
+	src := &Source{sqsSvc: sqsSvcMock}
+	pub := &recordingPublisher{subscribed: map[string]bool{
+		"arn:aws:ecs:ap-southeast-2:123456789012:task/test/dece5e631c854b0d9edd5d93e91d5b8c": true,
+	}}
+
+	// the wait time is limited to the SQS maximum
+	got, err := src.Run(ctx, &RunParams{QueueURL: "http://localhost:9324/queue/test", WaitTime: time.Minute}, pub)
+	require.Nil(t, err)
+	require.Equal(t, &RunResult{Received: 3, Published: 1, Unmatched: 1, Ignored: 1}, got)
+	require.Len(t, pub.updates, 2)
+	require.Equal(t, []bool{true, false}, pub.fallback)
+	sqsSvcMock.AssertExpectations(t)
+}
+
+func TestSource_Run_UnmatchedExpired(t *testing.T) {
+
+	sqsSvcMock := &awsmocks.SQSAPI{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sent := strconv.FormatInt(time.Now().Add(-time.Hour).UnixNano()/int64(time.Millisecond), 10)
+
+	sqsSvcMock.On("ReceiveMessageWithContext", ctx, mock.AnythingOfType("*sqs.ReceiveMessageInput")).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{
+			{
+				Body:          aws.String(ecsStoppedEvent),
+				ReceiptHandle: aws.String("r1"),
+				Attributes:    map[string]*string{sqs.MessageSystemAttributeNameSentTimestamp: aws.String(sent)},
+			},
+		},
+	}, nil).Once()
+	sqsSvcMock.On("ReceiveMessageWithContext", ctx, mock.AnythingOfType("*sqs.ReceiveMessageInput")).Run(func(args mock.Arguments) {
+		cancel()
+	}).Return(nil, errors.New("request cancelled"))
+	// nobody subscribed to the task within the retention so the event is deleted
+	sqsSvcMock.On("DeleteMessageBatch", mock.MatchedBy(func(in *sqs.DeleteMessageBatchInput) bool {
+		return len(in.Entries) == 1 && aws.StringValue(in.Entries[0].ReceiptHandle) == "r1"
+	})).Return(&sqs.DeleteMessageBatchOutput{}, nil)
+
+	src := &Source{sqsSvc: sqsSvcMock}
+	pub := &recordingPublisher{}
+
+	got, err := src.Run(ctx, &RunParams{QueueURL: "http://localhost:9324/queue/test", UnmatchedRetention: time.Minute}, pub)
+	require.Nil(t, err)
+	require.Equal(t, &RunResult{Received: 1, Unmatched: 1, Expired: 1}, got)
+	sqsSvcMock.AssertNotCalled(t, "ChangeMessageVisibilityBatch", mock.Anything)
+	sqsSvcMock.AssertExpectations(t)
+}
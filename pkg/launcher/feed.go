@@ -0,0 +1,57 @@
+package launcher
+
+import "sync"
+
+// StatusFeed forwards status updates from another source, such as task state change events, to the pollers
+// of the launchers it is configured on
+type StatusFeed struct {
+	mu       sync.Mutex
+	pollers  []*Poller
+	fallback bool
+}
+
+// NewStatusFeed create a status feed with no pollers attached
+func NewStatusFeed() *StatusFeed {
+	return &StatusFeed{}
+}
+
+// Attach the poller so it receives the updates published to the feed
+func (sf *StatusFeed) Attach(p *Poller) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	sf.pollers = append(sf.pollers, p)
+
+	if sf.fallback {
+		p.SetFallback(true)
+	}
+}
+
+// Publish send the update to each attached poller, returns true if any poller had a subscriber for the task
+func (sf *StatusFeed) Publish(su *StatusUpdate) bool {
+	sf.mu.Lock()
+	pollers := sf.pollers
+	sf.mu.Unlock()
+
+	matched := false
+
+	for _, p := range pollers {
+		if p.Publish(su) {
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// SetFallback while enabled the attached pollers slow to their fallback interval
+func (sf *StatusFeed) SetFallback(enabled bool) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	sf.fallback = enabled
+
+	for _, p := range sf.pollers {
+		p.SetFallback(enabled)
+	}
+}
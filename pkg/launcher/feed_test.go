@@ -0,0 +1,35 @@
+package launcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusFeed_Publish(t *testing.T) {
+
+	p1 := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+	p2 := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	sf := NewStatusFeed()
+	sf.SetFallback(true)
+	sf.Attach(p1)
+	sf.Attach(p2)
+
+	// pollers attached while the feed is running fall back straight away
+	require.True(t, p1.fallback)
+	require.True(t, p2.fallback)
+
+	sub := p2.Subscribe("cluster-a", "abc123")
+	defer sub.Cancel()
+
+	<-sub.C // the first poll
+
+	require.False(t, sf.Publish(&StatusUpdate{ID: "other", TaskStatus: TaskSucceeded}))
+	require.True(t, sf.Publish(&StatusUpdate{ID: "abc123", TaskStatus: TaskSucceeded}))
+	require.Equal(t, TaskSucceeded, (<-sub.C).TaskStatus)
+
+	sf.SetFallback(false)
+	require.False(t, p1.fallback)
+}
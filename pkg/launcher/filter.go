@@ -0,0 +1,61 @@
+package launcher
+
+import (
+	"time"
+)
+
+// TaskFilter select tasks by status, start time and tags, empty fields match every task
+type TaskFilter struct {
+	TaskStatus    []string          `json:"task_status,omitempty"`
+	StartedAfter  *time.Time        `json:"started_after,omitempty"`
+	StartedBefore *time.Time        `json:"started_before,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// MatchStatus the task status is one of the filter statuses
+func (tf *TaskFilter) MatchStatus(taskStatus string) bool {
+	if len(tf.TaskStatus) == 0 {
+		return true
+	}
+
+	for _, s := range tf.TaskStatus {
+		if s == taskStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchStartTime the task started within the filter time range, tasks which haven't started only match
+// when no range is set
+func (tf *TaskFilter) MatchStartTime(startTime *time.Time) bool {
+	if tf.StartedAfter == nil && tf.StartedBefore == nil {
+		return true
+	}
+
+	if startTime == nil {
+		return false
+	}
+
+	if tf.StartedAfter != nil && startTime.Before(*tf.StartedAfter) {
+		return false
+	}
+
+	if tf.StartedBefore != nil && !startTime.Before(*tf.StartedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// MatchTags the tags contain every filter tag
+func (tf *TaskFilter) MatchTags(tags map[string]string) bool {
+	for k, v := range tf.Tags {
+		if tv, ok := tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,35 @@
+package launcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskFilter(t *testing.T) {
+
+	after := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.Add(time.Hour)
+	inside := after.Add(time.Minute)
+	outside := before.Add(time.Minute)
+
+	tf := &TaskFilter{}
+	require.True(t, tf.MatchStatus(TaskRunning))
+	require.True(t, tf.MatchStartTime(nil))
+	require.True(t, tf.MatchTags(nil))
+
+	tf = &TaskFilter{
+		TaskStatus:    []string{TaskFailed, TaskStopped},
+		StartedAfter:  &after,
+		StartedBefore: &before,
+		Tags:          map[string]string{"team": "ci"},
+	}
+	require.True(t, tf.MatchStatus(TaskFailed))
+	require.False(t, tf.MatchStatus(TaskRunning))
+	require.True(t, tf.MatchStartTime(&inside))
+	require.False(t, tf.MatchStartTime(&outside))
+	require.False(t, tf.MatchStartTime(nil))
+	require.True(t, tf.MatchTags(map[string]string{"team": "ci", "env": "dev"}))
+	require.False(t, tf.MatchTags(map[string]string{"team": "web"}))
+}
@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+)
+
+// FollowTaskParams wait for a task while following its logs
+type FollowTaskParams struct {
+	WaitOrStopParams
+
+	// reads the logs of the task
+	Reader cwlogs.LogsReader
+	Follow *cwlogs.FollowParams
+}
+
+// FollowTaskResult the outcome of following a task
+type FollowTaskResult struct {
+	// set when the task was stopped after the run was interrupted
+	Stopped   bool
+	LineCount int64
+}
+
+type followOutcome struct {
+	res *cwlogs.FollowResult
+	err error
+}
+
+// FollowTask write the logs of the task to the sinks until it completes, once complete the stream is drained
+// until it is quiet so lines ingested late by cloudwatch aren't missed
+func FollowTask(p *Poller, ftp *FollowTaskParams, sinks ...cwlogs.LogSink) (*FollowTaskResult, error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	followCh := make(chan *followOutcome, 1)
+
+	go func() {
+		res, err := cwlogs.Follow(ctx, ftp.Reader, ftp.Follow, sinks...)
+		followCh <- &followOutcome{res: res, err: err}
+	}()
+
+	stopped, err := WaitOrStop(p, &ftp.WaitOrStopParams)
+
+	// stop following and wait for the remaining logs to drain
+	cancel()
+	outcome := <-followCh
+
+	if err != nil {
+		return nil, err
+	}
+
+	if outcome.err != nil {
+		return nil, errors.Wrap(outcome.err, "failed to follow logs for task.")
+	}
+
+	return &FollowTaskResult{Stopped: stopped, LineCount: outcome.res.LineCount}, nil
+}
@@ -0,0 +1,61 @@
+package launcher
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+)
+
+// pagedReader returns one page of lines per read, then empty pages
+type pagedReader struct {
+	mu    sync.Mutex
+	pages [][]string
+}
+
+func (pr *pagedReader) ReadLogs(rlp *cwlogs.ReadLogsParams) (*cwlogs.ReadLogsResult, error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	res := &cwlogs.ReadLogsResult{NextToken: aws.String("f/1")}
+
+	if len(pr.pages) == 0 {
+		return res, nil
+	}
+
+	for _, msg := range pr.pages[0] {
+		res.LogLines = append(res.LogLines, &cwlogs.LogLine{Message: msg})
+	}
+	pr.pages = pr.pages[1:]
+
+	return res, nil
+}
+
+func TestFollowTask(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Publish(&StatusUpdate{ID: "abc1", TaskStatus: TaskSucceeded})
+	}()
+
+	buf := new(bytes.Buffer)
+
+	got, err := FollowTask(p, &FollowTaskParams{
+		WaitOrStopParams: WaitOrStopParams{IDs: []string{"abc1"}},
+		Reader:           &pagedReader{pages: [][]string{{"one", "two"}, {"three"}}},
+		Follow: &cwlogs.FollowParams{
+			PollInterval:     5 * time.Millisecond,
+			DrainQuietPeriod: 5 * time.Millisecond,
+		},
+	}, cwlogs.NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.False(t, got.Stopped)
+	require.Equal(t, int64(3), got.LineCount)
+	require.Equal(t, "one\ntwo\nthree\n", buf.String())
+}
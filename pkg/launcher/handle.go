@@ -0,0 +1,94 @@
+package launcher
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BackendECS tasks launched in ECS
+	BackendECS = "ecs"
+	// BackendCodebuild builds started in CodeBuild
+	BackendCodebuild = "codebuild"
+)
+
+// ErrInvalidTaskHandle the task handle couldn't be decoded or is for another backend
+var ErrInvalidTaskHandle = errors.New("invalid task handle")
+
+// TaskHandle everything needed to find a launched task again, this is returned by launch task in its encoded form
+// so it can be stored and used by another process
+type TaskHandle struct {
+	Backend string `json:"b"`
+	Region  string `json:"r,omitempty"`
+	// the ECS cluster
+	Cluster string `json:"c,omitempty"`
+	// the CodeBuild project
+	Project string `json:"p,omitempty"`
+	// the ECS task definition
+	Definition string `json:"d,omitempty"`
+	// the ECS task ARN or CodeBuild build ID
+	ID string `json:"i"`
+}
+
+// Encode the handle as an opaque URL safe string
+func (th *TaskHandle) Encode() string {
+	data, _ := json.Marshal(th)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// CheckRegion the handle must be for the region, handles or launchers without a region are accepted
+func (th *TaskHandle) CheckRegion(region string) error {
+	if th.Region == "" || region == "" || th.Region == region {
+		return nil
+	}
+
+	return errors.Wrapf(ErrInvalidTaskHandle, "handle is for region %s not %s", th.Region, region)
+}
+
+// DecodeTaskHandle decode a handle returned by launch task
+func DecodeTaskHandle(handle string) (*TaskHandle, error) {
+	data, err := base64.RawURLEncoding.DecodeString(handle)
+	if err != nil {
+		return nil, ErrInvalidTaskHandle
+	}
+
+	th := &TaskHandle{}
+
+	err = json.Unmarshal(data, th)
+	if err != nil {
+		return nil, ErrInvalidTaskHandle
+	}
+
+	if th.Backend == "" || th.ID == "" {
+		return nil, ErrInvalidTaskHandle
+	}
+
+	return th, nil
+}
+
+// DecodeBackendHandle decode a handle which must be for the backend
+func DecodeBackendHandle(handle, backend string) (*TaskHandle, error) {
+	th, err := DecodeTaskHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if th.Backend != backend {
+		return nil, errors.Wrapf(ErrInvalidTaskHandle, "handle is for %s not %s", th.Backend, backend)
+	}
+
+	return th, nil
+}
+
+// RegionFromArn the region of the resource, empty if the ARN can't be parsed
+func RegionFromArn(resourceArn string) string {
+	a, err := arn.Parse(resourceArn)
+	if err != nil {
+		return ""
+	}
+
+	return a.Region
+}
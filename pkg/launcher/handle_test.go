@@ -0,0 +1,47 @@
+package launcher
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHandle(t *testing.T) {
+	th := &TaskHandle{
+		Backend:    BackendECS,
+		Region:     "ap-southeast-2",
+		Cluster:    "test",
+		Definition: "test-command:12",
+		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1",
+	}
+
+	got, err := DecodeTaskHandle(th.Encode())
+	require.Nil(t, err)
+	require.Equal(t, th, got)
+
+	_, err = DecodeBackendHandle(th.Encode(), BackendCodebuild)
+	require.Equal(t, ErrInvalidTaskHandle, errors.Cause(err))
+
+	_, err = DecodeTaskHandle("not a handle")
+	require.Equal(t, ErrInvalidTaskHandle, err)
+
+	_, err = DecodeTaskHandle((&TaskHandle{Backend: BackendECS}).Encode())
+	require.Equal(t, ErrInvalidTaskHandle, err)
+}
+
+func TestTaskHandle_CheckRegion(t *testing.T) {
+	th := &TaskHandle{Backend: BackendECS, Region: "ap-southeast-2", ID: "abc1"}
+
+	require.Nil(t, th.CheckRegion("ap-southeast-2"))
+	require.Nil(t, th.CheckRegion(""))
+	require.Equal(t, ErrInvalidTaskHandle, errors.Cause(th.CheckRegion("us-east-1")))
+
+	require.Nil(t, (&TaskHandle{Backend: BackendECS, ID: "abc1"}).CheckRegion("us-east-1"))
+}
+
+func TestRegionFromArn(t *testing.T) {
+	require.Equal(t, "ap-southeast-2", RegionFromArn("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"))
+	require.Equal(t, "us-gov-west-1", RegionFromArn("arn:aws-us-gov:codebuild:us-gov-west-1:123456789012:build/testing-1:abc1"))
+	require.Equal(t, "", RegionFromArn("testing-1:abc1"))
+}
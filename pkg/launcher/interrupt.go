@@ -0,0 +1,146 @@
+package launcher
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ExitCodeInterrupted exit code for a run which was stopped after the process was interrupted, this matches
+	// the convention used by shells for SIGINT
+	ExitCodeInterrupted = 130
+
+	// DefaultStopReason reason recorded against tasks stopped after an interrupt
+	DefaultStopReason = "interrupted by user"
+	// DefaultStopTimeout how long to wait for an interrupted task to stop
+	DefaultStopTimeout = 2 * time.Minute
+)
+
+// NotifyFunc notifies a run of an interrupt, the release function restores the default signal handling
+type NotifyFunc func() (<-chan struct{}, func())
+
+// NotifyInterrupt the returned channel is closed when the process receives SIGINT or SIGTERM, the release
+// function restores the default signal handling
+//
+// The default handling is also restored as soon as the first signal arrives, so a second signal terminates
+// the process straight away rather than waiting for the interrupted tasks to stop.
+func NotifyInterrupt() (<-chan struct{}, func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	interrupted := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-sigCh:
+			signal.Stop(sigCh)
+			logrus.WithField("signal", sig.String()).Warn("interrupted, stopping tasks, interrupt again to quit without waiting")
+			close(interrupted)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+
+	return interrupted, func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			close(done)
+		})
+	}
+}
+
+// WaitOrStopParams wait for the tasks of a run which are stopped if the run is interrupted
+type WaitOrStopParams struct {
+	BatchKey string
+	IDs      []string
+
+	// closed when the run is interrupted, a nil channel waits for the task to complete
+	Interrupted <-chan struct{}
+	// called once when interrupted
+	Stop func() error
+	// optional, defaults to DefaultStopTimeout
+	StopTimeout time.Duration
+}
+
+// WaitOrStop wait for all the tasks to complete, if interrupted first the tasks are stopped and given the stop
+// timeout to finish, returns true if the tasks were interrupted
+func WaitOrStop(p *Poller, wsp *WaitOrStopParams) (bool, error) {
+
+	updates := make(chan *StatusUpdate)
+	done := make(chan struct{})
+	defer close(done)
+
+	remaining := map[string]bool{}
+
+	for _, id := range wsp.IDs {
+		remaining[id] = true
+
+		sub := p.Subscribe(wsp.BatchKey, id)
+		defer sub.Cancel()
+
+		go func(sub *Subscription) {
+			for {
+				select {
+				case su := <-sub.C:
+					select {
+					case updates <- su:
+					case <-done:
+						return
+					}
+				case <-done:
+					return
+				}
+			}
+		}(sub)
+	}
+
+	interrupted := wsp.Interrupted
+	stopped := false
+
+	var timeout <-chan time.Time
+
+	for len(remaining) > 0 {
+		select {
+		case su := <-updates:
+			if su.Err != nil {
+				return stopped, errors.Wrap(su.Err, "failed to check stopped task.")
+			}
+
+			if su.Done() {
+				delete(remaining, su.ID)
+			}
+		case <-interrupted:
+			// a nil channel is never selected again
+			interrupted = nil
+			stopped = true
+
+			err := wsp.Stop()
+			if err != nil {
+				return stopped, errors.Wrap(err, "failed to stop interrupted task.")
+			}
+
+			stopTimeout := wsp.StopTimeout
+			if stopTimeout <= 0 {
+				stopTimeout = DefaultStopTimeout
+			}
+
+			timer := time.NewTimer(stopTimeout)
+			defer timer.Stop()
+
+			timeout = timer.C
+		case <-timeout:
+			logrus.WithField("IDs", wsp.IDs).Warn("timed out waiting for interrupted tasks to stop")
+			return stopped, nil
+		}
+	}
+
+	return stopped, nil
+}
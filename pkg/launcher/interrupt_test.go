@@ -0,0 +1,140 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type runningFetcher struct{}
+
+func (rf *runningFetcher) FetchStatuses(batchKey string, ids []string) ([]*StatusUpdate, error) {
+	updates := make([]*StatusUpdate, len(ids))
+	for n, id := range ids {
+		updates[n] = &StatusUpdate{ID: id, TaskStatus: TaskRunning}
+	}
+
+	return updates, nil
+}
+
+func TestWaitOrStop(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	interrupted := make(chan struct{})
+	close(interrupted)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		<-stopped
+		p.Publish(&StatusUpdate{ID: "abc1", TaskStatus: TaskStopped})
+	}()
+
+	got, err := WaitOrStop(p, &WaitOrStopParams{
+		IDs:         []string{"abc1"},
+		Interrupted: interrupted,
+		Stop: func() error {
+			close(stopped)
+			return nil
+		},
+	})
+	require.Nil(t, err)
+	require.True(t, got)
+}
+
+func TestWaitOrStop_All(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	interrupted := make(chan struct{})
+	close(interrupted)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		<-stopped
+		p.Publish(&StatusUpdate{ID: "abc1", TaskStatus: TaskStopped})
+		p.Publish(&StatusUpdate{ID: "abc2", TaskStatus: TaskStopped})
+	}()
+
+	var waited int32
+
+	got, err := WaitOrStop(p, &WaitOrStopParams{
+		IDs:         []string{"abc1", "abc2"},
+		Interrupted: interrupted,
+		Stop: func() error {
+			atomic.AddInt32(&waited, 1)
+			close(stopped)
+			return nil
+		},
+	})
+	require.Nil(t, err)
+	require.True(t, got)
+	require.Equal(t, int32(1), atomic.LoadInt32(&waited))
+}
+
+func TestWaitOrStop_Timeout(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	interrupted := make(chan struct{})
+	close(interrupted)
+
+	got, err := WaitOrStop(p, &WaitOrStopParams{
+		IDs:         []string{"abc1"},
+		Interrupted: interrupted,
+		Stop:        func() error { return nil },
+		StopTimeout: 10 * time.Millisecond,
+	})
+	require.Nil(t, err)
+	require.True(t, got)
+}
+
+func TestNotifyInterrupt(t *testing.T) {
+
+	interrupted, release := NotifyInterrupt()
+	defer release()
+
+	err := syscall.Kill(os.Getpid(), syscall.SIGINT)
+	require.Nil(t, err)
+
+	select {
+	case <-interrupted:
+	case <-time.After(time.Second):
+		t.Fatal("expected interrupt")
+	}
+}
+
+func TestNotifyInterrupt_Forced(t *testing.T) {
+
+	if os.Getenv("NOTIFY_INTERRUPT_FORCED") == "1" {
+		interrupted, release := NotifyInterrupt()
+		defer release()
+
+		_ = syscall.Kill(os.Getpid(), syscall.SIGINT)
+		<-interrupted
+
+		// the default handling has been restored so this terminates the process
+		_ = syscall.Kill(os.Getpid(), syscall.SIGINT)
+		time.Sleep(5 * time.Second)
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestNotifyInterrupt_Forced")
+	cmd.Env = append(os.Environ(), "NOTIFY_INTERRUPT_FORCED=1")
+
+	err := cmd.Run()
+	exitErr, ok := err.(*exec.ExitError)
+	require.True(t, ok, "expected the process to be terminated: %v", err)
+
+	status := exitErr.Sys().(syscall.WaitStatus)
+	require.True(t, status.Signaled())
+	require.Equal(t, syscall.SIGINT, status.Signal())
+}
@@ -13,6 +13,17 @@ const (
 	TaskStopped = "STOPPED"
 	// TaskSucceeded task succeeded
 	TaskSucceeded = "SUCCEEDED"
+	// TaskSpotInterrupted task was stopped because the spot capacity it was running on was reclaimed
+	TaskSpotInterrupted = "SPOT_INTERRUPTED"
+
+	// CreatedByTagKey tag key applied to resources created by the launchers
+	CreatedByTagKey = "createdBy"
+	// CreatedByTagValue tag value applied to resources created by the launchers
+	CreatedByTagValue = "fargate-run-job"
+	// DefinedAtTagKey tag key recording when a task definition was registered, the ECS API doesn't return this
+	DefinedAtTagKey = "definedAt"
+	// LogGroupFormatTagKey tag key recording the log group name format configured for a definition
+	LogGroupFormatTagKey = "logGroupFormat"
 )
 
 var (
@@ -20,4 +31,17 @@ var (
 	ErrMissingParams = errors.New("service params are missing from Definition, configure either ECS or Codebuild")
 	// ErrInvalidParams either missing or configured more than service one parameters entry
 	ErrInvalidParams = errors.New("Requires only one service parameters entry, ecs or codebuild")
+	// ErrLogsNotAvailable the task isn't configured to write logs which can be read by the launcher
+	ErrLogsNotAvailable = errors.New("logs not available for this task")
+	// ErrTasksRunning cleanup was refused as tasks using the definition are still running
+	ErrTasksRunning = errors.New("tasks using the definition are still running")
+	// ErrInterrupted the run was interrupted and the task was stopped, callers should exit with ExitCodeInterrupted,
+	// the run result describing the stopped task is returned along with this error
+	ErrInterrupted = errors.New("run interrupted, task stopped")
 )
+
+// TaskFailure a task which failed to launch, or couldn't be described or stopped in a batch call
+type TaskFailure struct {
+	ID     string `json:"id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,192 @@
+package launcher
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LeaseOwnerTagKey tag key identifying the process which owns the lease on a task
+	LeaseOwnerTagKey = "leaseOwner"
+	// LeaseExpiresTagKey tag key holding the time the lease on a task expires, tags on a project use this
+	// key as a prefix followed by the owner as builds can't be tagged
+	LeaseExpiresTagKey = "leaseExpires"
+
+	// LeaseOwnerEnvVar build environment marker identifying the owner of the lease on a build
+	LeaseOwnerEnvVar = "AWS_LAUNCH_LEASE_OWNER"
+	// LeaseExpiresEnvVar build environment marker holding the time the initial lease on a build expires
+	LeaseExpiresEnvVar = "AWS_LAUNCH_LEASE_EXPIRES"
+
+	// DefaultLeaseDuration the time a lease lasts without being renewed
+	DefaultLeaseDuration = 5 * time.Minute
+)
+
+// LeaseConfig the owner of the tasks being launched, tasks are stopped by the reaper once the lease expires
+type LeaseConfig struct {
+	OwnerID string `json:"owner_id,omitempty" jsonschema:"required"`
+
+	// optional, defaults to DefaultLeaseDuration, the lease is renewed every third of the duration
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// LeaseDuration the configured duration or the default
+func (lc *LeaseConfig) LeaseDuration() time.Duration {
+	if lc.Duration <= 0 {
+		return DefaultLeaseDuration
+	}
+
+	return lc.Duration
+}
+
+// Expires the expiry of a lease taken or renewed at the supplied time
+func (lc *LeaseConfig) Expires(now time.Time) time.Time {
+	return now.Add(lc.LeaseDuration()).UTC()
+}
+
+// Tags the lease tags for a lease expiring at the supplied time
+func (lc *LeaseConfig) Tags(expires time.Time) map[string]string {
+	return map[string]string{
+		LeaseOwnerTagKey:   lc.OwnerID,
+		LeaseExpiresTagKey: FormatLeaseTime(expires),
+	}
+}
+
+// ProjectTagKey the project tag key holding the lease expiry of this owner
+func (lc *LeaseConfig) ProjectTagKey() string {
+	return LeaseExpiresTagKey + ":" + lc.OwnerID
+}
+
+// LeaseFromTags the owner and expiry of the lease in the tags, nil is returned for the expiry if there is no lease
+func LeaseFromTags(tags map[string]string) (string, *time.Time) {
+	return tags[LeaseOwnerTagKey], ParseLeaseTime(tags[LeaseExpiresTagKey])
+}
+
+// ProjectLeases the lease expiry of each owner recorded in the project tags
+func ProjectLeases(tags map[string]string) map[string]*time.Time {
+	leases := map[string]*time.Time{}
+
+	for k, v := range tags {
+		if !strings.HasPrefix(k, LeaseExpiresTagKey+":") {
+			continue
+		}
+
+		if expires := ParseLeaseTime(v); expires != nil {
+			leases[strings.TrimPrefix(k, LeaseExpiresTagKey+":")] = expires
+		}
+	}
+
+	return leases
+}
+
+// ProjectLeaseTags the lease tags in the project tags which haven't expired by now, tags which can't be parsed are dropped
+func ProjectLeaseTags(tags map[string]string, now time.Time) map[string]string {
+	leaseTags := map[string]string{}
+
+	for owner, expires := range ProjectLeases(tags) {
+		if expires.After(now) {
+			leaseTags[LeaseExpiresTagKey+":"+owner] = tags[LeaseExpiresTagKey+":"+owner]
+		}
+	}
+
+	return leaseTags
+}
+
+// PruneProjectLeases a copy of the project tags without the leases which expired before now, this keeps the
+// project under the tag limit as owners come and go
+func PruneProjectLeases(tags map[string]string, now time.Time) map[string]string {
+	pruned := map[string]string{}
+
+	for k, v := range tags {
+		if !strings.HasPrefix(k, LeaseExpiresTagKey+":") {
+			pruned[k] = v
+		}
+	}
+
+	for k, v := range ProjectLeaseTags(tags, now) {
+		pruned[k] = v
+	}
+
+	return pruned
+}
+
+// FormatLeaseTime format the lease expiry for use in tags and environment markers
+func FormatLeaseTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ParseLeaseTime parse a lease expiry, nil is returned if it is missing or invalid
+func ParseLeaseTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// RenewFunc extend the lease until the supplied expiry
+type RenewFunc func(expires time.Time) error
+
+// Renewer renews a lease in the background until it is stopped
+type Renewer struct {
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+// StartRenewer renew the lease every third of its duration, failed renewals are logged and retried on the
+// next interval, the most recent failure is returned by stop
+func StartRenewer(lc *LeaseConfig, renew RenewFunc) *Renewer {
+	r := &Renewer{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	interval := lc.LeaseDuration() / 3
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case now := <-ticker.C:
+				err := renew(lc.Expires(now))
+
+				r.mu.Lock()
+				r.err = err
+				r.mu.Unlock()
+
+				if err != nil {
+					logrus.WithError(err).WithField("OwnerID", lc.OwnerID).Warn("Renew lease failed")
+				}
+			}
+		}
+	}()
+
+	return r
+}
+
+// Stop renewing the lease, the lease is left to expire
+func (r *Renewer) Stop() error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+
+	<-r.done
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
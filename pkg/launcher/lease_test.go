@@ -0,0 +1,74 @@
+package launcher
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseConfig(t *testing.T) {
+	lc := &LeaseConfig{OwnerID: "worker-1"}
+	require.Equal(t, DefaultLeaseDuration, lc.LeaseDuration())
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := lc.Expires(now)
+	require.Equal(t, now.Add(DefaultLeaseDuration), expires)
+
+	owner, got := LeaseFromTags(lc.Tags(expires))
+	require.Equal(t, "worker-1", owner)
+	require.Equal(t, expires, got.UTC())
+
+	_, got = LeaseFromTags(map[string]string{})
+	require.Nil(t, got)
+
+	leases := ProjectLeases(map[string]string{
+		lc.ProjectTagKey():        FormatLeaseTime(expires),
+		LeaseExpiresTagKey + ":x": "invalid",
+		"team":                    "build",
+	})
+	require.Len(t, leases, 1)
+	require.Equal(t, expires, leases["worker-1"].UTC())
+}
+
+func TestPruneProjectLeases(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tags := map[string]string{
+		LeaseExpiresTagKey + ":worker-1": FormatLeaseTime(now.Add(time.Minute)),
+		LeaseExpiresTagKey + ":worker-2": FormatLeaseTime(now.Add(-time.Minute)),
+		LeaseExpiresTagKey + ":worker-3": "invalid",
+		"team":                           "build",
+	}
+
+	require.Equal(t, map[string]string{
+		LeaseExpiresTagKey + ":worker-1": FormatLeaseTime(now.Add(time.Minute)),
+	}, ProjectLeaseTags(tags, now))
+
+	require.Equal(t, map[string]string{
+		LeaseExpiresTagKey + ":worker-1": FormatLeaseTime(now.Add(time.Minute)),
+		"team":                           "build",
+	}, PruneProjectLeases(tags, now))
+}
+
+func TestStartRenewer(t *testing.T) {
+	var calls int32
+
+	lc := &LeaseConfig{OwnerID: "worker-1", Duration: 30 * time.Millisecond}
+
+	r := StartRenewer(lc, func(expires time.Time) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("renew failed")
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	err := r.Stop()
+	require.EqualError(t, err, "renew failed")
+	require.True(t, atomic.LoadInt32(&calls) > 0)
+
+	// stop can be called more than once
+	require.EqualError(t, r.Stop(), "renew failed")
+}
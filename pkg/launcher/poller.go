@@ -0,0 +1,567 @@
+package launcher
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxStatusBatchSize the most tasks which can be described in a single DescribeTasks or BatchGetBuilds call
+	MaxStatusBatchSize = 100
+
+	// DefaultMinPollInterval the shortest interval between polls
+	DefaultMinPollInterval = 2 * time.Second
+
+	// DefaultMaxPollInterval the longest interval between polls, reached when there are many tasks or calls are throttled
+	DefaultMaxPollInterval = 30 * time.Second
+
+	// DefaultFallbackPollInterval the interval between polls while another source, such as events, is providing updates
+	DefaultFallbackPollInterval = 60 * time.Second
+
+	// DefaultPollRequestsPerSecond the rate of status calls the poller tries to stay under as the number of tasks grows
+	DefaultPollRequestsPerSecond = 2.0
+
+	// DefaultMaxWait the longest a wait for tasks to finish blocks before giving up, this matches the
+	// 100 attempts 6 seconds apart made by the SDK waiters
+	DefaultMaxWait = 10 * time.Minute
+)
+
+var (
+	// ErrWaitTimeout the task didn't finish before the wait timed out
+	ErrWaitTimeout = errors.New("timed out waiting for task to finish")
+)
+
+// StatusUpdate the status of a task as reported by a status source
+type StatusUpdate struct {
+	ID         string     `json:"id,omitempty"`
+	TaskStatus string     `json:"task_status,omitempty"`
+	LastStatus string     `json:"last_status,omitempty"`
+	StopCode   string     `json:"stop_code,omitempty"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+
+	// optional, when the backend recorded the current status of the task
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+
+	// optional, backend specific details of the task
+	Details map[string]string `json:"details,omitempty"`
+
+	// set when the status of the task couldn't be retrieved
+	Err error `json:"-"`
+}
+
+// LatestTime the latest of the times which are set, nil if none are set
+func LatestTime(times ...*time.Time) *time.Time {
+	var latest *time.Time
+
+	for _, t := range times {
+		if t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// Done the task has finished running
+func (su *StatusUpdate) Done() bool {
+	return su.TaskStatus != "" && su.TaskStatus != TaskRunning
+}
+
+// StatusFetcher retrieve the status of a batch of tasks which share a batch key, for ECS this is the cluster
+type StatusFetcher interface {
+	FetchStatuses(batchKey string, ids []string) ([]*StatusUpdate, error)
+}
+
+// WithDoneHook wrap the fetcher so the hook is called with the id of each task it reports as done
+func WithDoneHook(fetcher StatusFetcher, hook func(id string)) StatusFetcher {
+	return &doneHookFetcher{fetcher: fetcher, hook: hook}
+}
+
+type doneHookFetcher struct {
+	fetcher StatusFetcher
+	hook    func(id string)
+}
+
+func (dhf *doneHookFetcher) FetchStatuses(batchKey string, ids []string) ([]*StatusUpdate, error) {
+	updates, err := dhf.fetcher.FetchStatuses(batchKey, ids)
+
+	for _, su := range updates {
+		if su.Err == nil && su.Done() {
+			dhf.hook(su.ID)
+		}
+	}
+
+	return updates, err
+}
+
+// PollerConfig tune the poller, zero values are replaced with the defaults
+type PollerConfig struct {
+	MinInterval       time.Duration `json:"min_interval,omitempty"`
+	MaxInterval       time.Duration `json:"max_interval,omitempty"`
+	BatchSize         int           `json:"batch_size,omitempty"`
+	RequestsPerSecond float64       `json:"requests_per_second,omitempty"`
+	FallbackInterval  time.Duration `json:"fallback_interval,omitempty"`
+}
+
+// Poller polls the status of all the tasks with subscribers using as few calls as possible, then fans
+// the results out to the subscribers of each task
+type Poller struct {
+	fetcher StatusFetcher
+	cfg     PollerConfig
+
+	mu sync.Mutex
+	// targets indexed by task id then batch key, the same id may be polled under more than one batch key
+	targets  map[string]map[string]*pollTarget
+	running  bool
+	backoff  time.Duration
+	fallback bool
+}
+
+type pollTarget struct {
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives status updates for a task, each change in status is queued until it is received so no
+// transitions are lost when the receiver falls behind, repeats of the last queued status are dropped
+type Subscription struct {
+	C <-chan *StatusUpdate
+
+	ch       chan *StatusUpdate
+	batchKey string
+	id       string
+	p        *Poller
+	once     sync.Once
+
+	mu     sync.Mutex
+	queue  []*StatusUpdate
+	last   *StatusUpdate
+	notify chan struct{}
+	done   chan struct{}
+}
+
+// NewPoller create a poller which uses the fetcher to retrieve task status, a nil config uses the defaults
+func NewPoller(fetcher StatusFetcher, cfg *PollerConfig) *Poller {
+	p := &Poller{
+		fetcher: fetcher,
+		targets: map[string]map[string]*pollTarget{},
+	}
+
+	if cfg != nil {
+		p.cfg = *cfg
+	}
+
+	if p.cfg.MinInterval == 0 {
+		p.cfg.MinInterval = DefaultMinPollInterval
+	}
+
+	if p.cfg.MaxInterval == 0 {
+		p.cfg.MaxInterval = DefaultMaxPollInterval
+	}
+
+	if p.cfg.BatchSize <= 0 || p.cfg.BatchSize > MaxStatusBatchSize {
+		p.cfg.BatchSize = MaxStatusBatchSize
+	}
+
+	if p.cfg.RequestsPerSecond <= 0 {
+		p.cfg.RequestsPerSecond = DefaultPollRequestsPerSecond
+	}
+
+	if p.cfg.FallbackInterval == 0 {
+		p.cfg.FallbackInterval = DefaultFallbackPollInterval
+	}
+
+	return p
+}
+
+// Subscribe to status updates for the task, polling starts with the first subscriber and stops once
+// all subscriptions are cancelled
+func (p *Poller) Subscribe(batchKey, id string) *Subscription {
+	sub := newSubscription(p, batchKey, id)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.targets[id] == nil {
+		p.targets[id] = map[string]*pollTarget{}
+	}
+
+	target, ok := p.targets[id][batchKey]
+	if !ok {
+		target = &pollTarget{subs: map[*Subscription]struct{}{}}
+		p.targets[id][batchKey] = target
+	}
+
+	target.subs[sub] = struct{}{}
+
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+
+	return sub
+}
+
+// WaitAll wait for all the tasks to finish, returning the final update for each task in the order of the
+// ids along with the tasks which failed, tasks which haven't finished once the timeout passes are failed
+// with ErrWaitTimeout
+func (p *Poller) WaitAll(batchKey string, ids []string, timeout time.Duration) ([]*StatusUpdate, []*TaskFailure) {
+	finalCh := make(chan *StatusUpdate, len(ids))
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	for _, id := range ids {
+		sub := p.Subscribe(batchKey, id)
+
+		go func(sub *Subscription) {
+			defer sub.Cancel()
+
+			for {
+				select {
+				case su := <-sub.C:
+					if su.Err != nil || su.Done() {
+						finalCh <- su
+						return
+					}
+				case <-doneCh:
+					return
+				}
+			}
+		}(sub)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	final := map[string]*StatusUpdate{}
+
+wait:
+	for range ids {
+		select {
+		case su := <-finalCh:
+			final[su.ID] = su
+		case <-timer.C:
+			break wait
+		}
+	}
+
+	updates := []*StatusUpdate{}
+	failures := []*TaskFailure{}
+
+	for _, id := range ids {
+		su, ok := final[id]
+		if !ok {
+			failures = append(failures, &TaskFailure{ID: id, Reason: ErrWaitTimeout.Error()})
+			continue
+		}
+
+		if su.Err != nil {
+			failures = append(failures, &TaskFailure{ID: id, Reason: su.Err.Error()})
+			continue
+		}
+
+		updates = append(updates, su)
+	}
+
+	return updates, failures
+}
+
+// WaitTimeout wait for the task to finish, ErrWaitTimeout is returned if the timeout passes first
+func (p *Poller) WaitTimeout(batchKey, id string, timeout time.Duration) (*StatusUpdate, error) {
+	sub := p.Subscribe(batchKey, id)
+	defer sub.Cancel()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case su := <-sub.C:
+			if su.Err != nil {
+				return nil, su.Err
+			}
+
+			if su.Done() {
+				return su, nil
+			}
+		case <-timer.C:
+			return nil, ErrWaitTimeout
+		}
+	}
+}
+
+func newSubscription(p *Poller, batchKey, id string) *Subscription {
+	ch := make(chan *StatusUpdate)
+
+	sub := &Subscription{
+		C:        ch,
+		ch:       ch,
+		batchKey: batchKey,
+		id:       id,
+		p:        p,
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	go sub.deliver()
+
+	return sub
+}
+
+// Cancel stop receiving updates, the task is no longer polled once it has no subscribers
+func (s *Subscription) Cancel() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.p.mu.Lock()
+		defer s.p.mu.Unlock()
+
+		target, ok := s.p.targets[s.id][s.batchKey]
+		if !ok {
+			return
+		}
+
+		delete(target.subs, s)
+
+		if len(target.subs) == 0 {
+			delete(s.p.targets[s.id], s.batchKey)
+		}
+
+		if len(s.p.targets[s.id]) == 0 {
+			delete(s.p.targets, s.id)
+		}
+	})
+}
+
+// SetFallback while enabled another source is publishing updates so polling slows to the fallback interval
+func (p *Poller) SetFallback(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.fallback = enabled
+}
+
+// Publish send an update to the subscribers of the task under every batch key, this allows other status
+// sources to feed the poller, returns true if the task had any subscribers
+func (p *Poller) Publish(su *StatusUpdate) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	matched := false
+
+	for _, target := range p.targets[su.ID] {
+		for sub := range target.subs {
+			sub.send(su)
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// publishBatch send an update to the subscribers of the task which used the batch key
+func (p *Poller) publishBatch(batchKey string, su *StatusUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	target, ok := p.targets[su.ID][batchKey]
+	if !ok {
+		return
+	}
+
+	for sub := range target.subs {
+		sub.send(su)
+	}
+}
+
+// send queue the update unless it repeats the last queued status
+func (s *Subscription) send(su *StatusUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last != nil && sameStatus(s.last, su) {
+		return
+	}
+
+	s.queue = append(s.queue, su)
+	s.last = su
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// deliver pass the queued updates to the receiver in order until the subscription is cancelled
+func (s *Subscription) deliver() {
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+
+			select {
+			case <-s.notify:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		su := s.queue[0]
+		s.mu.Unlock()
+
+		select {
+		case s.ch <- su:
+		case <-s.done:
+			return
+		}
+
+		s.mu.Lock()
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+	}
+}
+
+// sameStatus the updates differ only in when they were retrieved, errors are never treated as repeats
+func sameStatus(a, b *StatusUpdate) bool {
+	if a.Err != nil || b.Err != nil {
+		return false
+	}
+
+	return a.TaskStatus == b.TaskStatus &&
+		a.LastStatus == b.LastStatus &&
+		a.StopCode == b.StopCode &&
+		reflect.DeepEqual(a.Details, b.Details)
+}
+
+func (p *Poller) run() {
+	for {
+		calls, throttled := p.poll()
+
+		p.mu.Lock()
+
+		if len(p.targets) == 0 {
+			p.running = false
+			p.mu.Unlock()
+			return
+		}
+
+		p.backoff = nextBackoff(p.cfg, p.backoff, throttled)
+		interval := pollInterval(p.cfg, calls, p.backoff)
+
+		if p.fallback && interval < p.cfg.FallbackInterval {
+			interval = p.cfg.FallbackInterval
+		}
+
+		p.mu.Unlock()
+
+		logrus.WithFields(logrus.Fields{
+			"Calls":     calls,
+			"Throttled": throttled,
+			"Interval":  interval,
+		}).Debug("Poll status")
+
+		time.Sleep(interval)
+	}
+}
+
+// poll fetch the status of all the tasks with subscribers grouped by batch key, returns the number of calls made
+func (p *Poller) poll() (int, bool) {
+	batches := map[string][]string{}
+
+	p.mu.Lock()
+	for id, byBatch := range p.targets {
+		for batchKey := range byBatch {
+			batches[batchKey] = append(batches[batchKey], id)
+		}
+	}
+	p.mu.Unlock()
+
+	calls := 0
+	throttled := false
+
+	for batchKey, ids := range batches {
+		sort.Strings(ids)
+
+		for _, chunk := range ChunkIDs(ids, p.cfg.BatchSize) {
+			calls++
+
+			updates, err := p.fetcher.FetchStatuses(batchKey, chunk)
+			if err != nil {
+				// throttled calls are retried on the next poll with a longer interval
+				if request.IsErrorThrottle(errors.Cause(err)) {
+					throttled = true
+					continue
+				}
+
+				for _, id := range chunk {
+					p.publishBatch(batchKey, &StatusUpdate{ID: id, Err: err})
+				}
+				continue
+			}
+
+			for _, su := range updates {
+				p.publishBatch(batchKey, su)
+			}
+		}
+	}
+
+	return calls, throttled
+}
+
+// ChunkIDs split the ids into chunks of at most size ids, a size less than one uses MaxStatusBatchSize
+func ChunkIDs(ids []string, size int) [][]string {
+	chunks := [][]string{}
+
+	if size <= 0 {
+		size = MaxStatusBatchSize
+	}
+
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+
+	return chunks
+}
+
+// pollInterval space out polls so the call rate stays under the configured requests per second
+func pollInterval(cfg PollerConfig, calls int, backoff time.Duration) time.Duration {
+	interval := time.Duration(float64(calls)/cfg.RequestsPerSecond*float64(time.Second)) + backoff
+
+	if interval < cfg.MinInterval {
+		return cfg.MinInterval
+	}
+
+	if interval > cfg.MaxInterval {
+		return cfg.MaxInterval
+	}
+
+	return interval
+}
+
+// nextBackoff double the backoff while calls are throttled and halve it once they succeed
+func nextBackoff(cfg PollerConfig, backoff time.Duration, throttled bool) time.Duration {
+	if !throttled {
+		return backoff / 2
+	}
+
+	if backoff < cfg.MinInterval {
+		return cfg.MinInterval
+	}
+
+	if backoff*2 > cfg.MaxInterval {
+		return cfg.MaxInterval
+	}
+
+	return backoff * 2
+}
@@ -0,0 +1,190 @@
+package launcher
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	mu      sync.Mutex
+	batches map[string][]int
+}
+
+func (ff *fakeFetcher) FetchStatuses(batchKey string, ids []string) ([]*StatusUpdate, error) {
+	ff.mu.Lock()
+	ff.batches[batchKey] = append(ff.batches[batchKey], len(ids))
+	ff.mu.Unlock()
+
+	updates := make([]*StatusUpdate, len(ids))
+	for n, id := range ids {
+		updates[n] = &StatusUpdate{ID: id, TaskStatus: TaskSucceeded}
+	}
+
+	return updates, nil
+}
+
+func TestPoller_Batches(t *testing.T) {
+
+	ff := &fakeFetcher{batches: map[string][]int{}}
+	p := NewPoller(ff, &PollerConfig{MinInterval: time.Hour})
+
+	subs := []*Subscription{}
+
+	p.mu.Lock()
+	p.running = true // hold off polling until all the subscriptions are added
+	p.mu.Unlock()
+
+	for n := 0; n < 250; n++ {
+		subs = append(subs, p.Subscribe("cluster-a", fmt.Sprintf("a-%03d", n)))
+	}
+	for n := 0; n < 3; n++ {
+		subs = append(subs, p.Subscribe("cluster-b", fmt.Sprintf("b-%03d", n)))
+	}
+
+	calls, throttled := p.poll()
+	require.Equal(t, 4, calls)
+	require.False(t, throttled)
+	require.Equal(t, map[string][]int{"cluster-a": {100, 100, 50}, "cluster-b": {3}}, ff.batches)
+
+	for _, sub := range subs {
+		su := <-sub.C
+		require.Equal(t, sub.id, su.ID)
+		require.True(t, su.Done())
+		sub.Cancel()
+	}
+
+	require.Empty(t, p.targets)
+}
+
+func TestPoller_Subscribe(t *testing.T) {
+
+	ff := &fakeFetcher{batches: map[string][]int{}}
+	p := NewPoller(ff, nil)
+
+	sub1 := p.Subscribe("cluster-a", "abc123")
+	sub2 := p.Subscribe("cluster-a", "abc123")
+
+	require.Equal(t, TaskSucceeded, (<-sub1.C).TaskStatus)
+	require.Equal(t, TaskSucceeded, (<-sub2.C).TaskStatus)
+
+	sub1.Cancel()
+	sub2.Cancel()
+}
+
+func TestPoller_Subscribe_BatchKeys(t *testing.T) {
+
+	ff := &fakeFetcher{batches: map[string][]int{}}
+	p := NewPoller(ff, &PollerConfig{MinInterval: time.Hour})
+
+	p.mu.Lock()
+	p.running = true // poll manually
+	p.mu.Unlock()
+
+	// the same id in two clusters is polled in both
+	sub1 := p.Subscribe("cluster-a", "abc123")
+	sub2 := p.Subscribe("cluster-b", "abc123")
+
+	calls, _ := p.poll()
+	require.Equal(t, 2, calls)
+	require.Equal(t, map[string][]int{"cluster-a": {1}, "cluster-b": {1}}, ff.batches)
+
+	require.Equal(t, TaskSucceeded, (<-sub1.C).TaskStatus)
+	require.Equal(t, TaskSucceeded, (<-sub2.C).TaskStatus)
+
+	sub1.Cancel()
+	require.Len(t, p.targets["abc123"], 1)
+
+	sub2.Cancel()
+	require.Empty(t, p.targets)
+}
+
+func TestPoller_WaitAll_Timeout(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		p.Publish(&StatusUpdate{ID: "abc1", TaskStatus: TaskSucceeded})
+	}()
+
+	updates, failures := p.WaitAll("cluster-a", []string{"abc1", "abc2"}, 50*time.Millisecond)
+	require.Len(t, updates, 1)
+	require.Equal(t, "abc1", updates[0].ID)
+	require.Equal(t, []*TaskFailure{{ID: "abc2", Reason: ErrWaitTimeout.Error()}}, failures)
+}
+
+func TestPoller_WaitTimeout(t *testing.T) {
+
+	p := NewPoller(&fakeFetcher{batches: map[string][]int{}}, nil)
+
+	su, err := p.WaitTimeout("cluster-a", "abc123", time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, TaskSucceeded, su.TaskStatus)
+
+	p = NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+
+	su, err = p.WaitTimeout("cluster-a", "abc123", 10*time.Millisecond)
+	require.Equal(t, ErrWaitTimeout, err)
+	require.Nil(t, su)
+}
+
+func TestSubscription_Transitions(t *testing.T) {
+
+	p := NewPoller(&fakeFetcher{}, nil)
+
+	sub := newSubscription(p, "", "abc123")
+	defer sub.Cancel()
+
+	p.targets["abc123"] = map[string]*pollTarget{"": {subs: map[*Subscription]struct{}{sub: {}}}}
+
+	// every transition is queued while the receiver isn't reading, repeats are dropped
+	p.Publish(&StatusUpdate{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "PROVISIONING"})
+	p.Publish(&StatusUpdate{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "PROVISIONING"})
+	p.Publish(&StatusUpdate{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "RUNNING"})
+	p.Publish(&StatusUpdate{ID: "abc123", TaskStatus: TaskFailed, LastStatus: "STOPPED"})
+	p.Publish(&StatusUpdate{ID: "other", TaskStatus: TaskFailed})
+
+	require.Equal(t, "PROVISIONING", (<-sub.C).LastStatus)
+	require.Equal(t, "RUNNING", (<-sub.C).LastStatus)
+	require.Equal(t, "STOPPED", (<-sub.C).LastStatus)
+
+	select {
+	case su := <-sub.C:
+		t.Fatalf("unexpected update: %v", su)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestChunkIDs(t *testing.T) {
+
+	ids := []string{"a", "b", "c"}
+
+	require.Equal(t, [][]string{{"a", "b"}, {"c"}}, ChunkIDs(ids, 2))
+	require.Equal(t, [][]string{{"a", "b", "c"}}, ChunkIDs(ids, 0))
+	require.Equal(t, [][]string{{"a", "b", "c"}}, ChunkIDs(ids, -1))
+	require.Equal(t, [][]string{}, ChunkIDs(nil, 2))
+}
+
+func Test_pollInterval(t *testing.T) {
+
+	cfg := PollerConfig{MinInterval: 2 * time.Second, MaxInterval: 30 * time.Second, RequestsPerSecond: 2}
+
+	require.Equal(t, 2*time.Second, pollInterval(cfg, 1, 0))
+	require.Equal(t, 5*time.Second, pollInterval(cfg, 10, 0))
+	require.Equal(t, 7*time.Second, pollInterval(cfg, 10, 2*time.Second))
+	require.Equal(t, 30*time.Second, pollInterval(cfg, 200, 0))
+}
+
+func Test_nextBackoff(t *testing.T) {
+
+	cfg := PollerConfig{MinInterval: 2 * time.Second, MaxInterval: 30 * time.Second}
+
+	require.Equal(t, 2*time.Second, nextBackoff(cfg, 0, true))
+	require.Equal(t, 8*time.Second, nextBackoff(cfg, 4*time.Second, true))
+	require.Equal(t, 30*time.Second, nextBackoff(cfg, 20*time.Second, true))
+	require.Equal(t, 2*time.Second, nextBackoff(cfg, 4*time.Second, false))
+}
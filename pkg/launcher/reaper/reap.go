@@ -0,0 +1,678 @@
+package reaper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+	cblauncher "github.com/wolfeidau/aws-launch/pkg/launcher/codebuild"
+)
+
+// Reaper finds resources using the ownership tags applied by the launchers
+type Reaper struct {
+	ecsSvc       ecsiface.ECSAPI
+	codeBuildSvc codebuildiface.CodeBuildAPI
+	cwlogsSvc    cloudwatchlogsiface.CloudWatchLogsAPI
+	taggingSvc   resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+// NewReaper create a new reaper
+func NewReaper(cfgs ...*aws.Config) ReaperAPI {
+	sess := session.Must(session.NewSession(cfgs...))
+	return &Reaper{
+		ecsSvc:       ecs.New(sess),
+		codeBuildSvc: codebuild.New(sess),
+		cwlogsSvc:    cloudwatchlogs.New(sess),
+		taggingSvc:   resourcegroupstaggingapi.New(sess),
+	}
+}
+
+// Reap stop long running tasks and builds along with those whose lease has expired, then remove the task
+// definitions, projects and log groups which haven't been used within the TTL, on error the result still records
+// what was reaped before the failure
+func (r *Reaper) Reap(rp *ReapParams) (*ReapResult, error) {
+
+	if rp.TTL <= 0 && !rp.LeasesOnly {
+		return nil, errors.New("ttl is required.")
+	}
+
+	taskTTL := rp.TaskTTL
+	if taskTTL <= 0 {
+		taskTTL = rp.TTL
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-rp.TTL)
+	taskCutoff := now.Add(-taskTTL)
+
+	res := &ReapResult{DryRun: rp.DryRun}
+
+	usage := newDefinitionUsage()
+
+	// log groups written to by the task definitions and projects which are kept
+	logGroupsInUse := map[string]bool{}
+
+	err := r.reapTasks(rp, now, taskCutoff, usage, res)
+	if err != nil {
+		return res, err
+	}
+
+	err = r.reapProjects(rp, now, cutoff, taskCutoff, logGroupsInUse, res)
+	if err != nil {
+		return res, err
+	}
+
+	if rp.LeasesOnly {
+		return res, nil
+	}
+
+	err = r.reapTaskDefinitions(rp, cutoff, usage, logGroupsInUse, res)
+	if err != nil {
+		return res, err
+	}
+
+	err = r.reapLogGroups(rp, cutoff, logGroupsInUse, res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// reapTasks stop the long running or expired tasks, recording when each task definition revision was last used
+func (r *Reaper) reapTasks(rp *ReapParams, now, cutoff time.Time, usage *definitionUsage, res *ReapResult) error {
+
+	clusters := aws.StringSlice(rp.ClusterNames)
+
+	if len(clusters) == 0 {
+		var nextToken *string
+
+		for {
+			listRes, err := r.ecsSvc.ListClusters(&ecs.ListClustersInput{NextToken: nextToken})
+			if err != nil {
+				return errors.Wrap(err, "failed to list clusters.")
+			}
+
+			clusters = append(clusters, listRes.ClusterArns...)
+
+			nextToken = listRes.NextToken
+			if nextToken == nil {
+				break
+			}
+		}
+	}
+
+	filter := &launcher.TaskFilter{Tags: launcher.OwnershipTags(rp.Tags)}
+
+	for _, cluster := range clusters {
+		cluster := cluster
+
+		err := r.describeTasks(cluster, ecs.DesiredStatusRunning, func(task *ecs.Task) {
+			// every task counts towards the use of the definition, not just those created by the launchers
+			usage.record(task, true)
+
+			tags := convertECSTagsToMap(task.Tags)
+			if !filter.MatchTags(tags) {
+				return
+			}
+
+			lastUsed := task.StartedAt
+			if lastUsed == nil {
+				lastUsed = task.CreatedAt
+			}
+
+			// leased tasks are only stopped once the owner stops renewing the lease
+			_, expires := launcher.LeaseFromTags(tags)
+
+			if !expired(rp, now, cutoff, lastUsed, expires) {
+				return
+			}
+
+			if expires != nil {
+				lastUsed = expires
+			}
+
+			r.act(res, &Resource{Type: ResourceTask, ID: aws.StringValue(task.TaskArn), Action: ActionStop, LastUsed: lastUsed}, func() error {
+				_, err := r.ecsSvc.StopTask(&ecs.StopTaskInput{
+					Cluster: cluster,
+					Task:    task.TaskArn,
+					Reason:  aws.String(StopReason),
+				})
+				return err
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		if rp.LeasesOnly {
+			continue
+		}
+
+		// recently stopped tasks are only needed to find when the task definitions were last used
+		err = r.describeTasks(cluster, ecs.DesiredStatusStopped, func(task *ecs.Task) {
+			usage.record(task, false)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// describeTasks call fn with each of the tasks in the cluster with the desired status
+func (r *Reaper) describeTasks(cluster *string, desiredStatus string, fn func(task *ecs.Task)) error {
+
+	var nextToken *string
+
+	for {
+		listRes, err := r.ecsSvc.ListTasks(&ecs.ListTasksInput{
+			Cluster:       cluster,
+			DesiredStatus: aws.String(desiredStatus),
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list tasks.")
+		}
+
+		if len(listRes.TaskArns) > 0 {
+			descRes, err := r.ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
+				Cluster: cluster,
+				Tasks:   listRes.TaskArns,
+				Include: []*string{aws.String(ecs.TaskFieldTags)},
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to describe tasks.")
+			}
+
+			for _, task := range descRes.Tasks {
+				fn(task)
+			}
+		}
+
+		nextToken = listRes.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *Reaper) reapProjects(rp *ReapParams, now, cutoff, buildCutoff time.Time, logGroupsInUse map[string]bool, res *ReapResult) error {
+
+	mappings, err := r.getResources(ResourceProject, rp.Tags)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for _, m := range mappings {
+		names = append(names, projectNameFromArn(aws.StringValue(m.ResourceARN)))
+	}
+
+	for _, chunk := range launcher.ChunkIDs(names, launcher.MaxStatusBatchSize) {
+		projRes, err := r.codeBuildSvc.BatchGetProjects(&codebuild.BatchGetProjectsInput{
+			Names: aws.StringSlice(chunk),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to get projects.")
+		}
+
+		for _, project := range projRes.Projects {
+			lastUsed, running, err := r.reapBuilds(rp, project, now, buildCutoff, res)
+			if err != nil {
+				return err
+			}
+
+			if rp.LeasesOnly {
+				continue
+			}
+
+			if lastUsed == nil || (project.LastModified != nil && project.LastModified.After(*lastUsed)) {
+				lastUsed = project.LastModified
+			}
+
+			// projects with builds still running, including those just stopped, are left for a later run
+			if running || lastUsed == nil || !lastUsed.Before(cutoff) {
+				logGroupsInUse[projectLogGroup(project)] = true
+				continue
+			}
+
+			deleted := r.act(res, &Resource{Type: ResourceProject, ID: aws.StringValue(project.Arn), Action: ActionDelete, LastUsed: lastUsed}, func() error {
+				_, err := r.codeBuildSvc.DeleteProject(&codebuild.DeleteProjectInput{Name: project.Name})
+				return err
+			})
+			if !deleted {
+				logGroupsInUse[projectLogGroup(project)] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// reapBuilds stop the long running or expired builds in the most recent page of builds, returning the start time
+// of the latest build and whether any of the builds are still in progress
+func (r *Reaper) reapBuilds(rp *ReapParams, project *codebuild.Project, now, cutoff time.Time, res *ReapResult) (*time.Time, bool, error) {
+
+	listRes, err := r.codeBuildSvc.ListBuildsForProject(&codebuild.ListBuildsForProjectInput{
+		ProjectName: project.Name,
+		SortOrder:   aws.String(codebuild.SortOrderTypeDescending),
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to list builds.")
+	}
+
+	if len(listRes.Ids) == 0 {
+		return nil, false, nil
+	}
+
+	buildsRes, err := r.codeBuildSvc.BatchGetBuilds(&codebuild.BatchGetBuildsInput{Ids: listRes.Ids})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get builds.")
+	}
+
+	var lastUsed *time.Time
+
+	running := false
+
+	leases := launcher.ProjectLeases(convertCodebuildTagsToMap(project.Tags))
+
+	for _, build := range buildsRes.Builds {
+		if build.StartTime == nil {
+			continue
+		}
+
+		if lastUsed == nil || build.StartTime.After(*lastUsed) {
+			lastUsed = build.StartTime
+		}
+
+		if aws.BoolValue(build.BuildComplete) {
+			continue
+		}
+
+		running = true
+
+		expires := buildLeaseExpiry(build, leases)
+
+		if !expired(rp, now, cutoff, build.StartTime, expires) {
+			continue
+		}
+
+		buildLastUsed := build.StartTime
+		if expires != nil {
+			buildLastUsed = expires
+		}
+
+		r.act(res, &Resource{Type: ResourceBuild, ID: aws.StringValue(build.Id), Action: ActionStop, LastUsed: buildLastUsed}, func() error {
+			_, err := r.codeBuildSvc.StopBuild(&codebuild.StopBuildInput{Id: build.Id})
+			return err
+		})
+	}
+
+	return lastUsed, running, nil
+}
+
+// reapTaskDefinitions deregister the revisions which haven't been defined or used by a task within the TTL, the
+// latest active revision of each family and revisions with running tasks are always kept
+func (r *Reaper) reapTaskDefinitions(rp *ReapParams, cutoff time.Time, usage *definitionUsage, logGroupsInUse map[string]bool, res *ReapResult) error {
+
+	mappings, err := r.getResources(ResourceTaskDefinition, rp.Tags)
+	if err != nil {
+		return err
+	}
+
+	latestRevisions := map[string]string{}
+
+	for _, m := range mappings {
+		definitionArn := aws.StringValue(m.ResourceARN)
+
+		// deregistered revisions are still returned while they keep their tags
+		descRes, err := r.ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{TaskDefinition: m.ResourceARN})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe task definition.")
+		}
+
+		td := descRes.TaskDefinition
+
+		if aws.StringValue(td.Status) != ecs.TaskDefinitionStatusActive {
+			continue
+		}
+
+		// revisions registered before the defined at tag was added have an unknown age so are left alone
+		lastUsed := launcher.DefinedAt(convertTaggingTagsToMap(m.Tags))
+		if lastUsed != nil {
+			lastUsed = launcher.LatestTime(lastUsed, usage.lastUsed[definitionArn])
+		}
+
+		if lastUsed == nil || !lastUsed.Before(cutoff) || usage.running[definitionArn] {
+			keepLogGroups(logGroupsInUse, td)
+			continue
+		}
+
+		family := aws.StringValue(td.Family)
+
+		latest, ok := latestRevisions[family]
+		if !ok {
+			latest, err = r.latestRevision(family)
+			if err != nil {
+				return err
+			}
+
+			latestRevisions[family] = latest
+		}
+
+		if latest == definitionArn {
+			keepLogGroups(logGroupsInUse, td)
+			continue
+		}
+
+		deregistered := r.act(res, &Resource{Type: ResourceTaskDefinition, ID: definitionArn, Action: ActionDeregister, LastUsed: lastUsed}, func() error {
+			_, err := r.ecsSvc.DeregisterTaskDefinition(&ecs.DeregisterTaskDefinitionInput{TaskDefinition: m.ResourceARN})
+			return err
+		})
+		if !deregistered {
+			keepLogGroups(logGroupsInUse, td)
+		}
+	}
+
+	return nil
+}
+
+// latestRevision the ARN of the latest active revision of the family
+func (r *Reaper) latestRevision(family string) (string, error) {
+
+	listRes, err := r.ecsSvc.ListTaskDefinitions(&ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String(family),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+		MaxResults:   aws.Int64(1),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list task definitions.")
+	}
+
+	if len(listRes.TaskDefinitionArns) == 0 {
+		return "", nil
+	}
+
+	return aws.StringValue(listRes.TaskDefinitionArns[0]), nil
+}
+
+// reapLogGroups delete the log groups which haven't been written to within the TTL, log groups are only removed
+// once none of the task definitions or projects which write to them remain
+func (r *Reaper) reapLogGroups(rp *ReapParams, cutoff time.Time, logGroupsInUse map[string]bool, res *ReapResult) error {
+
+	mappings, err := r.getResources(ResourceLogGroup, rp.Tags)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range mappings {
+		name := logGroupNameFromArn(aws.StringValue(m.ResourceARN))
+
+		if logGroupsInUse[name] {
+			continue
+		}
+
+		logGroup, err := cwlogs.FindLogGroup(r.cwlogsSvc, name)
+		if err != nil {
+			return err
+		}
+
+		if logGroup == nil {
+			continue
+		}
+
+		lastUsed := timeFromMillis(logGroup.CreationTime)
+
+		streamsRes, err := r.cwlogsSvc.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName: aws.String(name),
+			OrderBy:      aws.String(cloudwatchlogs.OrderByLastEventTime),
+			Descending:   aws.Bool(true),
+			Limit:        aws.Int64(1),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe log streams.")
+		}
+
+		for _, stream := range streamsRes.LogStreams {
+			if lastEvent := timeFromMillis(stream.LastEventTimestamp); lastEvent != nil && (lastUsed == nil || lastEvent.After(*lastUsed)) {
+				lastUsed = lastEvent
+			}
+		}
+
+		if lastUsed == nil || !lastUsed.Before(cutoff) {
+			continue
+		}
+
+		r.act(res, &Resource{Type: ResourceLogGroup, ID: name, Action: ActionDelete, LastUsed: lastUsed}, func() error {
+			_, err := cwlogs.DeleteLogGroup(r.cwlogsSvc, name)
+			return err
+		})
+	}
+
+	return nil
+}
+
+// definitionUsage when each task definition revision was last used by a task, and whether it has tasks running
+type definitionUsage struct {
+	lastUsed map[string]*time.Time
+	running  map[string]bool
+}
+
+func newDefinitionUsage() *definitionUsage {
+	return &definitionUsage{
+		lastUsed: map[string]*time.Time{},
+		running:  map[string]bool{},
+	}
+}
+
+func (u *definitionUsage) record(task *ecs.Task, running bool) {
+	definitionArn := aws.StringValue(task.TaskDefinitionArn)
+
+	u.lastUsed[definitionArn] = launcher.LatestTime(u.lastUsed[definitionArn], task.CreatedAt, task.StartedAt, task.StoppedAt)
+
+	if running {
+		u.running[definitionArn] = true
+	}
+}
+
+// keepLogGroups mark the cloudwatch log groups the containers in the task definition write to as in use
+func keepLogGroups(logGroupsInUse map[string]bool, td *ecs.TaskDefinition) {
+	for _, cd := range td.ContainerDefinitions {
+		if cd.LogConfiguration == nil {
+			continue
+		}
+
+		if name := aws.StringValue(cd.LogConfiguration.Options["awslogs-group"]); name != "" {
+			logGroupsInUse[name] = true
+		}
+	}
+}
+
+// projectLogGroup the cloudwatch log group the project writes to, projects without a group name use the codebuild default
+func projectLogGroup(project *codebuild.Project) string {
+	if project.LogsConfig != nil && project.LogsConfig.CloudWatchLogs != nil {
+		if name := aws.StringValue(project.LogsConfig.CloudWatchLogs.GroupName); name != "" {
+			return name
+		}
+	}
+
+	return fmt.Sprintf(cblauncher.CodebuildLogGroupFormat, aws.StringValue(project.Name))
+}
+
+// expired a leased task or build has expired once the lease passes, otherwise it has run for longer than the TTL
+func expired(rp *ReapParams, now, cutoff time.Time, startedAt, leaseExpires *time.Time) bool {
+	if leaseExpires != nil {
+		return leaseExpires.Before(now)
+	}
+
+	if rp.LeasesOnly || startedAt == nil {
+		return false
+	}
+
+	return startedAt.Before(cutoff)
+}
+
+// buildLeaseExpiry the later of the expiry in the build environment markers and the renewal recorded on the project
+func buildLeaseExpiry(build *codebuild.Build, leases map[string]*time.Time) *time.Time {
+	if build.Environment == nil {
+		return nil
+	}
+
+	env := map[string]string{}
+	for _, ev := range build.Environment.EnvironmentVariables {
+		env[aws.StringValue(ev.Name)] = aws.StringValue(ev.Value)
+	}
+
+	owner, ok := env[launcher.LeaseOwnerEnvVar]
+	if !ok {
+		return nil
+	}
+
+	expires := launcher.ParseLeaseTime(env[launcher.LeaseExpiresEnvVar])
+
+	if renewed, ok := leases[owner]; ok && (expires == nil || renewed.After(*expires)) {
+		expires = renewed
+	}
+
+	return expires
+}
+
+// act record the resource and unless this is a dry run apply the action, returning false if the action failed
+func (r *Reaper) act(res *ReapResult, resource *Resource, apply func() error) bool {
+
+	res.Resources = append(res.Resources, resource)
+
+	logrus.WithFields(logrus.Fields{
+		"Type":   resource.Type,
+		"ID":     resource.ID,
+		"Action": resource.Action,
+		"DryRun": res.DryRun,
+	}).Info("Reap resource")
+
+	if res.DryRun {
+		return true
+	}
+
+	err := apply()
+	if err != nil {
+		resource.Error = err.Error()
+		return false
+	}
+
+	return true
+}
+
+// getResources list all the resources of the type with the ownership tags
+func (r *Reaper) getResources(resourceType string, tags map[string]string) ([]*resourcegroupstaggingapi.ResourceTagMapping, error) {
+
+	mappings := []*resourcegroupstaggingapi.ResourceTagMapping{}
+
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{resourceType}),
+		TagFilters:          buildTagFilters(launcher.OwnershipTags(tags)),
+	}
+
+	for {
+		getRes, err := r.taggingSvc.GetResources(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get tagged resources.")
+		}
+
+		mappings = append(mappings, getRes.ResourceTagMappingList...)
+
+		if aws.StringValue(getRes.PaginationToken) == "" {
+			break
+		}
+
+		input.PaginationToken = getRes.PaginationToken
+	}
+
+	return mappings, nil
+}
+
+func buildTagFilters(tags map[string]string) []*resourcegroupstaggingapi.TagFilter {
+	keys := []string{}
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	filters := []*resourcegroupstaggingapi.TagFilter{}
+	for _, k := range keys {
+		filters = append(filters, &resourcegroupstaggingapi.TagFilter{Key: aws.String(k), Values: aws.StringSlice([]string{tags[k]})})
+	}
+
+	return filters
+}
+
+// projectNameFromArn the project name from a project ARN, "arn:aws:codebuild:<region>:<account>:project/<name>"
+func projectNameFromArn(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// logGroupNameFromArn the log group name from a log group ARN, "arn:aws:logs:<region>:<account>:log-group:<name>:*"
+func logGroupNameFromArn(arn string) string {
+	name := arn[strings.Index(arn, ":log-group:")+len(":log-group:"):]
+	return strings.TrimSuffix(name, ":*")
+}
+
+func timeFromMillis(ms *int64) *time.Time {
+	if ms == nil {
+		return nil
+	}
+
+	t := time.Unix(0, aws.Int64Value(ms)*int64(time.Millisecond))
+
+	return &t
+}
+
+func convertTaggingTagsToMap(taggingTags []*resourcegroupstaggingapi.Tag) map[string]string {
+	tags := map[string]string{}
+
+	for _, tag := range taggingTags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tags
+}
+
+func convertCodebuildTagsToMap(codebuildTags []*codebuild.Tag) map[string]string {
+	tags := map[string]string{}
+
+	for _, tag := range codebuildTags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tags
+}
+
+func convertECSTagsToMap(ecsTags []*ecs.Tag) map[string]string {
+	tags := map[string]string{}
+
+	for _, tag := range ecsTags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tags
+}
@@ -0,0 +1,462 @@
+package reaper
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/awsmocks"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+)
+
+func resourceType(resourceType string) interface{} {
+	return mock.MatchedBy(func(in *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return aws.StringValue(in.ResourceTypeFilters[0]) == resourceType
+	})
+}
+
+func TestReaper_Reap(t *testing.T) {
+
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	taggingSvcMock := &awsmocks.ResourceGroupsTaggingAPIAPI{}
+
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+	}, nil)
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		DesiredStatus: aws.String(ecs.DesiredStatusStopped),
+	}).Return(&ecs.ListTasksOutput{}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:   aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				StartedAt: aws.Time(old),
+				Tags:      []*ecs.Tag{{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)}},
+			},
+			{
+				// not created by the launchers
+				TaskArn:   aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+				StartedAt: aws.Time(old),
+			},
+		},
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceProject)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1")},
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetProjects", &codebuild.BatchGetProjectsInput{
+		Names: aws.StringSlice([]string{"testing-1"}),
+	}).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Arn:          aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"),
+				Name:         aws.String("testing-1"),
+				LastModified: aws.Time(old),
+			},
+		},
+	}, nil)
+	codeBuildSvcMock.On("ListBuildsForProject", mock.AnythingOfType("*codebuild.ListBuildsForProjectInput")).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: aws.StringSlice([]string{"testing-1:abc1"}),
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{Id: aws.String("testing-1:abc1"), BuildComplete: aws.Bool(true), StartTime: aws.Time(old)},
+		},
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceTaskDefinition)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{
+				ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1"),
+				Tags:        []*resourcegroupstaggingapi.Tag{{Key: aws.String(launcher.DefinedAtTagKey), Value: aws.String(old.Format(time.RFC3339))}},
+			},
+			{
+				ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"),
+				Tags:        []*resourcegroupstaggingapi.Tag{{Key: aws.String(launcher.DefinedAtTagKey), Value: aws.String(recent.Format(time.RFC3339))}},
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1"),
+	}).Return(testTaskDefinition("test-command", 1, "/aws/fargate/test-command"), nil)
+	ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"),
+	}).Return(testTaskDefinition("test-command", 2, "/aws/fargate/test-command"), nil)
+	ecsSvcMock.On("ListTaskDefinitions", mock.AnythingOfType("*ecs.ListTaskDefinitionsInput")).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"}),
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceLogGroup)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/test-command:*")},
+			{ResourceARN: aws.String("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/gone-command:*")},
+		},
+	}, nil)
+	cwlogsSvcMock.On("DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/aws/fargate/gone-command"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []*cloudwatchlogs.LogGroup{
+			{LogGroupName: aws.String("/aws/fargate/gone-command"), CreationTime: aws.Int64(old.Unix() * 1000)},
+		},
+	}, nil)
+
+	// the log group is still being written to
+	cwlogsSvcMock.On("DescribeLogStreams", mock.AnythingOfType("*cloudwatchlogs.DescribeLogStreamsInput")).Return(&cloudwatchlogs.DescribeLogStreamsOutput{
+		LogStreams: []*cloudwatchlogs.LogStream{
+			{LastEventTimestamp: aws.Int64(recent.Unix() * 1000)},
+		},
+	}, nil)
+
+	r := &Reaper{
+		ecsSvc:       ecsSvcMock,
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    cwlogsSvcMock,
+		taggingSvc:   taggingSvcMock,
+	}
+
+	rp := &ReapParams{
+		TTL:          24 * time.Hour,
+		ClusterNames: []string{"test"},
+		DryRun:       true,
+	}
+
+	got, err := r.Reap(rp)
+	require.Nil(t, err)
+	require.True(t, got.DryRun)
+	require.Len(t, got.Resources, 3)
+	require.Equal(t, ResourceTask, got.Resources[0].Type)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", got.Resources[0].ID)
+	require.Equal(t, ResourceProject, got.Resources[1].Type)
+	require.Equal(t, ResourceTaskDefinition, got.Resources[2].Type)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1", got.Resources[2].ID)
+
+	// the log group is still used by revision 2
+	cwlogsSvcMock.AssertNotCalled(t, "DescribeLogGroups", &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/aws/fargate/test-command"),
+	})
+
+	ecsSvcMock.AssertNotCalled(t, "StopTask", mock.Anything)
+	ecsSvcMock.AssertNotCalled(t, "DeregisterTaskDefinition", mock.Anything)
+	codeBuildSvcMock.AssertNotCalled(t, "DeleteProject", mock.Anything)
+
+	ecsSvcMock.On("StopTask", &ecs.StopTaskInput{
+		Cluster: aws.String("test"),
+		Task:    aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+		Reason:  aws.String(StopReason),
+	}).Return(&ecs.StopTaskOutput{}, nil)
+	codeBuildSvcMock.On("DeleteProject", &codebuild.DeleteProjectInput{Name: aws.String("testing-1")}).Return(&codebuild.DeleteProjectOutput{}, nil)
+	ecsSvcMock.On("DeregisterTaskDefinition", &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1"),
+	}).Return(nil, awserr.New("ClientException", "task definition in use", nil))
+
+	rp.DryRun = false
+
+	got, err = r.Reap(rp)
+	require.Nil(t, err)
+	require.Len(t, got.Resources, 3)
+	require.Empty(t, got.Resources[0].Error)
+	require.Empty(t, got.Resources[1].Error)
+	require.NotEmpty(t, got.Resources[2].Error)
+}
+
+func TestReaper_Reap_Leases(t *testing.T) {
+
+	expired := time.Now().Add(-time.Minute)
+	renewed := time.Now().Add(time.Minute)
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	taggingSvcMock := &awsmocks.ResourceGroupsTaggingAPIAPI{}
+
+	ecsSvcMock.On("ListTasks", mock.AnythingOfType("*ecs.ListTasksInput")).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:   aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				StartedAt: aws.Time(time.Now()),
+				Tags: []*ecs.Tag{
+					{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)},
+					{Key: aws.String(launcher.LeaseOwnerTagKey), Value: aws.String("worker-1")},
+					{Key: aws.String(launcher.LeaseExpiresTagKey), Value: aws.String(launcher.FormatLeaseTime(expired))},
+				},
+			},
+			{
+				// no lease so it is left running
+				TaskArn:   aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+				StartedAt: aws.Time(time.Now().Add(-48 * time.Hour)),
+				Tags:      []*ecs.Tag{{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)}},
+			},
+		},
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceProject)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1")},
+		},
+	}, nil)
+	codeBuildSvcMock.On("BatchGetProjects", mock.AnythingOfType("*codebuild.BatchGetProjectsInput")).Return(&codebuild.BatchGetProjectsOutput{
+		Projects: []*codebuild.Project{
+			{
+				Arn:  aws.String("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"),
+				Name: aws.String("testing-1"),
+				Tags: []*codebuild.Tag{{Key: aws.String("leaseExpires:worker-2"), Value: aws.String(launcher.FormatLeaseTime(renewed))}},
+			},
+		},
+	}, nil)
+	codeBuildSvcMock.On("ListBuildsForProject", mock.AnythingOfType("*codebuild.ListBuildsForProjectInput")).Return(&codebuild.ListBuildsForProjectOutput{
+		Ids: aws.StringSlice([]string{"testing-1:abc1", "testing-1:abc2"}),
+	}, nil)
+	codeBuildSvcMock.On("BatchGetBuilds", mock.AnythingOfType("*codebuild.BatchGetBuildsInput")).Return(&codebuild.BatchGetBuildsOutput{
+		Builds: []*codebuild.Build{
+			{
+				Id:            aws.String("testing-1:abc1"),
+				BuildComplete: aws.Bool(false),
+				StartTime:     aws.Time(time.Now()),
+				Environment:   leaseEnvironment("worker-1", expired),
+			},
+			{
+				// the lease was renewed on the project
+				Id:            aws.String("testing-1:abc2"),
+				BuildComplete: aws.Bool(false),
+				StartTime:     aws.Time(time.Now()),
+				Environment:   leaseEnvironment("worker-2", expired),
+			},
+		},
+	}, nil)
+
+	r := &Reaper{
+		ecsSvc:       ecsSvcMock,
+		codeBuildSvc: codeBuildSvcMock,
+		taggingSvc:   taggingSvcMock,
+	}
+
+	got, err := r.Reap(&ReapParams{
+		LeasesOnly:   true,
+		ClusterNames: []string{"test"},
+		DryRun:       true,
+	})
+	require.Nil(t, err)
+	require.Len(t, got.Resources, 2)
+	require.Equal(t, ResourceTask, got.Resources[0].Type)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", got.Resources[0].ID)
+	require.Equal(t, ResourceBuild, got.Resources[1].Type)
+	require.Equal(t, "testing-1:abc1", got.Resources[1].ID)
+
+	taggingSvcMock.AssertNotCalled(t, "GetResources", resourceType(ResourceTaskDefinition))
+}
+
+func TestReaper_Reap_PartialFailure(t *testing.T) {
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	taggingSvcMock := &awsmocks.ResourceGroupsTaggingAPIAPI{}
+
+	ecsSvcMock.On("ListTasks", mock.AnythingOfType("*ecs.ListTasksInput")).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"}),
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", mock.AnythingOfType("*ecs.DescribeTasksInput")).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:   aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				StartedAt: aws.Time(time.Now().Add(-48 * time.Hour)),
+				Tags:      []*ecs.Tag{{Key: aws.String(launcher.CreatedByTagKey), Value: aws.String(launcher.CreatedByTagValue)}},
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("StopTask", mock.AnythingOfType("*ecs.StopTaskInput")).Return(&ecs.StopTaskOutput{}, nil)
+
+	// the projects can't be listed after the task has been stopped
+	taggingSvcMock.On("GetResources", resourceType(ResourceProject)).Return(nil, awserr.New("AccessDeniedException", "access denied", nil))
+
+	r := &Reaper{
+		ecsSvc:     ecsSvcMock,
+		taggingSvc: taggingSvcMock,
+	}
+
+	got, err := r.Reap(&ReapParams{
+		TTL:          24 * time.Hour,
+		ClusterNames: []string{"test"},
+	})
+	require.Error(t, err)
+	require.NotNil(t, got)
+	require.Len(t, got.Resources, 1)
+	require.Equal(t, ResourceTask, got.Resources[0].Type)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1", got.Resources[0].ID)
+	ecsSvcMock.AssertNumberOfCalls(t, "StopTask", 1)
+}
+
+func TestReaper_Reap_TaskDefinitions(t *testing.T) {
+
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+
+	ecsSvcMock := &awsmocks.ECSAPI{}
+	codeBuildSvcMock := &awsmocks.CodeBuildAPI{}
+	cwlogsSvcMock := &awsmocks.CloudWatchLogsAPI{}
+	taggingSvcMock := &awsmocks.ResourceGroupsTaggingAPIAPI{}
+
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"}),
+	}, nil)
+	ecsSvcMock.On("ListTasks", &ecs.ListTasksInput{
+		Cluster:       aws.String("test"),
+		DesiredStatus: aws.String(ecs.DesiredStatusStopped),
+	}).Return(&ecs.ListTasksOutput{
+		TaskArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"}),
+		Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				// not created by the launchers but still using revision 2
+				TaskArn:           aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"),
+				StartedAt:         aws.Time(time.Now()),
+			},
+		},
+	}, nil)
+	ecsSvcMock.On("DescribeTasks", &ecs.DescribeTasksInput{
+		Cluster: aws.String("test"),
+		Tasks:   aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"}),
+		Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+	}).Return(&ecs.DescribeTasksOutput{
+		Tasks: []*ecs.Task{
+			{
+				TaskArn:           aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc2"),
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/other-command:1"),
+				StartedAt:         aws.Time(old),
+				StoppedAt:         aws.Time(recent),
+			},
+		},
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceProject)).Return(&resourcegroupstaggingapi.GetResourcesOutput{}, nil)
+
+	definedAt := []*resourcegroupstaggingapi.Tag{{Key: aws.String(launcher.DefinedAtTagKey), Value: aws.String(old.Format(time.RFC3339))}}
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceTaskDefinition)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1"), Tags: definedAt},
+			{ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:2"), Tags: definedAt},
+			{ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3"), Tags: definedAt},
+			{ResourceARN: aws.String("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/other-command:1"), Tags: definedAt},
+		},
+	}, nil)
+	for _, td := range []*ecs.DescribeTaskDefinitionOutput{
+		testTaskDefinition("test-command", 1, "/aws/fargate/test-command"),
+		testTaskDefinition("test-command", 2, "/aws/fargate/test-command"),
+		testTaskDefinition("test-command", 3, "/aws/fargate/test-command"),
+		testTaskDefinition("other-command", 1, "/aws/fargate/other-command"),
+	} {
+		ecsSvcMock.On("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: td.TaskDefinition.TaskDefinitionArn,
+		}).Return(td, nil)
+	}
+	ecsSvcMock.On("ListTaskDefinitions", &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("test-command"),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+		MaxResults:   aws.Int64(1),
+	}).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: aws.StringSlice([]string{"arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:3"}),
+	}, nil)
+
+	taggingSvcMock.On("GetResources", resourceType(ResourceLogGroup)).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/test-command:*")},
+			{ResourceARN: aws.String("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/other-command:*")},
+		},
+	}, nil)
+
+	r := &Reaper{
+		ecsSvc:       ecsSvcMock,
+		codeBuildSvc: codeBuildSvcMock,
+		cwlogsSvc:    cwlogsSvcMock,
+		taggingSvc:   taggingSvcMock,
+	}
+
+	got, err := r.Reap(&ReapParams{
+		TTL:          24 * time.Hour,
+		ClusterNames: []string{"test"},
+		DryRun:       true,
+	})
+	require.Nil(t, err)
+
+	// revision 2 has a running task, revision 3 is the latest and other-command was used recently
+	require.Len(t, got.Resources, 1)
+	require.Equal(t, ResourceTaskDefinition, got.Resources[0].Type)
+	require.Equal(t, "arn:aws:ecs:ap-southeast-2:123456789012:task-definition/test-command:1", got.Resources[0].ID)
+
+	// both log groups are still used by the revisions which are kept
+	cwlogsSvcMock.AssertNotCalled(t, "DescribeLogGroups", mock.Anything)
+	ecsSvcMock.AssertNotCalled(t, "ListTaskDefinitions", &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("other-command"),
+		Status:       aws.String(ecs.TaskDefinitionStatusActive),
+		Sort:         aws.String(ecs.SortOrderDesc),
+		MaxResults:   aws.Int64(1),
+	})
+}
+
+func testTaskDefinition(family string, revision int64, logGroupName string) *ecs.DescribeTaskDefinitionOutput {
+	return &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			TaskDefinitionArn: aws.String(fmt.Sprintf("arn:aws:ecs:ap-southeast-2:123456789012:task-definition/%s:%d", family, revision)),
+			Family:            aws.String(family),
+			Revision:          aws.Int64(revision),
+			Status:            aws.String(ecs.TaskDefinitionStatusActive),
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String(family),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwslogs),
+						Options:   aws.StringMap(map[string]string{"awslogs-group": logGroupName}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func leaseEnvironment(owner string, expires time.Time) *codebuild.ProjectEnvironment {
+	return &codebuild.ProjectEnvironment{
+		EnvironmentVariables: []*codebuild.EnvironmentVariable{
+			{Name: aws.String(launcher.LeaseOwnerEnvVar), Value: aws.String(owner)},
+			{Name: aws.String(launcher.LeaseExpiresEnvVar), Value: aws.String(launcher.FormatLeaseTime(expires))},
+		},
+	}
+}
+
+func Test_logGroupNameFromArn(t *testing.T) {
+	require.Equal(t, "/aws/fargate/test-command", logGroupNameFromArn("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/test-command:*"))
+	require.Equal(t, "/aws/fargate/test-command", logGroupNameFromArn("arn:aws:logs:ap-southeast-2:123456789012:log-group:/aws/fargate/test-command"))
+}
+
+func Test_projectNameFromArn(t *testing.T) {
+	require.Equal(t, "testing-1", projectNameFromArn("arn:aws:codebuild:ap-southeast-2:123456789012:project/testing-1"))
+}
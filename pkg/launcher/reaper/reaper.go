@@ -0,0 +1,71 @@
+package reaper
+
+import (
+	"time"
+)
+
+const (
+	// ResourceTaskDefinition an ECS task definition revision
+	ResourceTaskDefinition = "ecs:task-definition"
+	// ResourceTask a running ECS task
+	ResourceTask = "ecs:task"
+	// ResourceProject a CodeBuild project
+	ResourceProject = "codebuild:project"
+	// ResourceBuild an in progress CodeBuild build
+	ResourceBuild = "codebuild:build"
+	// ResourceLogGroup a cloudwatch log group
+	ResourceLogGroup = "logs:log-group"
+
+	// ActionDeregister the task definition is deregistered
+	ActionDeregister = "deregister"
+	// ActionDelete the project or log group is deleted
+	ActionDelete = "delete"
+	// ActionStop the task or build is stopped
+	ActionStop = "stop"
+
+	// StopReason recorded against the ECS tasks stopped by the reaper
+	StopReason = "stopped by the aws-launch reaper"
+)
+
+// ReaperAPI find and remove the resources created by the launchers which are no longer in use
+type ReaperAPI interface {
+	Reap(*ReapParams) (*ReapResult, error)
+}
+
+// ReapParams resources tagged by the launchers which haven't been defined or used within the TTL are removed,
+// tasks and builds with an expired lease are always stopped
+type ReapParams struct {
+	TTL time.Duration `json:"ttl,omitempty" jsonschema:"required"`
+
+	// optional, only stop the tasks and builds with an expired lease, the TTL isn't required
+	LeasesOnly bool `json:"leases_only,omitempty"`
+
+	// optional, tasks and builds running for longer than this are stopped, defaults to the TTL
+	TaskTTL time.Duration `json:"task_ttl,omitempty"`
+
+	// optional, the clusters checked for long running tasks, defaults to all clusters
+	ClusterNames []string `json:"cluster_names,omitempty"`
+
+	// optional, resources must also have these tags
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// optional, report what would be removed without changing anything
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ReapResult report of the resources which were, or in a dry run would be, removed
+type ReapResult struct {
+	DryRun    bool        `json:"dry_run,omitempty"`
+	Resources []*Resource `json:"resources,omitempty"`
+}
+
+// Resource a resource found by the reaper along with the action taken
+type Resource struct {
+	Type     string     `json:"type,omitempty"`
+	ID       string     `json:"id,omitempty"`
+	Action   string     `json:"action,omitempty"`
+	LastUsed *time.Time `json:"last_used,omitempty"`
+
+	// set when the action failed, the reaper carries on with the remaining resources
+	Error string `json:"error,omitempty"`
+}
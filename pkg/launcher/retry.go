@@ -0,0 +1,178 @@
+package launcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+)
+
+const (
+	// DefaultRetryBackoff the delay before the first retry, this doubles after each attempt
+	DefaultRetryBackoff = 10 * time.Second
+	// DefaultMaxRetryBackoff the longest delay between attempts
+	DefaultMaxRetryBackoff = 5 * time.Minute
+
+	// StatusLaunchFailed the status of an attempt which couldn't be launched or placed, such as when Fargate Spot
+	// capacity is unavailable or the CodeBuild account limit is reached
+	StatusLaunchFailed = "LAUNCH_FAILED"
+)
+
+// RetryPolicy run the task again when it fails for one of the retryable reasons
+type RetryPolicy struct {
+	// the most attempts including the first
+	MaxAttempts int `json:"max_attempts,omitempty" jsonschema:"required"`
+
+	// optional, defaults to DefaultRetryBackoff
+	Backoff time.Duration `json:"backoff,omitempty"`
+	// optional, defaults to DefaultMaxRetryBackoff
+	MaxBackoff time.Duration `json:"max_backoff,omitempty"`
+
+	// optional, ECS stop codes such as SpotInterruption or TaskFailedToStart
+	StopCodes []string `json:"stop_codes,omitempty"`
+	// optional, task statuses or backend statuses such as the CodeBuild FAULT and TIMED_OUT build statuses, include
+	// StatusLaunchFailed to retry tasks which couldn't be launched or placed
+	Statuses []string `json:"statuses,omitempty"`
+	// optional, exit codes of the container or build command
+	ExitCodes []int64 `json:"exit_codes,omitempty"`
+}
+
+// RunAttempt the outcome of a single attempt at running a task
+type RunAttempt struct {
+	Attempt int `json:"attempt,omitempty"`
+
+	ID         string     `json:"id,omitempty"`
+	TaskStatus string     `json:"task_status,omitempty"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+
+	// the ECS last status or CodeBuild build status, StatusLaunchFailed when the task couldn't be launched
+	Status   string `json:"status,omitempty"`
+	StopCode string `json:"stop_code,omitempty"`
+	ExitCode *int64 `json:"exit_code,omitempty"`
+	// why the task couldn't be launched
+	Reason string `json:"reason,omitempty"`
+
+	// the handle of the task which can be used to get or export the logs of this attempt
+	Handle        string `json:"handle,omitempty"`
+	LogGroupName  string `json:"log_group_name,omitempty"`
+	LogStreamName string `json:"log_stream_name,omitempty"`
+	LogLineCount  int64  `json:"log_line_count,omitempty"`
+}
+
+// LaunchFailedAttempt an attempt for a task which couldn't be launched or placed
+func LaunchFailedAttempt(err error) *RunAttempt {
+	return &RunAttempt{TaskStatus: TaskFailed, Status: StatusLaunchFailed, Reason: err.Error()}
+}
+
+// Retryable the attempt failed for one of the retryable reasons
+func (rp *RetryPolicy) Retryable(ra *RunAttempt) bool {
+	if ra.TaskStatus == TaskSucceeded {
+		return false
+	}
+
+	for _, code := range rp.StopCodes {
+		if code == ra.StopCode {
+			return true
+		}
+	}
+
+	for _, status := range rp.Statuses {
+		if status == ra.Status || status == ra.TaskStatus {
+			return true
+		}
+	}
+
+	if ra.ExitCode != nil {
+		for _, code := range rp.ExitCodes {
+			if code == *ra.ExitCode {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Delay the backoff before the attempt following the supplied attempt
+func (rp *RetryPolicy) Delay(attempt int) time.Duration {
+	backoff := rp.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	maxBackoff := rp.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxRetryBackoff
+	}
+
+	for n := 1; n < attempt && backoff < maxBackoff; n++ {
+		backoff *= 2
+	}
+
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
+
+// WriteAttemptBoundary write a line to the sinks before the logs of a retry, so the output of each attempt can be
+// told apart when they are written to the same sinks
+func WriteAttemptBoundary(attempt int, taskID string, sinks ...cwlogs.LogSink) error {
+	line := &cwlogs.LogLine{
+		Timestamp: time.Now(),
+		Message:   fmt.Sprintf("--- attempt %d: %s", attempt, taskID),
+		TaskID:    taskID,
+		Attempt:   attempt,
+	}
+
+	for _, sink := range sinks {
+		err := sink.WriteLogLines([]*cwlogs.LogLine{line})
+		if err != nil {
+			return errors.Wrap(err, "failed to write logs to sink")
+		}
+	}
+
+	return nil
+}
+
+// RunFunc run a single attempt, the attempt is returned with any error which ended the run
+type RunFunc func(attempt int) (*RunAttempt, error)
+
+// Retry run attempts until one succeeds, fails for a reason which isn't retryable or the attempts are used up,
+// errors aren't retried, a nil policy runs a single attempt
+func Retry(rp *RetryPolicy, run RunFunc) ([]*RunAttempt, error) {
+
+	attempts := []*RunAttempt{}
+
+	for n := 1; ; n++ {
+		ra, err := run(n)
+		if ra != nil {
+			ra.Attempt = n
+			attempts = append(attempts, ra)
+		}
+
+		if err != nil || ra == nil {
+			return attempts, err
+		}
+
+		if rp == nil || n >= rp.MaxAttempts || !rp.Retryable(ra) {
+			return attempts, nil
+		}
+
+		delay := rp.Delay(n)
+
+		logrus.WithFields(logrus.Fields{
+			"ID":       ra.ID,
+			"Attempt":  n,
+			"StopCode": ra.StopCode,
+			"Status":   ra.Status,
+			"Delay":    delay,
+		}).Warn("retrying failed task")
+
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,72 @@
+package launcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	rp := &RetryPolicy{
+		StopCodes: []string{"SpotInterruption"},
+		Statuses:  []string{"FAULT"},
+		ExitCodes: []int64{75},
+	}
+
+	require.True(t, rp.Retryable(&RunAttempt{TaskStatus: TaskFailed, StopCode: "SpotInterruption"}))
+	require.True(t, rp.Retryable(&RunAttempt{TaskStatus: TaskFailed, Status: "FAULT"}))
+	require.True(t, rp.Retryable(&RunAttempt{TaskStatus: TaskFailed, ExitCode: aws.Int64(75)}))
+	require.False(t, rp.Retryable(&RunAttempt{TaskStatus: TaskFailed, ExitCode: aws.Int64(1)}))
+	require.False(t, rp.Retryable(&RunAttempt{TaskStatus: TaskSucceeded, ExitCode: aws.Int64(75)}))
+
+	// launch failures are only retried when the policy includes them
+	launchFailed := LaunchFailedAttempt(errors.New("failed to place task: Capacity is unavailable at this time."))
+	require.False(t, rp.Retryable(launchFailed))
+	require.True(t, (&RetryPolicy{Statuses: []string{StatusLaunchFailed}}).Retryable(launchFailed))
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	rp := &RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	require.Equal(t, time.Second, rp.Delay(1))
+	require.Equal(t, 2*time.Second, rp.Delay(2))
+	require.Equal(t, 4*time.Second, rp.Delay(3))
+	require.Equal(t, 5*time.Second, rp.Delay(4))
+	require.Equal(t, DefaultRetryBackoff, (&RetryPolicy{}).Delay(1))
+}
+
+func TestRetry(t *testing.T) {
+	rp := &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Statuses: []string{"FAULT"}}
+
+	attempts, err := Retry(rp, func(n int) (*RunAttempt, error) {
+		if n < 2 {
+			return &RunAttempt{TaskStatus: TaskFailed, Status: "FAULT"}, nil
+		}
+
+		return &RunAttempt{TaskStatus: TaskSucceeded}, nil
+	})
+	require.Nil(t, err)
+	require.Len(t, attempts, 2)
+	require.Equal(t, 2, attempts[1].Attempt)
+
+	attempts, err = Retry(rp, func(n int) (*RunAttempt, error) {
+		return &RunAttempt{TaskStatus: TaskFailed, Status: "FAULT"}, nil
+	})
+	require.Nil(t, err)
+	require.Len(t, attempts, 3)
+
+	attempts, err = Retry(nil, func(n int) (*RunAttempt, error) {
+		return &RunAttempt{TaskStatus: TaskFailed, Status: "FAULT"}, nil
+	})
+	require.Nil(t, err)
+	require.Len(t, attempts, 1)
+
+	attempts, err = Retry(rp, func(n int) (*RunAttempt, error) {
+		return nil, errors.New("launch failed")
+	})
+	require.EqualError(t, err, "launch failed")
+	require.Empty(t, attempts)
+}
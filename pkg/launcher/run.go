@@ -0,0 +1,136 @@
+package launcher
+
+import (
+	"time"
+
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+)
+
+// RunParams the options of a run which are shared by the backends
+type RunParams struct {
+	// the batch key used to poll the status of the tasks
+	BatchKey string
+
+	FieldMapping     *cwlogs.FieldMapping
+	PollInterval     time.Duration
+	DrainQuietPeriod time.Duration
+
+	// optional, renew the lease on the tasks while they run
+	Lease *LeaseConfig
+	// optional, stop the tasks when the process is interrupted
+	StopOnInterrupt bool
+	// optional, defaults to DefaultStopTimeout
+	StopTimeout time.Duration
+	// optional, defaults to NotifyInterrupt
+	NotifyInterrupt NotifyFunc
+
+	// optional, run the task again when it fails for one of the retryable reasons
+	RetryPolicy *RetryPolicy
+}
+
+// RunLaunch the tasks started by an attempt
+type RunLaunch struct {
+	// the ID of the task whose logs are followed, this is used to label the log lines
+	TaskID string
+	// all the tasks which are waited for
+	IDs []string
+
+	Reader cwlogs.LogsReader
+}
+
+// RunBackend the launcher operations used by each attempt of a run, the backend keeps the tasks of the current attempt
+type RunBackend interface {
+	Launch() (*RunLaunch, error)
+	RenewLease(expires time.Time) error
+	Stop() error
+	// Complete called once the launched tasks are no longer followed, with the error which ended following
+	Complete(ftr *FollowTaskResult, err error) (*RunAttempt, error)
+}
+
+// RunTask launch and follow the tasks of each attempt until one succeeds or fails for a reason which isn't retryable
+func RunTask(p *Poller, rb RunBackend, rp *RunParams, sinks ...cwlogs.LogSink) ([]*RunAttempt, error) {
+
+	var launchErr error
+
+	attempts, err := Retry(rp.RetryPolicy, func(attempt int) (*RunAttempt, error) {
+		launchErr = nil
+
+		rl, err := rb.Launch()
+		if err != nil {
+			launchErr = err
+			return LaunchFailedAttempt(err), nil
+		}
+
+		return runAttempt(p, rb, rp, rl, attempt, sinks...)
+	})
+	// the launch error is returned when the last attempt couldn't be launched
+	if launchErr != nil {
+		err = launchErr
+	}
+
+	return attempts, err
+}
+
+// runAttempt follow the logs of the launched tasks until they complete
+func runAttempt(p *Poller, rb RunBackend, rp *RunParams, rl *RunLaunch, attempt int, sinks ...cwlogs.LogSink) (*RunAttempt, error) {
+
+	follow := &cwlogs.FollowParams{
+		TaskID:           rl.TaskID,
+		FieldMapping:     rp.FieldMapping,
+		PollInterval:     rp.PollInterval,
+		DrainQuietPeriod: rp.DrainQuietPeriod,
+	}
+
+	if rp.RetryPolicy != nil {
+		follow.Attempt = attempt
+	}
+
+	if attempt > 1 {
+		err := WriteAttemptBoundary(attempt, rl.TaskID, sinks...)
+		if err != nil {
+			return rb.Complete(nil, err)
+		}
+	}
+
+	if rp.Lease != nil {
+		renewer := StartRenewer(rp.Lease, rb.RenewLease)
+		defer renewer.Stop()
+	}
+
+	var interrupted <-chan struct{}
+
+	if rp.StopOnInterrupt {
+		notify := rp.NotifyInterrupt
+		if notify == nil {
+			notify = NotifyInterrupt
+		}
+
+		ch, release := notify()
+		defer release()
+
+		interrupted = ch
+	}
+
+	followRes, err := FollowTask(p, &FollowTaskParams{
+		WaitOrStopParams: WaitOrStopParams{
+			BatchKey:    rp.BatchKey,
+			IDs:         rl.IDs,
+			Interrupted: interrupted,
+			Stop:        rb.Stop,
+			StopTimeout: rp.StopTimeout,
+		},
+		Reader: rl.Reader,
+		Follow: follow,
+	}, sinks...)
+
+	ra, err := rb.Complete(followRes, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if followRes.Stopped {
+		return ra, ErrInterrupted
+	}
+
+	return ra, nil
+}
@@ -0,0 +1,89 @@
+package launcher
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+)
+
+// recordingBackend fails the first launch, then launches a task which succeeds
+type recordingBackend struct {
+	p        *Poller
+	launches int
+	complete []*FollowTaskResult
+}
+
+func (rb *recordingBackend) Launch() (*RunLaunch, error) {
+	rb.launches++
+
+	if rb.launches == 1 {
+		return nil, errors.New("capacity is unavailable")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		rb.p.Publish(&StatusUpdate{ID: "abc2", TaskStatus: TaskSucceeded})
+	}()
+
+	return &RunLaunch{
+		TaskID: "abc2",
+		IDs:    []string{"abc2"},
+		Reader: &pagedReader{pages: [][]string{{"passed"}}},
+	}, nil
+}
+
+func (rb *recordingBackend) RenewLease(expires time.Time) error {
+	return nil
+}
+
+func (rb *recordingBackend) Stop() error {
+	return nil
+}
+
+func (rb *recordingBackend) Complete(ftr *FollowTaskResult, err error) (*RunAttempt, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	rb.complete = append(rb.complete, ftr)
+
+	return &RunAttempt{ID: "abc2", TaskStatus: TaskSucceeded, LogLineCount: ftr.LineCount}, nil
+}
+
+func TestRunTask(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+	rb := &recordingBackend{p: p}
+
+	buf := new(bytes.Buffer)
+
+	attempts, err := RunTask(p, rb, &RunParams{
+		PollInterval:     5 * time.Millisecond,
+		DrainQuietPeriod: 5 * time.Millisecond,
+		RetryPolicy:      &RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, Statuses: []string{StatusLaunchFailed}},
+	}, cwlogs.NewTextSink(buf, ""))
+	require.Nil(t, err)
+	require.Len(t, attempts, 2)
+	require.Equal(t, StatusLaunchFailed, attempts[0].Status)
+	require.Equal(t, "capacity is unavailable", attempts[0].Reason)
+	require.Equal(t, TaskSucceeded, attempts[1].TaskStatus)
+	require.Equal(t, int64(1), attempts[1].LogLineCount)
+	require.Len(t, rb.complete, 1)
+	require.Equal(t, "--- attempt 2: abc2\npassed\n", buf.String())
+}
+
+func TestRunTask_LaunchFailed(t *testing.T) {
+
+	p := NewPoller(&runningFetcher{}, &PollerConfig{MinInterval: time.Hour})
+	rb := &recordingBackend{p: p}
+
+	// without a retry policy the launch error is returned
+	attempts, err := RunTask(p, rb, &RunParams{})
+	require.EqualError(t, err, "capacity is unavailable")
+	require.Len(t, attempts, 1)
+	require.Empty(t, rb.complete)
+}
@@ -1,7 +1,12 @@
 package service
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/wolfeidau/aws-launch/pkg/cwlogs"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
 	"github.com/wolfeidau/aws-launch/pkg/launcher/codebuild"
 	"github.com/wolfeidau/aws-launch/pkg/launcher/ecs"
 )
@@ -19,3 +24,220 @@ func New(cfgs ...*aws.Config) *Dispatcher {
 		Codebuild: codebuild.NewLauncher(cfgs...),
 	}
 }
+
+// NewForHandle create a service dispatcher in the region the task was launched
+func NewForHandle(handle string, cfgs ...*aws.Config) (*Dispatcher, error) {
+	th, err := launcher.DecodeTaskHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if th.Region != "" {
+		cfgs = append(cfgs, &aws.Config{Region: aws.String(th.Region)})
+	}
+
+	return New(cfgs...), nil
+}
+
+// GetTaskStatusParams get the status of the task with the handle returned by launch task
+type GetTaskStatusParams struct {
+	Handle string `json:"handle,omitempty" jsonschema:"required"`
+}
+
+// WaitForTaskParams wait for the task with the handle returned by launch task
+type WaitForTaskParams struct {
+	Handle string `json:"handle,omitempty" jsonschema:"required"`
+
+	// optional, defaults to launcher.DefaultMaxWait
+	MaxWait time.Duration `json:"max_wait,omitempty"`
+}
+
+// StopTaskParams stop the task with the handle returned by launch task
+type StopTaskParams struct {
+	Handle string `json:"handle,omitempty" jsonschema:"required"`
+
+	// optional, recorded as the stopped reason of ECS tasks
+	Reason string `json:"reason,omitempty"`
+	// optional, wait for the task to stop
+	Wait bool `json:"wait,omitempty"`
+	// optional, defaults to launcher.DefaultStopTimeout
+	WaitTimeout time.Duration `json:"wait_timeout,omitempty"`
+}
+
+// TaskStatusResult the status of a task in either backend
+type TaskStatusResult struct {
+	Handle  string `json:"handle,omitempty"`
+	Backend string `json:"backend,omitempty"`
+
+	ID         string     `json:"id,omitempty"`
+	TaskStatus string     `json:"task_status,omitempty"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+	ExitCode   *int64     `json:"exit_code,omitempty"`
+}
+
+// GetTaskLogsParams get a page of logs for the task with the handle returned by launch task
+type GetTaskLogsParams struct {
+	Handle    string  `json:"handle,omitempty" jsonschema:"required"`
+	NextToken *string `json:"next_token,omitempty"`
+
+	// optional, read from the start of the stream when no next token is supplied
+	StartFromHead bool `json:"start_from_head,omitempty"`
+
+	// optional, parse each message as JSON with this mapping
+	FieldMapping *cwlogs.FieldMapping `json:"field_mapping,omitempty"`
+}
+
+// GetTaskLogsResult a page of logs for the task
+type GetTaskLogsResult struct {
+	LogLines  []*cwlogs.LogLine `json:"log_lines,omitempty"`
+	NextToken *string           `json:"next_token,omitempty"`
+}
+
+// GetTaskStatus get the status of the task from the backend in the handle
+func (d *Dispatcher) GetTaskStatus(gts *GetTaskStatusParams) (*TaskStatusResult, error) {
+
+	th, err := launcher.DecodeTaskHandle(gts.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TaskStatusResult{Handle: gts.Handle, Backend: th.Backend}
+
+	switch th.Backend {
+	case launcher.BackendECS:
+		statusRes, err := d.ECS.GetTaskStatus(&ecs.GetTaskStatusParams{Handle: gts.Handle})
+		if err != nil {
+			return nil, err
+		}
+
+		res.ID = statusRes.ID
+		res.TaskStatus = statusRes.TaskStatus
+		res.StartTime = statusRes.StartTime
+		res.EndTime = statusRes.EndTime
+	case launcher.BackendCodebuild:
+		statusRes, err := d.Codebuild.GetTaskStatus(&codebuild.GetTaskStatusParams{Handle: gts.Handle})
+		if err != nil {
+			return nil, err
+		}
+
+		res.ID = statusRes.ID
+		res.TaskStatus = statusRes.TaskStatus
+		res.StartTime = statusRes.StartTime
+		res.EndTime = statusRes.EndTime
+	default:
+		return nil, errors.Wrapf(launcher.ErrInvalidTaskHandle, "unknown backend %s", th.Backend)
+	}
+
+	return res, nil
+}
+
+// WaitForTask wait for the task to complete then return its final status
+func (d *Dispatcher) WaitForTask(wft *WaitForTaskParams) (*TaskStatusResult, error) {
+
+	th, err := launcher.DecodeTaskHandle(wft.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	switch th.Backend {
+	case launcher.BackendECS:
+		_, err = d.ECS.WaitForTask(&ecs.WaitForTaskParams{Handle: wft.Handle, MaxWait: wft.MaxWait})
+	case launcher.BackendCodebuild:
+		_, err = d.Codebuild.WaitForTask(&codebuild.WaitForTaskParams{Handle: wft.Handle, MaxWait: wft.MaxWait})
+	default:
+		return nil, errors.Wrapf(launcher.ErrInvalidTaskHandle, "unknown backend %s", th.Backend)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTaskStatus(&GetTaskStatusParams{Handle: wft.Handle})
+}
+
+// StopTask stop the task, optionally waiting for it to stop
+func (d *Dispatcher) StopTask(stp *StopTaskParams) (*TaskStatusResult, error) {
+
+	th, err := launcher.DecodeTaskHandle(stp.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TaskStatusResult{Handle: stp.Handle, Backend: th.Backend}
+
+	switch th.Backend {
+	case launcher.BackendECS:
+		stopRes, err := d.ECS.StopTask(&ecs.StopTaskParams{
+			Handle:      stp.Handle,
+			Reason:      stp.Reason,
+			Wait:        stp.Wait,
+			WaitTimeout: stp.WaitTimeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res.ID = stopRes.ID
+		res.TaskStatus = stopRes.TaskStatus
+		res.EndTime = stopRes.EndTime
+		res.ExitCode = stopRes.ExitCode
+	case launcher.BackendCodebuild:
+		stopRes, err := d.Codebuild.StopTask(&codebuild.StopTaskParams{
+			Handle:      stp.Handle,
+			Reason:      stp.Reason,
+			Wait:        stp.Wait,
+			WaitTimeout: stp.WaitTimeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res.ID = stopRes.ID
+		res.TaskStatus = stopRes.TaskStatus
+		res.EndTime = stopRes.EndTime
+		res.ExitCode = stopRes.ExitCode
+	default:
+		return nil, errors.Wrapf(launcher.ErrInvalidTaskHandle, "unknown backend %s", th.Backend)
+	}
+
+	return res, nil
+}
+
+// GetTaskLogs get a page of logs for the task
+func (d *Dispatcher) GetTaskLogs(gtlp *GetTaskLogsParams) (*GetTaskLogsResult, error) {
+
+	th, err := launcher.DecodeTaskHandle(gtlp.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	switch th.Backend {
+	case launcher.BackendECS:
+		logsRes, err := d.ECS.GetTaskLogs(&ecs.GetTaskLogsParams{
+			Handle:        gtlp.Handle,
+			NextToken:     gtlp.NextToken,
+			StartFromHead: gtlp.StartFromHead,
+			FieldMapping:  gtlp.FieldMapping,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &GetTaskLogsResult{LogLines: logsRes.LogLines, NextToken: logsRes.NextToken}, nil
+	case launcher.BackendCodebuild:
+		logsRes, err := d.Codebuild.GetTaskLogs(&codebuild.GetTaskLogsParams{
+			Handle:        gtlp.Handle,
+			NextToken:     gtlp.NextToken,
+			StartFromHead: gtlp.StartFromHead,
+			FieldMapping:  gtlp.FieldMapping,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &GetTaskLogsResult{LogLines: logsRes.LogLines, NextToken: logsRes.NextToken}, nil
+	}
+
+	return nil, errors.Wrapf(launcher.ErrInvalidTaskHandle, "unknown backend %s", th.Backend)
+}
@@ -3,7 +3,14 @@ package service
 import (
 	"testing"
 
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/wolfeidau/aws-launch/mocks/codebuildmock"
+	"github.com/wolfeidau/aws-launch/mocks/ecsmock"
+	"github.com/wolfeidau/aws-launch/pkg/launcher"
+	"github.com/wolfeidau/aws-launch/pkg/launcher/codebuild"
+	"github.com/wolfeidau/aws-launch/pkg/launcher/ecs"
 )
 
 func Test_New(t *testing.T) {
@@ -11,3 +18,70 @@ func Test_New(t *testing.T) {
 	got := New(nil)
 	require.NotNil(t, got)
 }
+
+func Test_NewForHandle(t *testing.T) {
+
+	got, err := NewForHandle((&launcher.TaskHandle{Backend: launcher.BackendECS, Region: "ap-southeast-2", ID: "abc1"}).Encode())
+	require.Nil(t, err)
+	require.NotNil(t, got)
+
+	_, err = NewForHandle("not a handle")
+	require.Equal(t, launcher.ErrInvalidTaskHandle, err)
+}
+
+func TestDispatcher_GetTaskStatus(t *testing.T) {
+
+	ecsHandle := (&launcher.TaskHandle{Backend: launcher.BackendECS, Cluster: "test", ID: "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1"}).Encode()
+	codebuildHandle := (&launcher.TaskHandle{Backend: launcher.BackendCodebuild, Project: "testing-1", ID: "testing-1:abc1"}).Encode()
+
+	ecsMock := &ecsmock.LauncherAPI{}
+	codebuildMock := &codebuildmock.LauncherAPI{}
+
+	ecsMock.On("GetTaskStatus", &ecs.GetTaskStatusParams{Handle: ecsHandle}).Return(&ecs.GetTaskStatusResult{
+		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1",
+		TaskStatus: launcher.TaskRunning,
+	}, nil)
+	codebuildMock.On("GetTaskStatus", &codebuild.GetTaskStatusParams{Handle: codebuildHandle}).Return(&codebuild.GetTaskStatusResult{
+		ID:         "testing-1:abc1",
+		TaskStatus: launcher.TaskSucceeded,
+	}, nil)
+
+	d := &Dispatcher{ECS: ecsMock, Codebuild: codebuildMock}
+
+	got, err := d.GetTaskStatus(&GetTaskStatusParams{Handle: ecsHandle})
+	require.Nil(t, err)
+	require.Equal(t, &TaskStatusResult{
+		Handle:     ecsHandle,
+		Backend:    launcher.BackendECS,
+		ID:         "arn:aws:ecs:ap-southeast-2:123456789012:task/test/abc1",
+		TaskStatus: launcher.TaskRunning,
+	}, got)
+
+	got, err = d.GetTaskStatus(&GetTaskStatusParams{Handle: codebuildHandle})
+	require.Nil(t, err)
+	require.Equal(t, launcher.BackendCodebuild, got.Backend)
+	require.Equal(t, launcher.TaskSucceeded, got.TaskStatus)
+
+	_, err = d.GetTaskStatus(&GetTaskStatusParams{Handle: (&launcher.TaskHandle{Backend: "batch", ID: "abc1"}).Encode()})
+	require.Equal(t, launcher.ErrInvalidTaskHandle, errors.Cause(err))
+}
+
+func TestDispatcher_StopTask(t *testing.T) {
+
+	handle := (&launcher.TaskHandle{Backend: launcher.BackendCodebuild, Project: "testing-1", ID: "testing-1:abc1"}).Encode()
+
+	codebuildMock := &codebuildmock.LauncherAPI{}
+
+	codebuildMock.On("StopTask", mock.MatchedBy(func(stp *codebuild.StopTaskParams) bool {
+		return stp.Handle == handle && stp.Reason == "cancelled" && stp.Wait
+	})).Return(&codebuild.StopTaskResult{
+		ID:         "testing-1:abc1",
+		TaskStatus: launcher.TaskFailed,
+	}, nil)
+
+	d := &Dispatcher{Codebuild: codebuildMock}
+
+	got, err := d.StopTask(&StopTaskParams{Handle: handle, Reason: "cancelled", Wait: true})
+	require.Nil(t, err)
+	require.Equal(t, launcher.TaskFailed, got.TaskStatus)
+}
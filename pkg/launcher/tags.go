@@ -0,0 +1,72 @@
+package launcher
+
+import (
+	"strings"
+	"time"
+)
+
+// OwnershipTags merge the ownership tags over the supplied tags, these can't be overridden as they are
+// used by the reaper to find the resources created by the launchers
+func OwnershipTags(tags map[string]string) map[string]string {
+	merged := map[string]string{}
+
+	for k, v := range tags {
+		merged[k] = v
+	}
+
+	merged[CreatedByTagKey] = CreatedByTagValue
+
+	return merged
+}
+
+// IsOwned the tags include the ownership tags
+func IsOwned(tags map[string]string) bool {
+	return tags[CreatedByTagKey] == CreatedByTagValue
+}
+
+// StripOwnershipTags remove the tags added by the launchers, including project lease tags, nil is returned if no other tags remain
+func StripOwnershipTags(tags map[string]string) map[string]string {
+	stripped := map[string]string{}
+
+	for k, v := range tags {
+		if (k == CreatedByTagKey && v == CreatedByTagValue) || k == DefinedAtTagKey || k == LogGroupFormatTagKey || strings.HasPrefix(k, LeaseExpiresTagKey+":") {
+			continue
+		}
+
+		stripped[k] = v
+	}
+
+	if len(stripped) == 0 {
+		return nil
+	}
+
+	return stripped
+}
+
+// logGroupFormatPlaceholder replaces %s in the tag value as % isn't a valid tag character, @ can't appear in log group names
+const logGroupFormatPlaceholder = "@"
+
+// LogGroupFormatTag the tag value recording the log group name format
+func LogGroupFormatTag(nameFormat string) string {
+	return strings.Replace(nameFormat, "%s", logGroupFormatPlaceholder, 1)
+}
+
+// LogGroupFormat the log group name format recorded in the tags, empty if the default format was used
+func LogGroupFormat(tags map[string]string) string {
+	v, ok := tags[LogGroupFormatTagKey]
+	if !ok {
+		return ""
+	}
+
+	return strings.Replace(v, logGroupFormatPlaceholder, "%s", 1)
+}
+
+// DefinedAt the time recorded in the defined at tag, nil if it is missing or invalid
+func DefinedAt(tags map[string]string) *time.Time {
+	t, err := time.Parse(time.RFC3339, tags[DefinedAtTagKey])
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,29 @@
+package launcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipTags(t *testing.T) {
+	tags := OwnershipTags(map[string]string{"team": "build", CreatedByTagKey: "someone"})
+	require.Equal(t, map[string]string{"team": "build", CreatedByTagKey: CreatedByTagValue}, tags)
+	require.True(t, IsOwned(tags))
+	require.False(t, IsOwned(map[string]string{"team": "build"}))
+
+	tags[DefinedAtTagKey] = "2020-01-02T03:04:05Z"
+	require.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), DefinedAt(tags).UTC())
+	require.Equal(t, map[string]string{"team": "build"}, StripOwnershipTags(tags))
+	require.Nil(t, StripOwnershipTags(OwnershipTags(nil)))
+	require.Nil(t, DefinedAt(map[string]string{}))
+}
+
+func TestLogGroupFormat(t *testing.T) {
+	tags := map[string]string{LogGroupFormatTagKey: LogGroupFormatTag("/ci/%s/logs")}
+	require.Equal(t, "/ci/@/logs", tags[LogGroupFormatTagKey])
+	require.Equal(t, "/ci/%s/logs", LogGroupFormat(tags))
+	require.Equal(t, "", LogGroupFormat(map[string]string{}))
+	require.Nil(t, StripOwnershipTags(tags))
+}
@@ -0,0 +1,114 @@
+package launcher
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultWatchBuffer the number of status changes buffered for a slow receiver
+const DefaultWatchBuffer = 16
+
+// StatusChange a transition in the status of a task
+type StatusChange struct {
+	ID        string    `json:"id,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+
+	PreviousTaskStatus string `json:"previous_task_status,omitempty"`
+	TaskStatus         string `json:"task_status,omitempty"`
+	PreviousLastStatus string `json:"previous_last_status,omitempty"`
+	LastStatus         string `json:"last_status,omitempty"`
+
+	StopCode  string     `json:"stop_code,omitempty"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	// backend specific details such as the codebuild phase or the state of each ECS container
+	Details map[string]string `json:"details,omitempty"`
+
+	// set when the status can no longer be retrieved, this is the last change sent
+	Err error `json:"-"`
+}
+
+// Watch subscribe to the poller and send a change each time the status or details of the task differ from the
+// last update, the channel is closed once the task reaches a terminal state or the returned stop func is called
+func Watch(p *Poller, batchKey, id string) (<-chan *StatusChange, func()) {
+	changes := make(chan *StatusChange, DefaultWatchBuffer)
+	stopCh := make(chan struct{})
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(stopCh) })
+	}
+
+	sub := p.Subscribe(batchKey, id)
+
+	go func() {
+		defer close(changes)
+		defer sub.Cancel()
+
+		var prev *StatusUpdate
+
+		for {
+			var su *StatusUpdate
+
+			select {
+			case <-stopCh:
+				return
+			case su = <-sub.C:
+			}
+
+			change := statusChange(prev, su)
+
+			if change != nil {
+				select {
+				case changes <- change:
+				case <-stopCh:
+					return
+				}
+			}
+
+			if su.Err != nil || su.Done() {
+				return
+			}
+
+			prev = su
+		}
+	}()
+
+	return changes, stop
+}
+
+// statusChange returns nil when nothing has changed since the previous update, the change is timestamped with
+// the time the backend recorded the status, falling back to now when the backend didn't provide one
+func statusChange(prev, su *StatusUpdate) *StatusChange {
+	ts := time.Now().UTC()
+	if su.Timestamp != nil {
+		ts = su.Timestamp.UTC()
+	}
+
+	change := &StatusChange{
+		ID:         su.ID,
+		Timestamp:  ts,
+		TaskStatus: su.TaskStatus,
+		LastStatus: su.LastStatus,
+		StopCode:   su.StopCode,
+		StartTime:  su.StartTime,
+		EndTime:    su.EndTime,
+		Details:    su.Details,
+		Err:        su.Err,
+	}
+
+	if su.Err != nil || prev == nil {
+		return change
+	}
+
+	if prev.TaskStatus == su.TaskStatus && prev.LastStatus == su.LastStatus && reflect.DeepEqual(prev.Details, su.Details) {
+		return nil
+	}
+
+	change.PreviousTaskStatus = prev.TaskStatus
+	change.PreviousLastStatus = prev.LastStatus
+
+	return change
+}
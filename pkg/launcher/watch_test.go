@@ -0,0 +1,98 @@
+package launcher
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceFetcher struct {
+	mu      sync.Mutex
+	updates []*StatusUpdate
+}
+
+func (sf *sequenceFetcher) FetchStatuses(batchKey string, ids []string) ([]*StatusUpdate, error) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	su := sf.updates[0]
+	if len(sf.updates) > 1 {
+		sf.updates = sf.updates[1:]
+	}
+
+	if su.Err != nil {
+		return nil, su.Err
+	}
+
+	return []*StatusUpdate{su}, nil
+}
+
+func TestWatch(t *testing.T) {
+
+	created := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sf := &sequenceFetcher{updates: []*StatusUpdate{
+		{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "PROVISIONING", Timestamp: &created},
+		{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "PROVISIONING", Timestamp: &created},
+		{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "RUNNING", Details: map[string]string{"container/web": "RUNNING"}},
+		{ID: "abc123", TaskStatus: TaskSucceeded, LastStatus: "STOPPED", Details: map[string]string{"container/web": "STOPPED"}},
+	}}
+
+	p := NewPoller(sf, &PollerConfig{MinInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	events, stop := Watch(p, "", "abc123")
+	defer stop()
+
+	changes := []*StatusChange{}
+	for change := range events {
+		changes = append(changes, change)
+	}
+
+	require.Len(t, changes, 3)
+	require.Equal(t, "", changes[0].PreviousLastStatus)
+	require.Equal(t, created, changes[0].Timestamp)
+	require.Equal(t, "PROVISIONING", changes[0].LastStatus)
+	require.Equal(t, "PROVISIONING", changes[1].PreviousLastStatus)
+	require.Equal(t, "RUNNING", changes[1].LastStatus)
+	require.Equal(t, TaskRunning, changes[2].PreviousTaskStatus)
+	require.Equal(t, TaskSucceeded, changes[2].TaskStatus)
+	require.Equal(t, "STOPPED", changes[2].Details["container/web"])
+}
+
+func TestWatch_Error(t *testing.T) {
+
+	sf := &sequenceFetcher{updates: []*StatusUpdate{
+		{Err: errors.New("access denied")},
+	}}
+
+	p := NewPoller(sf, &PollerConfig{MinInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	events, stop := Watch(p, "", "abc123")
+	defer stop()
+
+	change := <-events
+	require.Error(t, change.Err)
+
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestWatch_Stop(t *testing.T) {
+
+	sf := &sequenceFetcher{updates: []*StatusUpdate{
+		{ID: "abc123", TaskStatus: TaskRunning, LastStatus: "RUNNING"},
+	}}
+
+	p := NewPoller(sf, &PollerConfig{MinInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	events, stop := Watch(p, "", "abc123")
+
+	<-events
+	stop()
+
+	for range events {
+	}
+}